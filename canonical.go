@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MarshalCanonical produces a JSON document with the keys of every object sorted,
+// so that the output is stable and may be compared or hashed.
+//
+// The JSON produced by the custom marshalers of spec objects is concatenated from several parts,
+// e.g. properties first, then vendor extensions. The canonical form sorts all keys alike,
+// including the "x-" keys of extensions and the keys of objects nested in their values.
+// Numbers are rendered as found in the original document.
+func MarshalCanonical(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	// maps are marshaled with sorted keys
+	return json.Marshal(generic)
+}
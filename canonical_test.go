@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestMarshalCanonical(t *testing.T) {
+	t.Run("should sort extensions with other keys", func(t *testing.T) {
+		schema := StringProperty().WithDescription("a name").WithMaxLength(80)
+		schema.AddExtension("x-order", 2)
+		schema.AddExtension("x-go-name", "Name")
+		schema.AddExtension("x-nullable", false)
+		schema.AddExtension("x-a-first", map[string]any{"z": 1, "b": []any{map[string]any{"y": 1, "x": 2}}})
+
+		b, err := MarshalCanonical(schema)
+		require.NoError(t, err)
+		assert.Equal(t,
+			`{"description":"a name","maxLength":80,"type":"string",`+
+				`"x-a-first":{"b":[{"x":2,"y":1}],"z":1},"x-go-name":"Name","x-nullable":false,"x-order":2}`,
+			string(b),
+		)
+	})
+
+	t.Run("should produce a stable output", func(t *testing.T) {
+		doc := &Swagger{SwaggerProps: SwaggerProps{OpenAPI: "3.1.0"}}
+		doc.AddExtension("x-c", 1)
+		doc.AddExtension("x-b", 1.5)
+		doc.AddExtension("x-a", uint64(12345678901234567890))
+
+		first, err := MarshalCanonical(doc)
+		require.NoError(t, err)
+
+		for range 10 {
+			again, err := MarshalCanonical(doc)
+			require.NoError(t, err)
+			require.Equal(t, string(first), string(again))
+		}
+		assert.Contains(t, string(first), `"x-a":12345678901234567890,"x-b":1.5,"x-c":1`)
+	})
+}
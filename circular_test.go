@@ -62,7 +62,7 @@ func TestExpandCircular_RefExpansion(t *testing.T) {
 	schema := partialSpec.Definitions["car"]
 	refPath := "#/definitions/car"
 
-	_, err = expandSchema(schema, []string{refPath}, resolver, normalizeBase(basePath))
+	_, err = expandSchema(schema, []string{refPath}, resolver, normalizeBase(basePath), "")
 	require.NoError(t, err)
 
 	jazon := asJSON(t, schema)
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"errors"
+	"strings"
+)
+
+const codeSamplesExtension = "x-codeSamples"
+
+// CodeSample is an example of a call to an operation, as found in the x-codeSamples extension
+// supported by most documentation renderers.
+type CodeSample struct {
+	Lang   string `json:"lang"`
+	Label  string `json:"label,omitempty"`
+	Source string `json:"source"`
+}
+
+// CodeSamples decodes the x-codeSamples extension of an operation.
+//
+// The legacy x-code-samples extension is decoded when x-codeSamples is missing.
+// An operation without code samples returns no code samples and no error.
+func (o Operation) CodeSamples() ([]CodeSample, error) {
+	var samples []CodeSample
+
+	err := o.DecodeExtension(codeSamplesExtension, &samples)
+	if errors.Is(err, ErrExtensionNotFound) {
+		err = o.DecodeExtension("x-code-samples", &samples)
+	}
+	if errors.Is(err, ErrExtensionNotFound) {
+		return nil, nil
+	}
+
+	return samples, err
+}
+
+// AddCodeSample appends a code sample to the x-codeSamples extension of an operation.
+//
+// The label may be empty, in which case renderers usually display the language.
+func (o *Operation) AddCodeSample(lang, label, source string) *Operation {
+	key := codeSamplesExtension
+	for k := range o.Extensions {
+		if strings.EqualFold(k, codeSamplesExtension) {
+			key = k
+
+			break
+		}
+	}
+
+	sample := map[string]any{"lang": lang, "source": source}
+	if label != "" {
+		sample["label"] = label
+	}
+
+	samples, _ := o.Extensions[key].([]any)
+	if o.Extensions == nil {
+		o.Extensions = make(Extensions)
+	}
+	o.Extensions[key] = append(samples, sample)
+
+	return o
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestOperation_CodeSamples(t *testing.T) {
+	t.Run("should round-trip code samples", func(t *testing.T) {
+		op := NewOperation("listPets").
+			AddCodeSample("shell", "cURL", "curl https://example.com/pets").
+			AddCodeSample("go", "", "client.ListPets(ctx)")
+
+		b, err := json.Marshal(op)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"operationId": "listPets",
+			"x-codeSamples": [
+				{"lang": "shell", "label": "cURL", "source": "curl https://example.com/pets"},
+				{"lang": "go", "source": "client.ListPets(ctx)"}
+			]
+		}`, string(b))
+
+		var decoded Operation
+		require.NoError(t, json.Unmarshal(b, &decoded))
+
+		samples, err := decoded.CodeSamples()
+		require.NoError(t, err)
+		assert.Equal(t, []CodeSample{
+			{Lang: "shell", Label: "cURL", Source: "curl https://example.com/pets"},
+			{Lang: "go", Source: "client.ListPets(ctx)"},
+		}, samples)
+	})
+
+	t.Run("should decode the legacy extension", func(t *testing.T) {
+		var op Operation
+		require.NoError(t, json.Unmarshal([]byte(`{"x-code-samples": [{"lang": "python", "source": "list_pets()"}]}`), &op))
+
+		samples, err := op.CodeSamples()
+		require.NoError(t, err)
+		assert.Equal(t, []CodeSample{{Lang: "python", Source: "list_pets()"}}, samples)
+	})
+
+	t.Run("should return no code samples when missing", func(t *testing.T) {
+		samples, err := NewOperation("listPets").CodeSamples()
+		require.NoError(t, err)
+		assert.Empty(t, samples)
+	})
+
+	t.Run("should fail on malformed code samples", func(t *testing.T) {
+		var op Operation
+		require.NoError(t, json.Unmarshal([]byte(`{"x-codeSamples": {"lang": "go"}}`), &op))
+
+		_, err := op.CodeSamples()
+		require.Error(t, err)
+	})
+}
@@ -5,6 +5,7 @@ package spec
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/go-openapi/jsonpointer"
 	"github.com/go-openapi/swag/jsonutils"
@@ -31,6 +32,7 @@ type ComponentsProps struct {
 	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
 	Links           map[string]Link           `json:"links,omitempty"`
 	Callbacks       map[string]Callback       `json:"callbacks,omitempty"`
+	PathItems       map[string]PathItem       `json:"pathItems,omitempty"`
 }
 
 // JSONLookup look up a value by the json property name
@@ -166,8 +168,28 @@ func (c *Callback) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &c.Refable); err != nil {
 		return err
 	}
-	if err := json.Unmarshal(data, &c.Expressions); err != nil {
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
 		return err
 	}
+
+	c.Expressions = nil
+	for key, field := range fields {
+		// the $ref and the vendor extensions are not expressions
+		if key == "$ref" || strings.HasPrefix(strings.ToLower(key), "x-") {
+			continue
+		}
+
+		var item PathItem
+		if err := json.Unmarshal(field, &item); err != nil {
+			return err
+		}
+		if c.Expressions == nil {
+			c.Expressions = make(map[string]PathItem, len(fields))
+		}
+		c.Expressions[key] = item
+	}
+
 	return json.Unmarshal(data, &c.VendorExtensible)
 }
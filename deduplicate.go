@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// DeduplicateComponents collapses the structurally identical schemas of the components
+// (or of definitions, with Swagger 2.0 documents) into a single one.
+//
+// Schemas are identical when they only differ by their "title" and "description".
+// The canonical schema of a group of identical schemas is the one with the smallest name:
+// the others are removed, and all the local $ref's to them are rewritten to the canonical schema.
+// Since rewriting $ref's may make other schemas identical, this repeats until no more schemas are collapsed.
+//
+// It returns a map of the removed names to their canonical name.
+func (s *Swagger) DeduplicateComponents() (map[string]string, error) {
+	schemas, section := s.Definitions, pointerTo("definitions")
+	if s.Components != nil {
+		schemas, section = s.Components.Schemas, pointerTo("components", "schemas")
+	}
+
+	renames := make(map[string]string)
+	for {
+		collapsed, err := identicalSchemas(schemas)
+		if err != nil {
+			return nil, err
+		}
+		if len(collapsed) == 0 {
+			return renames, nil
+		}
+
+		for name, canonical := range collapsed {
+			renames[name] = canonical
+		}
+		// keep the rename map flat when a canonical schema gets collapsed in a later pass
+		for name, canonical := range renames {
+			if next, ok := collapsed[canonical]; ok {
+				renames[name] = next
+			}
+		}
+
+		if err := s.rewriteSchemaRefs(section, collapsed); err != nil {
+			return nil, err
+		}
+
+		schemas = s.Definitions
+		if s.Components != nil {
+			schemas = s.Components.Schemas
+		}
+	}
+}
+
+// identicalSchemas maps the names of the duplicated schemas to the name of their canonical schema.
+func identicalSchemas(schemas map[string]Schema) (map[string]string, error) {
+	collapsed := make(map[string]string)
+	canonicals := make(map[string]string, len(schemas))
+
+	for _, name := range slices.Sorted(maps.Keys(schemas)) {
+		schema := schemas[name]
+		schema.Title = ""
+		schema.Description = ""
+
+		key, err := MarshalCanonical(schema)
+		if err != nil {
+			return nil, err
+		}
+
+		if canonical, isDuplicate := canonicals[string(key)]; isDuplicate {
+			collapsed[name] = canonical
+
+			continue
+		}
+		canonicals[string(key)] = name
+	}
+
+	return collapsed, nil
+}
+
+// rewriteSchemaRefs removes the collapsed schemas from the document and redirects the $ref's
+// to them, including $ref's to their nested schemas.
+func (s *Swagger) rewriteSchemaRefs(section string, collapsed map[string]string) error {
+	return s.rewriteGeneric(func(doc any) error {
+		schemas, _ := lookupGeneric(doc, section).(map[string]any)
+		for name := range collapsed {
+			delete(schemas, name)
+		}
+
+		rewriteRefs(doc, componentRefRewriter(section, collapsed))
+
+		return nil
+	})
+}
+
+// rewriteGeneric edits the generic JSON form of the document, then decodes the document back from it.
+//
+// The document is left untouched when edit fails.
+func (s *Swagger) rewriteGeneric(edit func(doc any) error) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return err
+	}
+
+	if err := edit(doc); err != nil {
+		return err
+	}
+
+	b, err = json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	var rewritten Swagger
+	if err := json.Unmarshal(b, &rewritten); err != nil {
+		return err
+	}
+	*s = rewritten
+
+	return nil
+}
+
+// componentRefRewriter redirects the local $ref's to the renamed components of a section,
+// including $ref's to their nested values.
+func componentRefRewriter(section string, renames map[string]string) func(string) string {
+	prefix := "#" + section + "/"
+
+	return func(ref string) string {
+		name, rest, _ := strings.Cut(strings.TrimPrefix(ref, prefix), "/")
+		renamed, ok := renames[UnescapeJSONPointerToken(name)]
+		if !strings.HasPrefix(ref, prefix) || !ok {
+			return ref
+		}
+
+		if rest != "" {
+			return prefix + EscapeJSONPointerToken(renamed) + "/" + rest
+		}
+
+		return prefix + EscapeJSONPointerToken(renamed)
+	}
+}
+
+// lookupGeneric returns the value of a generic JSON document at a JSON pointer, or nil.
+func lookupGeneric(doc any, pointer string) any {
+	for _, token := range SplitPointer(pointer) {
+		object, ok := doc.(map[string]any)
+		if !ok {
+			return nil
+		}
+		doc = object[token]
+	}
+
+	return doc
+}
+
+// payloadKeywords are the keywords holding instances rather than spec objects, e.g. the value of an example:
+// a "$ref" key in these is plain data.
+var payloadKeywords = map[string]bool{
+	"example": true,
+	"default": true,
+	"const":   true,
+	"enum":    true,
+	"value":   true,
+}
+
+// namedKeywords are the keywords holding maps keyed by names, e.g. properties or response codes, where a name
+// may collide with a keyword.
+var namedKeywords = map[string]bool{
+	"definitions":         true,
+	"securityDefinitions": true,
+	"schemas":             true,
+	"parameters":          true,
+	"responses":           true,
+	"requestBodies":       true,
+	"headers":             true,
+	"examples":            true,
+	"securitySchemes":     true,
+	"links":               true,
+	"callbacks":           true,
+	"pathItems":           true,
+	"paths":               true,
+	"webhooks":            true,
+	"content":             true,
+	"encoding":            true,
+	"variables":           true,
+	"properties":          true,
+	"patternProperties":   true,
+	"dependentSchemas":    true,
+	"$defs":               true,
+}
+
+// rewriteRefs replaces in place all the "$ref" values of a generic JSON document.
+//
+// Examples, defaults, constants and enums are left untouched, even when they hold a "$ref" key.
+func rewriteRefs(doc any, rewrite func(string) string) {
+	rewriteNestedRefs(doc, rewrite, false)
+}
+
+// rewriteNestedRefs rewrites the "$ref" values of a generic JSON value. named tells whether the keys of an object
+// are names rather than keywords.
+func rewriteNestedRefs(doc any, rewrite func(string) string, named bool) {
+	switch value := doc.(type) {
+	case map[string]any:
+		for key, nested := range value {
+			if named {
+				rewriteNestedRefs(nested, rewrite, false)
+
+				continue
+			}
+
+			if ref, isRef := nested.(string); isRef && key == "$ref" {
+				value[key] = rewrite(ref)
+
+				continue
+			}
+
+			switch _, isArray := nested.([]any); {
+			case payloadKeywords[key]:
+			case key == "examples" && isArray:
+				// the examples of a JSON schema, rather than a map of example objects
+			default:
+				rewriteNestedRefs(nested, rewrite, namedKeywords[key])
+			}
+		}
+	case []any:
+		for _, nested := range value {
+			rewriteNestedRefs(nested, rewrite, false)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSwagger_DeduplicateComponents(t *testing.T) {
+	t.Run("should merge identical schemas and rewrite their $ref's", func(t *testing.T) {
+		var doc Swagger
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"openapi": "3.1.0",
+			"components": {
+				"schemas": {
+					"Pet": {"title": "Pet", "type": "object", "properties": {"name": {"type": "string"}}},
+					"Animal": {"description": "an animal", "type": "object", "properties": {"name": {"type": "string"}}},
+					"Owner": {"type": "object", "properties": {"pet": {"$ref": "#/components/schemas/Pet"}}},
+					"Keeper": {"type": "object", "properties": {"pet": {"$ref": "#/components/schemas/Animal"}}},
+					"Name": {"$ref": "#/components/schemas/Pet/properties/name"}
+				}
+			},
+			"paths": {
+				"/pets": {
+					"get": {
+						"responses": {
+							"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}
+						}
+					}
+				}
+			}
+		}`), &doc))
+
+		renames, err := doc.DeduplicateComponents()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"Pet": "Animal", "Owner": "Keeper"}, renames)
+
+		require.NotNil(t, doc.Components)
+		assert.Len(t, doc.Components.Schemas, 3)
+		assert.NotContains(t, doc.Components.Schemas, "Pet")
+		assert.NotContains(t, doc.Components.Schemas, "Owner")
+		assert.Len(t, doc.Definitions, 3)
+
+		name := doc.Components.Schemas["Name"]
+		assert.Equal(t, "#/components/schemas/Animal/properties/name", name.Ref.String())
+		keeper := doc.Components.Schemas["Keeper"].Properties["pet"]
+		assert.Equal(t, "#/components/schemas/Animal", keeper.Ref.String())
+
+		schema := doc.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Content["application/json"].Schema
+		require.NotNil(t, schema)
+		assert.Equal(t, "#/components/schemas/Animal", schema.Ref.String())
+	})
+
+	t.Run("should merge definitions of Swagger 2.0 documents", func(t *testing.T) {
+		var doc Swagger
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"swagger": "2.0",
+			"definitions": {
+				"a~b": {"type": "string"},
+				"c": {"type": "string", "title": "c"}
+			},
+			"paths": {
+				"/c": {"get": {"parameters": [{"in": "body", "name": "body", "schema": {"$ref": "#/definitions/c"}}]}}
+			}
+		}`), &doc))
+
+		renames, err := doc.DeduplicateComponents()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"c": "a~b"}, renames)
+		assert.Len(t, doc.Definitions, 1)
+
+		param := doc.Paths.Paths["/c"].Get.Parameters[0]
+		require.NotNil(t, param.Schema)
+		assert.Equal(t, "#/definitions/a~0b", param.Schema.Ref.String())
+	})
+
+	t.Run("should leave distinct schemas alone", func(t *testing.T) {
+		var doc Swagger
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"openapi": "3.1.0",
+			"components": {"schemas": {"a": {"type": "string"}, "b": {"type": "integer"}}}
+		}`), &doc))
+
+		renames, err := doc.DeduplicateComponents()
+		require.NoError(t, err)
+		assert.Empty(t, renames)
+		assert.Len(t, doc.Components.Schemas, 2)
+	})
+}
@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// Change describes a difference found between two versions of a spec.
+type Change struct {
+	// Pointer locates the change, as a JSON pointer in the revised spec
+	Pointer string
+	// Message explains the change
+	Message string
+	// Breaking is true when clients of the base version may fail with the revised version
+	Breaking bool
+}
+
+func (c Change) String() string {
+	return c.Pointer + ": " + c.Message
+}
+
+// Diff holds the changes between two versions of a spec.
+type Diff struct {
+	Changes []Change
+
+	base, revision *Swagger // roots to resolve $ref's against
+}
+
+// Breaking returns the changes which may break clients of the base version.
+func (d *Diff) Breaking() []Change {
+	var breaking []Change
+	for _, change := range d.Changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		}
+	}
+
+	return breaking
+}
+
+// position tells whether a schema describes data sent by clients or data received by clients.
+//
+// The same change to a schema may break clients in one position, but not in the other:
+// e.g. returning fewer enum values in a response breaks consumers relying on them, whereas
+// a request accepting fewer values is not considered breaking.
+type position int
+
+const (
+	requestPosition position = iota
+	responsePosition
+)
+
+func (p position) String() string {
+	if p == requestPosition {
+		return "request"
+	}
+
+	return "response"
+}
+
+// CompareSpecs computes the changes between the operations of a base spec and its revision.
+//
+// Operations are matched by path and method, parameters by name and location,
+// and media types by name. Responses defined by a $ref are resolved against their spec.
+// Schemas are compared without resolving $ref's: expand both specs beforehand to compare their full content.
+func CompareSpecs(base, revision *Swagger) *Diff {
+	d := &Diff{base: base, revision: revision}
+
+	basePaths, revisedPaths := pathItemsOf(base), pathItemsOf(revision)
+
+	for _, key := range slices.Sorted(maps.Keys(basePaths)) {
+		location := pointerTo("paths", key)
+
+		revisedItem, exists := revisedPaths[key]
+		if !exists {
+			d.add(location, true, "path removed")
+
+			continue
+		}
+
+		revisedOps := maps.Collect(revisedItem.operations())
+		for method, op := range basePaths[key].operations() {
+			revisedOp, exists := revisedOps[method]
+			if !exists {
+				d.add(location+pointerTo(method), true, "operation removed")
+
+				continue
+			}
+
+			d.compareOperation(op, revisedOp, location+pointerTo(method))
+		}
+	}
+
+	return d
+}
+
+func pathItemsOf(doc *Swagger) map[string]PathItem {
+	if doc == nil || doc.Paths == nil {
+		return nil
+	}
+
+	return doc.Paths.Paths
+}
+
+func (d *Diff) add(pointer string, breaking bool, format string, args ...any) {
+	d.Changes = append(d.Changes, Change{
+		Pointer:  pointer,
+		Message:  fmt.Sprintf(format, args...),
+		Breaking: breaking,
+	})
+}
+
+func (d *Diff) compareOperation(base, revision *Operation, location string) {
+	for i, revisedParam := range revision.Parameters {
+		idx := slices.IndexFunc(base.Parameters, func(p Parameter) bool {
+			return p.Name == revisedParam.Name && p.In == revisedParam.In
+		})
+		if idx < 0 {
+			continue
+		}
+
+		d.compareParameter(base.Parameters[idx], revisedParam, location+pointerTo("parameters", strconv.Itoa(i)))
+	}
+
+	if base.RequestBody != nil && revision.RequestBody != nil {
+		d.compareContent(base.RequestBody.Content, revision.RequestBody.Content, location+pointerTo("requestBody"), requestPosition)
+	}
+
+	if base.Responses != nil && revision.Responses != nil {
+		if base.Responses.Default != nil && revision.Responses.Default != nil {
+			d.compareResponse(*base.Responses.Default, *revision.Responses.Default, location+pointerTo("responses", "default"))
+		}
+
+		for _, code := range slices.Sorted(maps.Keys(revision.Responses.StatusCodeResponses)) {
+			if baseResponse, exists := base.Responses.StatusCodeResponses[code]; exists {
+				d.compareResponse(baseResponse, revision.Responses.StatusCodeResponses[code], location+pointerTo("responses", strconv.Itoa(code)))
+			}
+		}
+
+		for _, class := range slices.Sorted(maps.Keys(revision.Responses.StatusRangeResponses)) {
+			if baseResponse, exists := base.Responses.StatusRangeResponses[class]; exists {
+				d.compareResponse(baseResponse, revision.Responses.StatusRangeResponses[class], location+pointerTo("responses", statusRange(class)))
+			}
+		}
+	}
+}
+
+func (d *Diff) compareParameter(base, revision Parameter, location string) {
+	d.compareEnum(base.Enum, revision.Enum, location, requestPosition)
+
+	if base.Schema != nil && revision.Schema != nil {
+		d.compareSchema(*base.Schema, *revision.Schema, location+pointerTo("schema"), requestPosition)
+	}
+
+	d.compareContent(base.Content, revision.Content, location, requestPosition)
+}
+
+func (d *Diff) compareResponse(base, revision Response, location string) {
+	// unresolved $ref's are left as is: their content is then compared as empty
+	if resolved, err := base.Resolve(d.base); err == nil {
+		base = *resolved
+	}
+	if resolved, err := revision.Resolve(d.revision); err == nil {
+		revision = *resolved
+	}
+
+	if base.Schema != nil && revision.Schema != nil {
+		d.compareSchema(*base.Schema, *revision.Schema, location+pointerTo("schema"), responsePosition)
+	}
+
+	d.compareContent(base.Content, revision.Content, location, responsePosition)
+}
+
+func (d *Diff) compareContent(base, revision map[string]MediaType, location string, pos position) {
+	for _, mediaType := range slices.Sorted(maps.Keys(revision)) {
+		baseMedia, exists := base[mediaType]
+		revisedMedia := revision[mediaType]
+		if !exists || baseMedia.Schema == nil || revisedMedia.Schema == nil {
+			continue
+		}
+
+		d.compareSchema(*baseMedia.Schema, *revisedMedia.Schema, location+pointerTo("content", mediaType, "schema"), pos)
+	}
+}
+
+func (d *Diff) compareSchema(base, revision Schema, location string, pos position) {
+	if base.Ref.String() != "" || revision.Ref.String() != "" {
+		// $ref's are not resolved: schemas referring to the same target are considered equal
+		if base.Ref.String() != revision.Ref.String() {
+			d.add(location, false, "$ref changed from %q to %q", base.Ref.String(), revision.Ref.String())
+		}
+
+		return
+	}
+
+	d.compareEnum(base.Enum, revision.Enum, location, pos)
+
+	for _, name := range slices.Sorted(maps.Keys(revision.Properties)) {
+		if baseProperty, exists := base.Properties[name]; exists {
+			d.compareSchema(baseProperty, revision.Properties[name], location+pointerTo("properties", name), pos)
+		}
+	}
+
+	if base.Items != nil && revision.Items != nil && base.Items.Schema != nil && revision.Items.Schema != nil {
+		d.compareSchema(*base.Items.Schema, *revision.Items.Schema, location+pointerTo("items"), pos)
+	}
+
+	if base.AdditionalProperties != nil && revision.AdditionalProperties != nil &&
+		base.AdditionalProperties.Schema != nil && revision.AdditionalProperties.Schema != nil {
+		d.compareSchema(*base.AdditionalProperties.Schema, *revision.AdditionalProperties.Schema, location+pointerTo("additionalProperties"), pos)
+	}
+}
+
+// compareEnum classifies enum changes according to the position of the schema.
+//
+// In a response, removing a value breaks consumers which rely on it, whereas adding one is not breaking.
+// In a request, it's the reverse: adding a value is breaking, whereas removing one is not.
+// An empty enum allows any value: introducing an enum removes values, and removing an enum adds values.
+func (d *Diff) compareEnum(base, revision []any, location string, pos position) {
+	pointer := location + pointerTo("enum")
+
+	switch {
+	case len(base) == 0 && len(revision) == 0:
+		return
+	case len(base) == 0:
+		d.add(pointer, pos == responsePosition, "enum introduced in %s", pos)
+
+		return
+	case len(revision) == 0:
+		d.add(pointer, pos == requestPosition, "enum removed from %s", pos)
+
+		return
+	}
+
+	baseKeys := make(map[string]struct{}, len(base))
+	for _, value := range base {
+		baseKeys[enumKey(value)] = struct{}{}
+	}
+
+	revisedKeys := make(map[string]struct{}, len(revision))
+	for _, value := range revision {
+		key := enumKey(value)
+		revisedKeys[key] = struct{}{}
+
+		if _, exists := baseKeys[key]; !exists {
+			d.add(pointer, pos == requestPosition, "enum value %s added to %s", key, pos)
+		}
+	}
+
+	for _, value := range base {
+		key := enumKey(value)
+		if _, exists := revisedKeys[key]; !exists {
+			d.add(pointer, pos == responsePosition, "enum value %s removed from %s", key, pos)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func petsSpec(t testing.TB, requestEnum, responseEnum string) *Swagger {
+	doc := new(Swagger)
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"paths": {
+			"/pets": {
+				"post": {
+					"parameters": [
+						{"name": "kind", "in": "query", "schema": {"type": "string", "enum": `+requestEnum+`}}
+					],
+					"requestBody": {
+						"content": {
+							"application/json": {
+								"schema": {"properties": {"kind": {"type": "string", "enum": `+requestEnum+`}}}
+							}
+						}
+					},
+					"responses": {
+						"200": {
+							"description": "ok",
+							"content": {
+								"application/json": {
+									"schema": {"type": "array", "items": {"properties": {"kind": {"type": "string", "enum": `+responseEnum+`}}}}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`), doc))
+
+	return doc
+}
+
+func TestCompareSpecs_Enum(t *testing.T) {
+	const (
+		narrow = `["cat", "dog"]`
+		wide   = `["cat", "dog", "bird"]`
+
+		paramEnum    = "/paths/~1pets/post/parameters/0/schema/enum"
+		bodyEnum     = "/paths/~1pets/post/requestBody/content/application~1json/schema/properties/kind/enum"
+		responseEnum = "/paths/~1pets/post/responses/200/content/application~1json/schema/items/properties/kind/enum"
+	)
+
+	t.Run("adding enum values", func(t *testing.T) {
+		diff := CompareSpecs(petsSpec(t, narrow, narrow), petsSpec(t, wide, wide))
+
+		assert.Equal(t, []Change{
+			{Pointer: paramEnum, Message: `enum value "bird" added to request`, Breaking: true},
+			{Pointer: bodyEnum, Message: `enum value "bird" added to request`, Breaking: true},
+			{Pointer: responseEnum, Message: `enum value "bird" added to response`},
+		}, diff.Changes)
+
+		breaking := diff.Breaking()
+		require.Len(t, breaking, 2)
+		assert.Equal(t, paramEnum, breaking[0].Pointer)
+		assert.Equal(t, bodyEnum, breaking[1].Pointer)
+	})
+
+	t.Run("removing enum values", func(t *testing.T) {
+		diff := CompareSpecs(petsSpec(t, wide, wide), petsSpec(t, narrow, narrow))
+
+		assert.Equal(t, []Change{
+			{Pointer: paramEnum, Message: `enum value "bird" removed from request`},
+			{Pointer: bodyEnum, Message: `enum value "bird" removed from request`},
+			{Pointer: responseEnum, Message: `enum value "bird" removed from response`, Breaking: true},
+		}, diff.Changes)
+
+		breaking := diff.Breaking()
+		require.Len(t, breaking, 1)
+		assert.Equal(t, responseEnum, breaking[0].Pointer)
+	})
+
+	t.Run("introducing and removing enums", func(t *testing.T) {
+		diff := CompareSpecs(petsSpec(t, `[]`, narrow), petsSpec(t, narrow, `[]`))
+
+		assert.Equal(t, []Change{
+			{Pointer: paramEnum, Message: `enum introduced in request`},
+			{Pointer: bodyEnum, Message: `enum introduced in request`},
+			{Pointer: responseEnum, Message: `enum removed from response`},
+		}, diff.Changes)
+
+		diff = CompareSpecs(petsSpec(t, narrow, `[]`), petsSpec(t, `[]`, narrow))
+
+		assert.Equal(t, []Change{
+			{Pointer: paramEnum, Message: `enum removed from request`, Breaking: true},
+			{Pointer: bodyEnum, Message: `enum removed from request`, Breaking: true},
+			{Pointer: responseEnum, Message: `enum introduced in response`, Breaking: true},
+		}, diff.Changes)
+	})
+
+	t.Run("unchanged spec", func(t *testing.T) {
+		diff := CompareSpecs(petsSpec(t, narrow, wide), petsSpec(t, narrow, wide))
+
+		assert.Empty(t, diff.Changes)
+		assert.Empty(t, diff.Breaking())
+	})
+
+	t.Run("removed operation", func(t *testing.T) {
+		revision := petsSpec(t, narrow, narrow)
+		revision.Paths.Paths["/pets"] = PathItem{}
+
+		diff := CompareSpecs(petsSpec(t, narrow, narrow), revision)
+		assert.Equal(t, []Change{
+			{Pointer: "/paths/~1pets/post", Message: "operation removed", Breaking: true},
+		}, diff.Breaking())
+	})
+}
+
+func TestCompareSpecs_ResponseRef(t *testing.T) {
+	spec := func(enum string) *Swagger {
+		doc := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"openapi": "3.1.0",
+			"paths": {
+				"/pets/{id}": {
+					"get": {
+						"responses": {"404": {"$ref": "#/components/responses/NotFound"}}
+					}
+				}
+			},
+			"components": {
+				"responses": {
+					"NotFound": {
+						"description": "not found",
+						"headers": {"X-Request-Id": {"schema": {"type": "string"}}},
+						"content": {"application/json": {"schema": {"type": "string", "enum": `+enum+`}}}
+					}
+				}
+			}
+		}`), doc))
+
+		return doc
+	}
+
+	diff := CompareSpecs(spec(`["gone", "moved"]`), spec(`["gone"]`))
+
+	assert.Equal(t, []Change{
+		{
+			Pointer:  "/paths/~1pets~1{id}/get/responses/404/content/application~1json/schema/enum",
+			Message:  `enum value "moved" removed from response`,
+			Breaking: true,
+		},
+	}, diff.Changes)
+}
+
+func TestCompareSpecs_DefaultAndRangeResponses(t *testing.T) {
+	spec := func(enum string) *Swagger {
+		doc := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"openapi": "3.1.0",
+			"paths": {
+				"/pets": {
+					"get": {
+						"responses": {
+							"4XX": {"description": "client error", "content": {"application/json": {"schema": {"type": "string", "enum": `+enum+`}}}},
+							"default": {"description": "error", "content": {"application/json": {"schema": {"type": "string", "enum": `+enum+`}}}}
+						}
+					}
+				}
+			}
+		}`), doc))
+
+		return doc
+	}
+
+	diff := CompareSpecs(spec(`["gone", "moved"]`), spec(`["gone"]`))
+
+	assert.Equal(t, []Change{
+		{
+			Pointer:  "/paths/~1pets/get/responses/default/content/application~1json/schema/enum",
+			Message:  `enum value "moved" removed from response`,
+			Breaking: true,
+		},
+		{
+			Pointer:  "/paths/~1pets/get/responses/4XX/content/application~1json/schema/enum",
+			Message:  `enum value "moved" removed from response`,
+			Breaking: true,
+		},
+	}, diff.Changes)
+}
@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Discriminator tells which schema of a polymorphic composition describes a payload,
+// after the value of one of its properties.
+//
+// In Swagger 2.0, the discriminator is the mere name of the property, and its values are
+// the names of the definitions. OpenAPI 3 adds a mapping from values to schemas.
+//
+// For more information: https://spec.openapis.org/oas/v3.1.0#discriminator-object
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
+// UnmarshalJSON accepts a discriminator defined either as a property name or as an object.
+//
+// A discriminator is always rendered as an object, as required by OpenAPI 3.
+func (d *Discriminator) UnmarshalJSON(data []byte) error {
+	var propertyName string
+	if err := json.Unmarshal(data, &propertyName); err == nil {
+		*d = Discriminator{PropertyName: propertyName}
+
+		return nil
+	}
+
+	type discriminatorObject Discriminator
+	var obj discriminatorObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	*d = Discriminator(obj)
+
+	return nil
+}
+
+// ResolveDiscriminated returns the subschema of a oneOf or anyOf composition which describes an instance,
+// after the value of the discriminator property of this schema.
+//
+// The value is looked up in the mapping of the discriminator, which yields either a $ref or a schema name.
+// Unmapped values are implicitly matched with the member of the composition referring to a schema with that name,
+// such as "#/components/schemas/{value}" or "#/definitions/{value}". Without oneOf or anyOf, e.g. when the
+// discriminator is set on the base schema of an allOf inheritance, unmapped values are the names of schemas
+// defined in the components, or in the definitions of a Swagger 2.0 document.
+//
+// The resolved schema is looked up with $ref's resolved against root.
+//
+// A missing or unknown discriminator value produces an error which matches ErrDiscriminator.
+func (s Schema) ResolveDiscriminated(instance map[string]any, root any) (*Schema, error) {
+	if s.Discriminator == nil || s.Discriminator.PropertyName == "" {
+		return nil, fmt.Errorf("schema has no discriminator: %w", ErrDiscriminator)
+	}
+	propertyName := s.Discriminator.PropertyName
+
+	raw, isSet := instance[propertyName]
+	if !isSet {
+		return nil, fmt.Errorf("property %q is missing: %w", propertyName, ErrDiscriminator)
+	}
+
+	value, isString := raw.(string)
+	if !isString {
+		return nil, fmt.Errorf("property %q should be a string, but got %T: %w", propertyName, raw, ErrDiscriminator)
+	}
+
+	members := s.OneOf
+	if len(members) == 0 {
+		members = s.AnyOf
+	}
+
+	if mapped, isMapped := s.Discriminator.Mapping[value]; isMapped {
+		ref, err := NewRef(mappingRef(mapped))
+		if err != nil {
+			return nil, fmt.Errorf("mapping of value %q: %w", value, err)
+		}
+
+		return ResolveRef(root, &ref)
+	}
+
+	for _, member := range members {
+		if member.Ref.GetURL() == nil {
+			continue
+		}
+
+		// the last token of the pointer is the schema name, e.g. "foo/bar" for "#/components/schemas/foo~1bar"
+		tokens := SplitPointer(member.Ref.GetURL().Fragment)
+		if len(tokens) == 0 || tokens[len(tokens)-1] != value {
+			continue
+		}
+
+		return ResolveRef(root, &member.Ref)
+	}
+
+	if len(members) == 0 {
+		for _, section := range []string{pointerTo("components", "schemas"), pointerTo("definitions")} {
+			ref, err := NewRef("#" + section + pointerTo(value))
+			if err != nil {
+				break
+			}
+
+			if resolved, err := ResolveRef(root, &ref); err == nil {
+				return resolved, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("unknown value %q for property %q: %w", value, propertyName, ErrDiscriminator)
+}
+
+// ValidateDiscriminator checks that the mappings of discriminators are consistent with their composition.
+//
+// Every value of a mapping must resolve against root. When the discriminator comes with a oneOf or anyOf
+// composition, every value of its mapping must also target a member of this composition, and members
+// which are not mapped, hence implicitly mapped by their schema name, must resolve as well.
+//
+// All schemas nested in s are checked. Each problem is reported as a *ValidationError
+// located by a JSON pointer relative to s.
+func (s Schema) ValidateDiscriminator(root any) []error {
+	var errs []error
+
+	walkSchema(s, "", func(schema Schema, location string) {
+		if schema.Discriminator == nil {
+			return
+		}
+
+		members, composition := schema.OneOf, "oneOf"
+		if len(members) == 0 {
+			members, composition = schema.AnyOf, "anyOf"
+		}
+
+		mapped := make(map[string]struct{}, len(schema.Discriminator.Mapping))
+		for _, value := range slices.Sorted(maps.Keys(schema.Discriminator.Mapping)) {
+			pointer := location + pointerTo("discriminator", "mapping", value)
+			target := mappingRef(schema.Discriminator.Mapping[value])
+			mapped[target] = struct{}{}
+
+			if err := resolvesRef(target, root); err != nil {
+				errs = append(errs, &ValidationError{
+					Path:    pointer,
+					Message: fmt.Sprintf("mapping of value %q cannot be resolved: %v", value, err),
+				})
+
+				continue
+			}
+
+			if len(members) == 0 {
+				continue
+			}
+
+			if !slices.ContainsFunc(members, func(member Schema) bool { return member.Ref.String() == target }) {
+				errs = append(errs, &ValidationError{
+					Path:    pointer,
+					Message: fmt.Sprintf("mapping of value %q targets %s, which is not a member of %s", value, target, composition),
+				})
+			}
+		}
+
+		for i, member := range members {
+			ref := member.Ref.String()
+			if ref == "" {
+				continue
+			}
+			if _, isMapped := mapped[ref]; isMapped {
+				continue
+			}
+
+			if err := resolvesRef(ref, root); err != nil {
+				errs = append(errs, &ValidationError{
+					Path:    location + pointerTo(composition, strconv.Itoa(i)),
+					Message: fmt.Sprintf("implicitly mapped member %s cannot be resolved: %v", ref, err),
+				})
+			}
+		}
+	})
+
+	return errs
+}
+
+// mappingRef yields the $ref targeted by the value of a discriminator mapping, which is either a $ref or a schema name.
+func mappingRef(mapped string) string {
+	if strings.Contains(mapped, "/") {
+		return mapped
+	}
+
+	// a schema name, which may contain "~"
+	return "#" + pointerTo("components", "schemas", mapped)
+}
+
+func resolvesRef(target string, root any) error {
+	ref, err := NewRef(target)
+	if err != nil {
+		return err
+	}
+
+	_, err = ResolveRef(root, &ref)
+
+	return err
+}
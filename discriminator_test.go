@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestDiscriminator_Serialization(t *testing.T) {
+	t.Run("should serialize a swagger 2 discriminator as an object", func(t *testing.T) {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(`{"discriminator": "petType"}`), &schema))
+		assert.Equal(t, &Discriminator{PropertyName: "petType"}, schema.Discriminator)
+
+		assertSerializeJSON(t, schema, `{"discriminator":{"propertyName":"petType"}}`)
+	})
+
+	t.Run("should round trip an OpenAPI 3 discriminator", func(t *testing.T) {
+		const discriminator = `{"discriminator":{"propertyName":"petType"}}`
+
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(discriminator), &schema))
+		assert.Equal(t, "petType", schema.Discriminator.PropertyName)
+
+		assertSerializeJSON(t, schema, discriminator)
+	})
+
+	t.Run("should serialize a discriminator with a mapping as an object", func(t *testing.T) {
+		schema := new(Schema).WithDiscriminator("petType")
+		schema.Discriminator.Mapping = map[string]string{"dog": "Dog"}
+
+		assertSerializeJSON(t, schema, `{"discriminator":{"propertyName":"petType","mapping":{"dog":"Dog"}}}`)
+	})
+}
+
+func TestSchema_ResolveDiscriminated(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"Cat": {"type": "object", "properties": {"meows": {"type": "boolean"}}},
+				"Dog": {"type": "object", "properties": {"barks": {"type": "boolean"}}},
+				"Lizard": {"type": "object", "properties": {"scales": {"type": "integer"}}},
+				"Pet": {
+					"oneOf": [
+						{"$ref": "#/components/schemas/Cat"},
+						{"$ref": "#/components/schemas/Dog"},
+						{"$ref": "#/components/schemas/Lizard"}
+					],
+					"discriminator": {
+						"propertyName": "petType",
+						"mapping": {
+							"dog": "#/components/schemas/Dog",
+							"reptile": "Lizard"
+						}
+					}
+				}
+			}
+		}
+	}`), &root))
+	pet := root.Components.Schemas["Pet"]
+
+	t.Run("should resolve a value with a $ref mapping", func(t *testing.T) {
+		resolved, err := pet.ResolveDiscriminated(map[string]any{"petType": "dog"}, root)
+		require.NoError(t, err)
+		assert.Contains(t, resolved.Properties, "barks")
+	})
+
+	t.Run("should resolve a value with a schema name mapping", func(t *testing.T) {
+		resolved, err := pet.ResolveDiscriminated(map[string]any{"petType": "reptile"}, root)
+		require.NoError(t, err)
+		assert.Contains(t, resolved.Properties, "scales")
+	})
+
+	t.Run("should resolve an unmapped value with the schema name", func(t *testing.T) {
+		resolved, err := pet.ResolveDiscriminated(map[string]any{"petType": "Cat"}, root)
+		require.NoError(t, err)
+		assert.Contains(t, resolved.Properties, "meows")
+	})
+
+	t.Run("should resolve a swagger 2 discriminator among anyOf definitions", func(t *testing.T) {
+		var doc Swagger
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"swagger": "2.0",
+			"definitions": {
+				"Cat": {"type": "object", "properties": {"meows": {"type": "boolean"}}}
+			}
+		}`), &doc))
+
+		schema := new(Schema).WithDiscriminator("petType")
+		schema.AnyOf = []Schema{*RefSchema("#/definitions/Cat")}
+
+		resolved, err := schema.ResolveDiscriminated(map[string]any{"petType": "Cat"}, doc)
+		require.NoError(t, err)
+		assert.Contains(t, resolved.Properties, "meows")
+	})
+
+	t.Run("should resolve the schema named after an unmapped value with allOf inheritance", func(t *testing.T) {
+		for _, doc := range []string{
+			`{
+				"openapi": "3.1.0",
+				"components": {
+					"schemas": {
+						"Pet": {"type": "object", "discriminator": {"propertyName": "petType"}},
+						"Cat": {"allOf": [{"$ref": "#/components/schemas/Pet"}, {"properties": {"meows": {"type": "boolean"}}}]}
+					}
+				}
+			}`,
+			`{
+				"swagger": "2.0",
+				"definitions": {
+					"Pet": {"type": "object", "discriminator": "petType"},
+					"Cat": {"allOf": [{"$ref": "#/definitions/Pet"}, {"properties": {"meows": {"type": "boolean"}}}]}
+				}
+			}`,
+		} {
+			var spec Swagger
+			require.NoError(t, json.Unmarshal([]byte(doc), &spec))
+
+			base := spec.Definitions["Pet"]
+			assert.Equal(t, "petType", base.DiscriminatorProperty())
+
+			resolved, err := base.ResolveDiscriminated(map[string]any{"petType": "Cat"}, spec)
+			require.NoError(t, err)
+			require.Len(t, resolved.AllOf, 2)
+			assert.Contains(t, resolved.AllOf[1].Properties, "meows")
+
+			_, err = base.ResolveDiscriminated(map[string]any{"petType": "Dog"}, spec)
+			require.ErrorIs(t, err, ErrDiscriminator)
+		}
+	})
+
+	t.Run("should match schema names with escaped characters", func(t *testing.T) {
+		var doc Swagger
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"openapi": "3.1.0",
+			"components": {
+				"schemas": {
+					"pets/Cat": {"type": "object", "properties": {"meows": {"type": "boolean"}}},
+					"Dog~v2": {"type": "object", "properties": {"barks": {"type": "boolean"}}}
+				}
+			}
+		}`), &doc))
+
+		schema := new(Schema).WithDiscriminator("petType")
+		schema.OneOf = []Schema{*RefSchema("#/components/schemas/pets~1Cat")}
+		schema.Discriminator.Mapping = map[string]string{"dog": "Dog~v2"}
+
+		resolved, err := schema.ResolveDiscriminated(map[string]any{"petType": "pets/Cat"}, doc)
+		require.NoError(t, err)
+		assert.Contains(t, resolved.Properties, "meows")
+
+		resolved, err = schema.ResolveDiscriminated(map[string]any{"petType": "dog"}, doc)
+		require.NoError(t, err)
+		assert.Contains(t, resolved.Properties, "barks")
+	})
+
+	t.Run("should fail when the discriminator cannot be resolved", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			schema   Schema
+			instance map[string]any
+			expected string
+		}{
+			{
+				name:     "missing discriminator property",
+				schema:   pet,
+				instance: map[string]any{"name": "rex"},
+				expected: `property "petType" is missing`,
+			},
+			{
+				name:     "unknown discriminator value",
+				schema:   pet,
+				instance: map[string]any{"petType": "bird"},
+				expected: `unknown value "bird" for property "petType"`,
+			},
+			{
+				name:     "non string discriminator value",
+				schema:   pet,
+				instance: map[string]any{"petType": 1.0},
+				expected: `property "petType" should be a string, but got float64`,
+			},
+			{
+				name:     "schema without discriminator",
+				schema:   *StringProperty(),
+				instance: map[string]any{"petType": "dog"},
+				expected: `schema has no discriminator`,
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := tc.schema.ResolveDiscriminated(tc.instance, root)
+				require.ErrorIs(t, err, ErrDiscriminator)
+				assert.ErrorContains(t, err, tc.expected)
+			})
+		}
+	})
+}
+
+func TestSchema_ValidateDiscriminator(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"Cat": {"type": "object", "properties": {"meows": {"type": "boolean"}}},
+				"Dog": {"type": "object", "properties": {"barks": {"type": "boolean"}}},
+				"Lizard": {"type": "object", "properties": {"scales": {"type": "integer"}}}
+			}
+		}
+	}`), &root))
+
+	for _, tc := range []struct {
+		name     string
+		schema   string
+		expected []string
+	}{
+		{
+			name: "consistent mapping",
+			schema: `{
+				"oneOf": [{"$ref": "#/components/schemas/Cat"}, {"$ref": "#/components/schemas/Dog"}, {"$ref": "#/components/schemas/Lizard"}],
+				"discriminator": {"propertyName": "petType", "mapping": {"dog": "#/components/schemas/Dog", "reptile": "Lizard"}}
+			}`,
+		},
+		{
+			name: "mapping to a schema which is not a member",
+			schema: `{
+				"oneOf": [{"$ref": "#/components/schemas/Cat"}, {"$ref": "#/components/schemas/Dog"}],
+				"discriminator": {"propertyName": "petType", "mapping": {"dog": "#/components/schemas/Dog", "reptile": "#/components/schemas/Lizard"}}
+			}`,
+			expected: []string{
+				`/discriminator/mapping/reptile: mapping of value "reptile" targets #/components/schemas/Lizard, which is not a member of oneOf`,
+			},
+		},
+		{
+			name: "mapping to a schema name which is not a member of anyOf",
+			schema: `{
+				"properties": {
+					"pet": {
+						"anyOf": [{"$ref": "#/components/schemas/Cat"}],
+						"discriminator": {"propertyName": "petType", "mapping": {"dog": "Dog"}}
+					}
+				}
+			}`,
+			expected: []string{
+				`/properties/pet/discriminator/mapping/dog: mapping of value "dog" targets #/components/schemas/Dog, which is not a member of anyOf`,
+			},
+		},
+		{
+			name:   "mapping without composition",
+			schema: `{"discriminator": {"propertyName": "petType", "mapping": {"dog": "Dog"}}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var schema Schema
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+
+			errs := schema.ValidateDiscriminator(root)
+			require.Len(t, errs, len(tc.expected))
+			for i, err := range errs {
+				require.ErrorIs(t, err, ErrSpec)
+				assert.EqualError(t, err, tc.expected[i])
+			}
+		})
+	}
+
+	t.Run("should report unresolved mappings and members", func(t *testing.T) {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"oneOf": [{"$ref": "#/components/schemas/Cat"}, {"$ref": "#/components/schemas/Bird"}, {"$ref": "#/components/schemas/Fish"}],
+			"discriminator": {"propertyName": "petType", "mapping": {"fish": "Fish"}}
+		}`), &schema))
+
+		errs := schema.ValidateDiscriminator(root)
+		require.Len(t, errs, 2)
+
+		var mappingErr, memberErr *ValidationError
+		require.ErrorAs(t, errs[0], &mappingErr)
+		assert.Equal(t, "/discriminator/mapping/fish", mappingErr.Path)
+		assert.Contains(t, mappingErr.Message, `mapping of value "fish" cannot be resolved`)
+
+		require.ErrorAs(t, errs[1], &memberErr)
+		assert.Equal(t, "/oneOf/1", memberErr.Path)
+		assert.Contains(t, memberErr.Message, "implicitly mapped member #/components/schemas/Bird cannot be resolved")
+	})
+}
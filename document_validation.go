@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Validate checks the structure of a whole document, in a single call.
+//
+// It reports:
+//   - a missing or unsupported version, see SpecVersion
+//   - a missing info object, or info without title or version
+//   - missing paths, or operations without responses, since OpenAPI 3.0 requires them
+//   - operationIds used by several operations, including webhooks
+//   - tags of operations missing from the tags of the document, when the document declares tags
+//   - the mismatches of path templates and path parameters, see ValidatePathParameters
+//   - undefined security schemes, see ValidateSecurity
+//
+// Each problem is reported as a *ValidationError located by a JSON pointer. NewMultiError aggregates them.
+func (s *Swagger) Validate() []error {
+	v := &documentValidator{doc: s}
+	v.version()
+	v.info()
+	v.operations()
+
+	errs := v.errs
+	errs = append(errs, s.ValidatePathParameters()...)
+
+	return append(errs, s.ValidateSecurity()...)
+}
+
+type documentValidator struct {
+	doc  *Swagger
+	errs []error
+}
+
+func (v *documentValidator) report(location, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{Path: location, Message: fmt.Sprintf(format, args...)})
+}
+
+// requiresResponses tells whether the document requires paths and responses, which are optional since OpenAPI 3.1.
+func (v *documentValidator) requiresResponses() bool {
+	_, minor, err := v.doc.SpecVersion()
+
+	return err != nil || minor == 0
+}
+
+func (v *documentValidator) version() {
+	if v.doc.OpenAPI == "" && v.doc.Swagger == "" {
+		v.report(pointerTo("openapi"), "the OpenAPI version is required")
+
+		return
+	}
+
+	if _, _, err := v.doc.SpecVersion(); err != nil {
+		location := pointerTo("openapi")
+		if v.doc.OpenAPI == "" {
+			location = pointerTo("swagger")
+		}
+		v.report(location, "%v", err)
+	}
+}
+
+func (v *documentValidator) info() {
+	location := pointerTo("info")
+	if v.doc.Info == nil {
+		v.report(location, "info is required")
+
+		return
+	}
+
+	if v.doc.Info.Title == "" {
+		v.report(location+pointerTo("title"), "the title of the API is required")
+	}
+	if v.doc.Info.Version == "" {
+		v.report(location+pointerTo("version"), "the version of the API is required")
+	}
+}
+
+func (v *documentValidator) operations() {
+	if v.doc.Paths == nil && v.requiresResponses() {
+		v.report(pointerTo("paths"), "paths are required")
+	}
+
+	var declaredTags []string
+	for _, tag := range v.doc.Tags {
+		declaredTags = append(declaredTags, tag.Name)
+	}
+
+	operationIDs := make(map[string]string)
+
+	validate := func(key OperationKey, op *Operation) {
+		location := key.Pointer()
+
+		if op.ID != "" {
+			if first, duplicate := operationIDs[op.ID]; duplicate {
+				v.report(location+pointerTo("operationId"), "operationId %q is already used by the operation at %s", op.ID, first)
+			} else {
+				operationIDs[op.ID] = location
+			}
+		}
+
+		if v.requiresResponses() && !hasResponses(op.Responses) {
+			v.report(location+pointerTo("responses"), "the operation has no responses")
+		}
+
+		if len(declaredTags) == 0 {
+			return
+		}
+		for i, tag := range op.Tags {
+			if !slices.Contains(declaredTags, tag) {
+				v.report(location+pointerTo("tags", strconv.Itoa(i)), "tag %q is not declared by the document", tag)
+			}
+		}
+	}
+
+	for key, op := range v.doc.Operations() {
+		validate(key, op)
+	}
+
+	for key, op := range v.doc.WebhookOperations() {
+		validate(key, op)
+	}
+}
+
+func hasResponses(responses *Responses) bool {
+	return responses != nil &&
+		(responses.Default != nil || len(responses.StatusCodeResponses) > 0 || len(responses.StatusRangeResponses) > 0)
+}
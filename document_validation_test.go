@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSwagger_Validate(t *testing.T) {
+	t.Run("should report each structural error with its pointer", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join("fixtures", "validation", "invalid.json"))
+		require.NoError(t, err)
+
+		var doc Swagger
+		require.NoError(t, json.Unmarshal(data, &doc))
+
+		errs := doc.Validate()
+		pointers := make(map[string]string, len(errs))
+		for _, err := range errs {
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			pointers[verr.Pointer()] = verr.Message
+		}
+
+		assert.Equal(t, map[string]string{
+			"/info/version":                        "the version of the API is required",
+			"/paths/~1pets/post/operationId":       `operationId "listPets" is already used by the operation at /paths/~1pets/get`,
+			"/paths/~1pets/post/tags/0":            `tag "pet" is not declared by the document`,
+			"/paths/~1pets~1{petId}/get/responses": "the operation has no responses",
+			"/paths/~1pets~1{petId}/get":           `path parameter "petId" is not defined`,
+			"/paths/~1pets/get/security/0/oauth":   `security scheme "oauth" is not defined`,
+		}, pointers)
+		assert.Len(t, errs, 6)
+	})
+
+	t.Run("should report missing version and info", func(t *testing.T) {
+		errs := new(Swagger).Validate()
+		require.Len(t, errs, 3)
+		assert.EqualError(t, errs[0], "/openapi: the OpenAPI version is required")
+		assert.EqualError(t, errs[1], "/info: info is required")
+		assert.EqualError(t, errs[2], "/paths: paths are required")
+	})
+
+	t.Run("should accept the default version of the package", func(t *testing.T) {
+		doc := Swagger{SwaggerProps: SwaggerProps{
+			OpenAPI: OpenAPIVersion,
+			Info:    &Info{InfoProps: InfoProps{Title: "pets", Version: "1.0"}},
+		}}
+		assert.Empty(t, doc.Validate())
+	})
+
+	t.Run("should report unsupported versions", func(t *testing.T) {
+		doc := Swagger{SwaggerProps: SwaggerProps{
+			OpenAPI: "4.0.0",
+			Info:    &Info{InfoProps: InfoProps{Title: "pets", Version: "1.0"}},
+			Paths:   &Paths{},
+		}}
+		errs := doc.Validate()
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], `/openapi: OpenAPI version "4.0.0" is not supported: unsupported operation`)
+	})
+
+	t.Run("should accept operations without responses in OpenAPI 3.1", func(t *testing.T) {
+		var doc Swagger
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"openapi": "3.1.0",
+			"info": {"title": "pets", "version": "1.0"},
+			"webhooks": {"newPet": {"post": {"operationId": "newPet", "tags": ["pets"]}}}
+		}`), &doc))
+
+		assert.Empty(t, doc.Validate())
+	})
+}
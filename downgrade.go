@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// downgradedVersion is the version of specs produced by Downgrade31To30
+const downgradedVersion = "3.0.3"
+
+// JSON Schema 2020-12 keywords which have no equivalent in OpenAPI 3.0 schemas
+var unsupportedKeywords30 = []string{
+	"$dynamicRef", "$dynamicAnchor", "$anchor", "$defs", "prefixItems", "contains", "minContains", "maxContains",
+	"if", "then", "else", "dependentSchemas", "dependentRequired", "propertyNames",
+	"unevaluatedItems", "unevaluatedProperties", "patternProperties", "contentSchema",
+}
+
+// Downgrade31To30 converts an OpenAPI 3.1 spec into an OpenAPI 3.0 spec, leaving the original spec untouched.
+//
+// The constructs of 3.1 schemas are converted to their nearest 3.0 equivalent:
+//   - a "null" member of a type array becomes "nullable: true"
+//   - the first member of an "examples" array becomes the "example"
+//   - "const" becomes a single value "enum"
+//   - "contentEncoding: base64" becomes "format: byte", other content encodings and media types "format: binary"
+//
+// Features which cannot be converted, such as webhooks, type arrays with several other types or "$dynamicRef",
+// are dropped. Each lossy conversion is reported as a Warning located by a JSON pointer.
+//
+// It fails on specs which do not declare an OpenAPI 3.1 version.
+func Downgrade31To30(spec *Swagger) (*Swagger, []Warning, error) {
+	_, minor, err := spec.SpecVersion()
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot downgrade to %s: %w", downgradedVersion, err)
+	}
+	if minor != 1 {
+		return nil, nil, fmt.Errorf("cannot downgrade OpenAPI version %q to %s: %w", spec.OpenAPI, downgradedVersion, errors.ErrUnsupported)
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, nil, err
+	}
+
+	d := &downgrader{}
+	d.document(doc)
+
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	downgraded := new(Swagger)
+	if err := json.Unmarshal(data, downgraded); err != nil {
+		return nil, nil, err
+	}
+
+	return downgraded, d.warnings, nil
+}
+
+type downgrader struct {
+	warnings []Warning
+}
+
+func (d *downgrader) warn(location, format string, args ...any) {
+	d.warnings = append(d.warnings, Warning{Path: location, Message: fmt.Sprintf(format, args...)})
+}
+
+// drop removes a key with a warning, when it is present.
+func (d *downgrader) drop(object map[string]any, key, location string) {
+	if _, ok := object[key]; !ok {
+		return
+	}
+
+	delete(object, key)
+	d.warn(location+pointerTo(key), "%s is not supported by OpenAPI 3.0: dropped", key)
+}
+
+func (d *downgrader) document(doc map[string]any) {
+	doc["openapi"] = downgradedVersion
+
+	d.drop(doc, "jsonSchemaDialect", "")
+	d.drop(doc, "webhooks", "")
+	if components, ok := doc["components"].(map[string]any); ok {
+		d.drop(components, "pathItems", pointerTo("components"))
+		// definitions mirror the schemas of the components, and are restored from them on unmarshaling
+		delete(doc, "definitions")
+	}
+
+	genericWalker{schema: d.schema}.document(doc)
+}
+
+func (d *downgrader) schema(schema map[string]any, location string) {
+	d.types(schema, location)
+
+	if examples, ok := schema["examples"].([]any); ok {
+		delete(schema, "examples")
+		if _, hasExample := schema["example"]; !hasExample && len(examples) > 0 {
+			schema["example"] = examples[0]
+			examples = examples[1:]
+		}
+		if len(examples) > 0 {
+			d.warn(location+pointerTo("examples"), "only one example is supported by OpenAPI 3.0: %d examples dropped", len(examples))
+		}
+	}
+
+	if value, ok := schema["const"]; ok {
+		delete(schema, "const")
+		schema["enum"] = []any{value}
+	}
+
+	inclusiveBound(schema, "maximum", "exclusiveMaximum", func(a, b float64) bool { return a < b })
+	inclusiveBound(schema, "minimum", "exclusiveMinimum", func(a, b float64) bool { return a > b })
+
+	encoding, hasEncoding := schema["contentEncoding"]
+	_, hasMediaType := schema["contentMediaType"]
+	if hasEncoding || hasMediaType {
+		delete(schema, "contentEncoding")
+		delete(schema, "contentMediaType")
+		if _, hasFormat := schema["format"]; !hasFormat {
+			schema["format"] = "binary"
+			if encoding == "base64" {
+				schema["format"] = "byte"
+			}
+		}
+	}
+
+	for _, keyword := range unsupportedKeywords30 {
+		d.drop(schema, keyword, location)
+	}
+}
+
+// inclusiveBound converts a numeric exclusive bound into the boolean modifier of its inclusive bound.
+//
+// When the inclusive bound is tighter, the exclusive bound is redundant and dropped.
+func inclusiveBound(schema map[string]any, bound, exclusive string, tighter func(a, b float64) bool) {
+	limit, ok := numericValue(schema[exclusive])
+	if !ok {
+		return
+	}
+
+	if inclusive, hasBound := numericValue(schema[bound]); hasBound && tighter(inclusive, limit) {
+		delete(schema, exclusive)
+
+		return
+	}
+
+	schema[bound] = schema[exclusive]
+	schema[exclusive] = true
+}
+
+// types converts a type array into a single type, flagged as nullable when "null" is a member.
+func (d *downgrader) types(schema map[string]any, location string) {
+	types, ok := schema["type"].([]any)
+	if !ok {
+		return
+	}
+
+	nullable := slices.Contains(types, any("null"))
+	types = slices.DeleteFunc(slices.Clone(types), func(tpe any) bool { return tpe == "null" })
+	if nullable {
+		schema["nullable"] = true
+	}
+
+	switch len(types) {
+	case 0:
+		delete(schema, "type")
+		d.warn(location+pointerTo("type"), "the null type is not supported by OpenAPI 3.0: the schema is nullable, without type")
+	case 1:
+		schema["type"] = types[0]
+	default:
+		delete(schema, "type")
+		d.warn(location+pointerTo("type"), "several types %v are not supported by OpenAPI 3.0: dropped", types)
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestDowngrade31To30(t *testing.T) {
+	var doc Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"jsonSchemaDialect": "https://spec.openapis.org/oas/3.1/dialect/base",
+		"components": {
+			"schemas": {
+				"Name": {"type": ["string", "null"], "examples": ["rex", "fido"]},
+				"Kind": {"const": "dog"},
+				"Picture": {"type": "string", "contentEncoding": "base64", "contentMediaType": "image/png"},
+				"Upload": {"type": "string", "contentMediaType": "application/octet-stream"},
+				"Price": {"type": "number", "exclusiveMinimum": 0, "maximum": 50, "exclusiveMaximum": 100},
+				"Tree": {"type": "object", "properties": {"children": {"$dynamicRef": "#node"}}}
+			}
+		},
+		"paths": {
+			"/pets": {
+				"get": {
+					"responses": {
+						"200": {"content": {"application/json": {"schema": {"type": ["integer", "null"]}}}}
+					}
+				}
+			}
+		}
+	}`), &doc))
+	original, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	downgraded, warnings, err := Downgrade31To30(&doc)
+	require.NoError(t, err)
+	require.NotNil(t, downgraded)
+	assert.Equal(t, "3.0.3", downgraded.OpenAPI)
+
+	t.Run("should leave the original spec untouched", func(t *testing.T) {
+		unchanged, err := json.Marshal(doc)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(original), string(unchanged))
+	})
+
+	t.Run("should convert type arrays with null to nullable", func(t *testing.T) {
+		name := downgraded.Components.Schemas["Name"]
+		assert.Equal(t, StringOrArray{"string"}, name.Type)
+		require.NotNil(t, name.Nullable)
+		assert.True(t, *name.Nullable)
+
+		schema := downgraded.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Content["application/json"].Schema
+		require.NotNil(t, schema)
+		assert.Equal(t, StringOrArray{"integer"}, schema.Type)
+	})
+
+	t.Run("should keep the first of examples as example", func(t *testing.T) {
+		name := downgraded.Components.Schemas["Name"]
+		assert.Equal(t, "rex", name.Example)
+		assert.NotContains(t, name.ExtraProps, "examples")
+	})
+
+	t.Run("should convert const to a single value enum", func(t *testing.T) {
+		kind := downgraded.Components.Schemas["Kind"]
+		assert.Equal(t, []any{"dog"}, kind.Enum)
+		assert.NotContains(t, kind.ExtraProps, "const")
+	})
+
+	t.Run("should convert numeric exclusive bounds to boolean modifiers", func(t *testing.T) {
+		price := downgraded.Components.Schemas["Price"]
+		assert.Equal(t, float64Ptr(0), price.Minimum)
+		assert.True(t, price.ExclusiveMinimum)
+		assert.Nil(t, price.ExclusiveMinValue)
+
+		// the inclusive maximum is tighter
+		assert.Equal(t, float64Ptr(50), price.Maximum)
+		assert.False(t, price.ExclusiveMaximum)
+		assert.Nil(t, price.ExclusiveMaxValue)
+	})
+
+	t.Run("should convert content keywords to byte and binary formats", func(t *testing.T) {
+		picture := downgraded.Components.Schemas["Picture"]
+		assert.Equal(t, "byte", picture.Format)
+		assert.NotContains(t, picture.ExtraProps, "contentEncoding")
+		assert.NotContains(t, picture.ExtraProps, "contentMediaType")
+
+		upload := downgraded.Components.Schemas["Upload"]
+		assert.Equal(t, "binary", upload.Format)
+		assert.NotContains(t, upload.ExtraProps, "contentMediaType")
+	})
+
+	t.Run("should warn about unconvertible features", func(t *testing.T) {
+		children := downgraded.Components.Schemas["Tree"].Properties["children"]
+		assert.NotContains(t, children.ExtraProps, "$dynamicRef")
+		assert.Empty(t, downgraded.JSONSchemaDialect)
+
+		assert.Equal(t, []Warning{
+			{Path: "/jsonSchemaDialect", Message: "jsonSchemaDialect is not supported by OpenAPI 3.0: dropped"},
+			{Path: "/components/schemas/Name/examples", Message: "only one example is supported by OpenAPI 3.0: 1 examples dropped"},
+			{Path: "/components/schemas/Tree/properties/children/$dynamicRef", Message: "$dynamicRef is not supported by OpenAPI 3.0: dropped"},
+		}, warnings)
+	})
+
+	t.Run("should reject specs which are not 3.1", func(t *testing.T) {
+		_, _, err := Downgrade31To30(&Swagger{SwaggerProps: SwaggerProps{OpenAPI: "3.0.3"}})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrUnsupported))
+	})
+}
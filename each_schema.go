@@ -0,0 +1,310 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// EachSchema calls fn on every schema of the document, with a JSON pointer to the schema, in a deterministic order.
+//
+// Schemas are found in components (or definitions, with Swagger 2.0 documents), as well as in the parameters,
+// request bodies, responses, headers and callbacks of paths and webhooks. Nested schemas, e.g. items,
+// properties or members of compositions, are visited after the schema containing them. $ref's are not followed.
+//
+// fn may modify the schemas, which are updated in place. The iteration stops on the first error returned by fn,
+// and EachSchema returns this error.
+func (s *Swagger) EachSchema(fn func(pointer string, schema *Schema) error) error {
+	return schemaVisitor{fn: fn}.document(s)
+}
+
+// schemaVisitor visits the schemas of a document, and optionally the objects holding them.
+//
+// When not nil, object is called on every parameter, header, media type and response, before their schemas are visited.
+type schemaVisitor struct {
+	fn     func(string, *Schema) error
+	object func(any)
+}
+
+func (v schemaVisitor) document(s *Swagger) error {
+	if s.Components != nil {
+		if err := v.components(&s.Components.ComponentsProps); err != nil {
+			return err
+		}
+	} else if err := schemaMap(s.Definitions, pointerTo("definitions"), v.schema); err != nil {
+		// definitions are otherwise synced with components.schemas
+		return err
+	}
+
+	if s.Paths != nil {
+		if err := v.pathItems(s.Paths.Paths, pointerTo("paths")); err != nil {
+			return err
+		}
+	}
+
+	return v.pathItems(s.Webhooks, pointerTo("webhooks"))
+}
+
+func (v schemaVisitor) visitObject(object any) {
+	if v.object != nil {
+		v.object(object)
+	}
+}
+
+func (v schemaVisitor) components(components *ComponentsProps) error {
+	location := pointerTo("components")
+
+	if err := schemaMap(components.Schemas, location+pointerTo("schemas"), v.schema); err != nil {
+		return err
+	}
+	if err := schemaMap(components.Parameters, location+pointerTo("parameters"), v.parameter); err != nil {
+		return err
+	}
+	if err := schemaMap(components.Headers, location+pointerTo("headers"), v.header); err != nil {
+		return err
+	}
+	if err := schemaMap(components.RequestBodies, location+pointerTo("requestBodies"), v.requestBody); err != nil {
+		return err
+	}
+	if err := schemaMap(components.Responses, location+pointerTo("responses"), v.response); err != nil {
+		return err
+	}
+	if err := schemaMap(components.Callbacks, location+pointerTo("callbacks"), v.callback); err != nil {
+		return err
+	}
+
+	return v.pathItems(components.PathItems, location+pointerTo("pathItems"))
+}
+
+func (v schemaVisitor) pathItems(items map[string]PathItem, location string) error {
+	return schemaMap(items, location, func(item *PathItem, location string) error {
+		if err := v.parameters(item.Parameters, location+pointerTo("parameters")); err != nil {
+			return err
+		}
+
+		for method, op := range item.operations() {
+			if err := v.operation(op, location+pointerTo(method)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (v schemaVisitor) operation(op *Operation, location string) error {
+	if err := v.parameters(op.Parameters, location+pointerTo("parameters")); err != nil {
+		return err
+	}
+
+	if op.RequestBody != nil {
+		if err := v.requestBody(op.RequestBody, location+pointerTo("requestBody")); err != nil {
+			return err
+		}
+	}
+
+	if op.Responses != nil {
+		location := location + pointerTo("responses")
+
+		if op.Responses.Default != nil {
+			if err := v.response(op.Responses.Default, location+pointerTo("default")); err != nil {
+				return err
+			}
+		}
+
+		for _, code := range slices.Sorted(maps.Keys(op.Responses.StatusCodeResponses)) {
+			response := op.Responses.StatusCodeResponses[code]
+			if err := v.response(&response, location+pointerTo(strconv.Itoa(code))); err != nil {
+				return err
+			}
+			op.Responses.StatusCodeResponses[code] = response
+		}
+
+		for _, class := range slices.Sorted(maps.Keys(op.Responses.StatusRangeResponses)) {
+			response := op.Responses.StatusRangeResponses[class]
+			if err := v.response(&response, location+pointerTo(statusRange(class))); err != nil {
+				return err
+			}
+			op.Responses.StatusRangeResponses[class] = response
+		}
+	}
+
+	return schemaMap(op.Callbacks, location+pointerTo("callbacks"), v.callback)
+}
+
+func (v schemaVisitor) callback(callback *Callback, location string) error {
+	return v.pathItems(callback.Expressions, location)
+}
+
+func (v schemaVisitor) parameters(params []Parameter, location string) error {
+	for i := range params {
+		if err := v.parameter(&params[i], location+pointerTo(strconv.Itoa(i))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v schemaVisitor) parameter(param *Parameter, location string) error {
+	v.visitObject(param)
+
+	if param.Schema != nil {
+		if err := v.schema(param.Schema, location+pointerTo("schema")); err != nil {
+			return err
+		}
+	}
+
+	return v.content(param.Content, location+pointerTo("content"))
+}
+
+func (v schemaVisitor) header(header *Header, location string) error {
+	v.visitObject(header)
+
+	if header.Schema == nil {
+		return nil
+	}
+
+	return v.schema(header.Schema, location+pointerTo("schema"))
+}
+
+func (v schemaVisitor) requestBody(body *RequestBody, location string) error {
+	return v.content(body.Content, location+pointerTo("content"))
+}
+
+func (v schemaVisitor) response(response *Response, location string) error {
+	v.visitObject(response)
+
+	if err := v.content(response.Content, location+pointerTo("content")); err != nil {
+		return err
+	}
+
+	return schemaMap(response.Headers, location+pointerTo("headers"), v.header)
+}
+
+func (v schemaVisitor) content(content map[string]MediaType, location string) error {
+	return schemaMap(content, location, func(media *MediaType, location string) error {
+		v.visitObject(media)
+
+		if media.Schema == nil {
+			return nil
+		}
+
+		return v.schema(media.Schema, location+pointerTo("schema"))
+	})
+}
+
+// schema visits a schema, then the schemas nested in it, in the same order as walkSchema.
+func (v schemaVisitor) schema(schema *Schema, location string) error {
+	if err := v.fn(location, schema); err != nil {
+		return err
+	}
+
+	if err := schemaMap(schema.Definitions, location+pointerTo("definitions"), v.schema); err != nil {
+		return err
+	}
+	if err := schemaMap(schema.Defs, location+pointerTo("$defs"), v.schema); err != nil {
+		return err
+	}
+
+	if schema.Items != nil {
+		if schema.Items.Schema != nil {
+			if err := v.schema(schema.Items.Schema, location+pointerTo("items")); err != nil {
+				return err
+			}
+		}
+		if err := v.schemaList(schema.Items.Schemas, location+pointerTo("items")); err != nil {
+			return err
+		}
+	}
+
+	if schema.Contains != nil {
+		if err := v.schema(schema.Contains, location+pointerTo("contains")); err != nil {
+			return err
+		}
+	}
+
+	if err := v.schemaList(schema.AllOf, location+pointerTo("allOf")); err != nil {
+		return err
+	}
+	if err := v.schemaList(schema.AnyOf, location+pointerTo("anyOf")); err != nil {
+		return err
+	}
+	if err := v.schemaList(schema.OneOf, location+pointerTo("oneOf")); err != nil {
+		return err
+	}
+
+	if schema.Not != nil {
+		if err := v.schema(schema.Not, location+pointerTo("not")); err != nil {
+			return err
+		}
+	}
+
+	if err := schemaMap(schema.Properties, location+pointerTo("properties"), v.schema); err != nil {
+		return err
+	}
+
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		if err := v.schema(schema.AdditionalProperties.Schema, location+pointerTo("additionalProperties")); err != nil {
+			return err
+		}
+	}
+
+	if err := schemaMap(schema.PatternProperties, location+pointerTo("patternProperties"), v.schemaOrBool); err != nil {
+		return err
+	}
+
+	if schema.PropertyNames != nil {
+		if err := v.schema(schema.PropertyNames, location+pointerTo("propertyNames")); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(schema.Dependencies)) {
+		if sch := schema.Dependencies[key].Schema; sch != nil {
+			if err := v.schema(sch, location+pointerTo("dependencies", key)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if schema.AdditionalItems != nil {
+		return v.schemaOrBool(schema.AdditionalItems, location+pointerTo("additionalItems"))
+	}
+
+	return nil
+}
+
+func (v schemaVisitor) schemaOrBool(schema *SchemaOrBool, location string) error {
+	if schema.Schema == nil {
+		return nil
+	}
+
+	return v.schema(schema.Schema, location)
+}
+
+func (v schemaVisitor) schemaList(schemas []Schema, location string) error {
+	for i := range schemas {
+		if err := v.schema(&schemas[i], location+pointerTo(strconv.Itoa(i))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// schemaMap visits the values of a map in the order of their keys, and updates the map with the visited values.
+func schemaMap[M ~map[string]V, V any](values M, location string, visit func(*V, string) error) error {
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		value := values[key]
+		if err := visit(&value, location+pointerTo(key)); err != nil {
+			return err
+		}
+		values[key] = value
+	}
+
+	return nil
+}
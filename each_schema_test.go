@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSwagger_EachSchema(t *testing.T) {
+	const doc = `{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"pets": {
+					"type": "array",
+					"items": {
+						"type": "object",
+						"properties": {
+							"owner": {
+								"type": "object",
+								"properties": {"name": {"type": "string"}}
+							}
+						}
+					}
+				}
+			},
+			"headers": {
+				"rate": {"schema": {"type": "integer"}}
+			}
+		},
+		"paths": {
+			"/pets": {
+				"parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer"}}],
+				"post": {
+					"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/pets"}}}},
+					"responses": {
+						"2XX": {"content": {"application/json": {"schema": {"oneOf": [{"type": "string"}, {"type": "null"}]}}}}
+					}
+				}
+			}
+		}
+	}`
+
+	load := func(t *testing.T) *Swagger {
+		t.Helper()
+
+		var spec Swagger
+		require.NoError(t, json.Unmarshal([]byte(doc), &spec))
+
+		return &spec
+	}
+
+	t.Run("should visit all the schemas with their pointer", func(t *testing.T) {
+		var pointers []string
+		require.NoError(t, load(t).EachSchema(func(pointer string, _ *Schema) error {
+			pointers = append(pointers, pointer)
+
+			return nil
+		}))
+
+		assert.Equal(t, []string{
+			"/components/schemas/pets",
+			"/components/schemas/pets/items",
+			"/components/schemas/pets/items/properties/owner",
+			"/components/schemas/pets/items/properties/owner/properties/name",
+			"/components/headers/rate/schema",
+			"/paths/~1pets/parameters/0/schema",
+			"/paths/~1pets/post/requestBody/content/application~1json/schema",
+			"/paths/~1pets/post/responses/2XX/content/application~1json/schema",
+			"/paths/~1pets/post/responses/2XX/content/application~1json/schema/oneOf/0",
+			"/paths/~1pets/post/responses/2XX/content/application~1json/schema/oneOf/1",
+		}, pointers)
+	})
+
+	t.Run("should update the schemas in place", func(t *testing.T) {
+		spec := load(t)
+		require.NoError(t, spec.EachSchema(func(_ string, schema *Schema) error {
+			if schema.Type.Contains("string") {
+				schema.MaxLength = int64Ptr(80)
+			}
+
+			return nil
+		}))
+
+		owner := spec.Components.Schemas["pets"].Items.Schema.Properties["owner"]
+		assert.Equal(t, int64Ptr(80), owner.Properties["name"].MaxLength)
+
+		schema := spec.Paths.Paths["/pets"].Post.Responses.StatusRangeResponses[2].Content["application/json"].Schema
+		require.NotNil(t, schema)
+		assert.Equal(t, int64Ptr(80), schema.OneOf[0].MaxLength)
+	})
+
+	t.Run("should stop on the first error", func(t *testing.T) {
+		stop := errors.New("stop")
+
+		var visited int
+		err := load(t).EachSchema(func(pointer string, _ *Schema) error {
+			visited++
+			if pointer == "/components/schemas/pets/items/properties/owner" {
+				return stop
+			}
+
+			return nil
+		})
+		require.ErrorIs(t, err, stop)
+		assert.Equal(t, 3, visited)
+	})
+}
@@ -3,7 +3,11 @@
 
 package spec
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // Error codes
 var (
@@ -14,12 +18,336 @@ var (
 	ErrResolveRefNeedsAPointer = errors.New("resolve ref: target needs to be a pointer")
 
 	// ErrDerefUnsupportedType indicates that a resolved reference was found in an unsupported container type.
-	// At the moment, $ref are supported only inside: schemas, parameters, responses, path items
+	// At the moment, $ref are supported only inside: schemas, parameters, responses, path items, request bodies and headers
 	ErrDerefUnsupportedType = errors.New("deref: unsupported type")
 
 	// ErrExpandUnsupportedType indicates that $ref expansion is attempted on some invalid type
 	ErrExpandUnsupportedType = errors.New("expand: unsupported type. Input should be of type *Parameter or *Response")
 
+	// ErrUnsupportedGoType indicates that a go type cannot be described by a schema
+	ErrUnsupportedGoType = errors.New("reflect: unsupported go type")
+
+	// ErrInvalidStructTag indicates that a struct tag describing a spec object is malformed
+	ErrInvalidStructTag = errors.New("reflect: invalid struct tag")
+
+	// ErrMaxExpandedSize indicates that the expanded document exceeds the configured size budget
+	ErrMaxExpandedSize = errors.New("expand: maximum expanded size exceeded")
+
+	// ErrMergeConflict indicates that the members of an allOf composition have constraints which cannot be combined
+	ErrMergeConflict = errors.New("merge allOf: conflicting constraints")
+
+	// ErrUnionSchema indicates that a schema is a union of oneOf or anyOf alternatives, rather than a single object
+	ErrUnionSchema = errors.New("schema is a union of alternatives")
+
+	// ErrUnsatisfiable indicates that combined constraints cannot be satisfied by any value, e.g. a minimum above the maximum
+	ErrUnsatisfiable = errors.New("unsatisfiable constraints")
+
+	// ErrMediaTypeNotFound indicates that some content is not available for the requested media type
+	ErrMediaTypeNotFound = errors.New("media type not found")
+
+	// ErrDiscriminator indicates that the schema describing a polymorphic payload cannot be determined
+	ErrDiscriminator = errors.New("discriminator: cannot resolve schema")
+
+	// ErrServerURL indicates that a templated server URL cannot be resolved into a concrete URL
+	ErrServerURL = errors.New("server url cannot be resolved")
+
+	// ErrExtensionNotFound indicates that an object has no vendor extension with the requested key
+	ErrExtensionNotFound = errors.New("extension not found")
+
+	// ErrComponentNotFound indicates that a document has no component of the requested kind and name
+	ErrComponentNotFound = errors.New("component not found")
+
+	// ErrComponentExists indicates that a document already has a component of the requested kind and name
+	ErrComponentExists = errors.New("component already exists")
+
 	// ErrSpec is an error raised by the spec package
 	ErrSpec = errors.New("spec error")
 )
+
+// ExpandedSizeError is returned when the expansion of a document exceeds
+// the budget set by ExpandOptions.MaxExpandedSize.
+//
+// It matches ErrMaxExpandedSize with errors.Is.
+type ExpandedSizeError struct {
+	// Limit is the configured budget, in bytes
+	Limit int
+	// Size is the approximate size reached when expansion was aborted, in bytes
+	Size int
+}
+
+func (e *ExpandedSizeError) Error() string {
+	return fmt.Sprintf("expanded document reached %d bytes, with a budget of %d bytes: %v", e.Size, e.Limit, ErrMaxExpandedSize)
+}
+
+// Unwrap yields ErrMaxExpandedSize.
+func (e *ExpandedSizeError) Unwrap() error {
+	return ErrMaxExpandedSize
+}
+
+// SpecError is implemented by the errors which relate to some location in a spec document.
+//
+// Callers may use errors.As to retrieve the concrete error type. All these errors match ErrSpec with errors.Is.
+type SpecError interface {
+	error
+
+	// Pointer is the JSON pointer to the offending location, or an empty string for a whole document
+	Pointer() string
+}
+
+var (
+	_ SpecError = &ValidationError{}
+	_ SpecError = &RefNotFoundError{}
+	_ SpecError = &RemoteFetchError{}
+	_ SpecError = &ParseError{}
+	_ SpecError = &CircularReferenceError{}
+)
+
+// MultiError aggregates the errors found by a validator, e.g. all the problems of a document.
+//
+// errors.Is and errors.As match any of the aggregated errors.
+type MultiError struct {
+	Errors []SpecError
+}
+
+// NewMultiError aggregates errors into a *MultiError, or returns nil when there is no error.
+//
+// The results of the validators of this package may be aggregated this way. Errors which are not
+// a SpecError are aggregated with an empty pointer, and do not match ErrSpec.
+func NewMultiError(errs ...error) error {
+	aggregate := &MultiError{Errors: make([]SpecError, 0, len(errs))}
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		specErr, ok := err.(SpecError)
+		if !ok {
+			specErr = &unlocatedError{err: err}
+		}
+		aggregate.Errors = append(aggregate.Errors, specErr)
+	}
+
+	if len(aggregate.Errors) == 0 {
+		return nil
+	}
+
+	return aggregate
+}
+
+// Error lists the aggregated errors, one per line.
+func (e *MultiError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap yields the aggregated errors.
+func (e *MultiError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// ByPointer returns the aggregated errors located at, or below, the JSON pointer prefix.
+//
+// Pointers are matched token by token, e.g. "/paths/~1pets" matches "/paths/~1pets/get" but not "/paths/~1pets~1{id}".
+// An empty prefix returns all the errors.
+func (e *MultiError) ByPointer(prefix string) []SpecError {
+	var matching []SpecError
+	for _, err := range e.Errors {
+		pointer := err.Pointer()
+		if pointer == prefix || strings.HasPrefix(pointer, strings.TrimSuffix(prefix, "/")+"/") {
+			matching = append(matching, err)
+		}
+	}
+
+	return matching
+}
+
+// unlocatedError is a SpecError without pointer, for errors which do not relate to a location.
+type unlocatedError struct {
+	err error
+}
+
+func (e *unlocatedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *unlocatedError) Pointer() string {
+	return ""
+}
+
+// Unwrap yields the aggregated error, which matches ErrSpec only if it is itself raised by this package.
+func (e *unlocatedError) Unwrap() error {
+	return e.err
+}
+
+// RefNotFoundError is returned when the target of a $ref cannot be found in the document it refers to.
+type RefNotFoundError struct {
+	// Ref is the $ref which could not be resolved
+	Ref string
+	// Err is the error raised by the JSON pointer lookup
+	Err error
+}
+
+func (e *RefNotFoundError) Error() string {
+	return fmt.Sprintf("$ref %q not found: %v", e.Ref, e.Err)
+}
+
+// Pointer is the JSON pointer to the missing target, in the document it belongs to.
+func (e *RefNotFoundError) Pointer() string {
+	return refPointer(e.Ref)
+}
+
+// Unwrap yields the error raised by the JSON pointer lookup.
+func (e *RefNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrSpec.
+func (e *RefNotFoundError) Is(target error) bool {
+	return target == ErrSpec
+}
+
+// RemoteFetchError is returned when a document cannot be fetched, be it from a local file or a remote URL.
+type RemoteFetchError struct {
+	// URL is the location of the document
+	URL string
+	// Err is the error raised by the document loader
+	Err error
+}
+
+func (e *RemoteFetchError) Error() string {
+	return fmt.Sprintf("could not fetch document %s: %v", e.URL, e.Err)
+}
+
+// Pointer is empty: the whole document is missing.
+func (e *RemoteFetchError) Pointer() string {
+	return ""
+}
+
+// Unwrap yields the error raised by the document loader.
+func (e *RemoteFetchError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrSpec.
+func (e *RemoteFetchError) Is(target error) bool {
+	return target == ErrSpec
+}
+
+// ParseError is returned when a document is not valid JSON, or cannot be decoded as a spec.
+type ParseError struct {
+	// Document is the location of the document. It is empty when the document is read from a stream.
+	Document string
+	// Err is the error raised by the decoder
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	if e.Document == "" {
+		return fmt.Sprintf("could not parse document: %v", e.Err)
+	}
+
+	return fmt.Sprintf("could not parse document %s: %v", e.Document, e.Err)
+}
+
+// Pointer is empty: the whole document is invalid.
+func (e *ParseError) Pointer() string {
+	return ""
+}
+
+// Unwrap yields the error raised by the decoder.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrSpec.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrSpec
+}
+
+// CircularReferenceError is returned when a $ref cycle prevents some processing to complete,
+// e.g. when merging an allOf composition which refers to itself.
+//
+// Notice that the expander does not fail on circular $ref's: these are left unexpanded.
+type CircularReferenceError struct {
+	// Ref is the $ref which closes the cycle
+	Ref string
+}
+
+func (e *CircularReferenceError) Error() string {
+	return fmt.Sprintf("circular $ref %q", e.Ref)
+}
+
+// Pointer is the JSON pointer to the target of the $ref, in the document it belongs to.
+func (e *CircularReferenceError) Pointer() string {
+	return refPointer(e.Ref)
+}
+
+// Is matches ErrSpec.
+func (e *CircularReferenceError) Is(target error) bool {
+	return target == ErrSpec
+}
+
+func refPointer(ref string) string {
+	r, err := NewRef(ref)
+	if err != nil {
+		return ""
+	}
+
+	return r.GetPointer().String()
+}
+
+// ValidationError describes a problem found in a spec object.
+//
+// It matches ErrSpec with errors.Is.
+type ValidationError struct {
+	// Path is the JSON pointer to the offending value, relative to the validated object
+	Path string
+	// SchemaPath is the JSON pointer to the schema rejecting the value, relative to the schema, when validating
+	// an instance against a schema. It holds a "$ref" token for every $ref followed to reach this schema.
+	SchemaPath string
+	// Message explains what is wrong
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	return e.Path + ": " + e.Message
+}
+
+// Pointer is the JSON pointer to the offending value.
+func (e *ValidationError) Pointer() string {
+	return e.Path
+}
+
+// Unwrap yields ErrSpec.
+func (e *ValidationError) Unwrap() error {
+	return ErrSpec
+}
+
+// Warning describes a questionable construct found in a spec object, which is valid but likely unintended.
+type Warning struct {
+	// Path is the JSON pointer to the questionable value, relative to the linted object
+	Path string
+	// Message explains what is questionable
+	Message string
+}
+
+func (w Warning) String() string {
+	if w.Path == "" {
+		return w.Message
+	}
+
+	return w.Path + ": " + w.Message
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestMultiError(t *testing.T) {
+	pets := &ValidationError{Path: "/paths/~1pets/get", Message: "missing responses"}
+	pet := &ValidationError{Path: "/paths/~1pets~1{id}/get/parameters/0", Message: "undeclared path parameter"}
+	schema := &ValidationError{Path: "/components/schemas/Pet/required/0", Message: "undefined property"}
+	circular := &CircularReferenceError{Ref: "#/components/schemas/Tree"}
+
+	err := NewMultiError(pets, nil, pet, schema, circular, fs.ErrNotExist)
+	require.Error(t, err)
+
+	var aggregate *MultiError
+	require.ErrorAs(t, err, &aggregate)
+	require.Len(t, aggregate.Errors, 5)
+
+	t.Run("should match the aggregated errors", func(t *testing.T) {
+		require.ErrorIs(t, err, ErrSpec)
+		require.ErrorIs(t, err, fs.ErrNotExist)
+
+		var cerr *CircularReferenceError
+		require.ErrorAs(t, err, &cerr)
+		assert.Equal(t, "#/components/schemas/Tree", cerr.Ref)
+
+		var verr *ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Same(t, pets, verr)
+	})
+
+	t.Run("should list the aggregated errors", func(t *testing.T) {
+		assert.EqualError(t, err, "/paths/~1pets/get: missing responses\n"+
+			"/paths/~1pets~1{id}/get/parameters/0: undeclared path parameter\n"+
+			"/components/schemas/Pet/required/0: undefined property\n"+
+			`circular $ref "#/components/schemas/Tree"`+"\n"+
+			fs.ErrNotExist.Error())
+	})
+
+	t.Run("should filter errors by pointer prefix", func(t *testing.T) {
+		assert.Equal(t, []SpecError{pets}, aggregate.ByPointer("/paths/~1pets"))
+		assert.Equal(t, []SpecError{pets, pet}, aggregate.ByPointer("/paths/"))
+		assert.Equal(t, []SpecError{schema, circular}, aggregate.ByPointer("/components/schemas"))
+		assert.Equal(t, []SpecError{schema}, aggregate.ByPointer("/components/schemas/Pet/required/0"))
+		assert.Empty(t, aggregate.ByPointer("/components/schemas/P"))
+		assert.Len(t, aggregate.ByPointer(""), 5)
+	})
+
+	t.Run("should be nil without errors", func(t *testing.T) {
+		require.NoError(t, NewMultiError())
+		require.NoError(t, NewMultiError(nil))
+		assert.False(t, errors.Is(NewMultiError(), ErrSpec))
+	})
+
+	t.Run("should not match ErrSpec with errors raised elsewhere", func(t *testing.T) {
+		err := NewMultiError(fs.ErrNotExist)
+		require.ErrorIs(t, err, fs.ErrNotExist)
+		assert.False(t, errors.Is(err, ErrSpec))
+	})
+}
+
+func TestMultiError_Validators(t *testing.T) {
+	var doc Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "pets", "version": "1.0"},
+		"paths": {
+			"/pets/{petId}": {
+				"get": {
+					"parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer", "maximum": 100}, "example": 500}],
+					"responses": {"200": {"description": "ok"}}
+				}
+			}
+		}
+	}`), &doc))
+
+	errs := doc.Validate()
+	errs = append(errs, doc.ValidateExamples()...)
+
+	err := NewMultiError(errs...)
+	require.ErrorIs(t, err, ErrSpec)
+
+	var aggregate *MultiError
+	require.ErrorAs(t, err, &aggregate)
+	require.Len(t, aggregate.Errors, 2)
+
+	located := aggregate.ByPointer("/paths/~1pets~1{petId}/get")
+	require.Len(t, located, 2)
+	for _, specErr := range located {
+		var verr *ValidationError
+		require.ErrorAs(t, specErr, &verr)
+	}
+	assert.Len(t, aggregate.ByPointer("/paths/~1pets~1{petId}/get/parameters/0/example"), 1)
+}
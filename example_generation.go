@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ExampleContext tells which payload an example is generated for.
+type ExampleContext int
+
+const (
+	// RequestExample generates the example of a payload sent by clients, without readOnly properties.
+	RequestExample ExampleContext = iota
+	// ResponseExample generates the example of a payload sent by servers, without writeOnly properties.
+	ResponseExample
+)
+
+// exampleFormats are the sample strings of the well known string formats.
+var exampleFormats = map[string]string{
+	"byte":      "ZXhhbXBsZQ==", // base64 of "example"
+	"date-time": "1970-01-01T00:00:00Z",
+	"date":      "1970-01-01",
+	"time":      "00:00:00Z",
+	"email":     "user@example.com",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"uri":       "https://example.com",
+	"uuid":      "00000000-0000-0000-0000-000000000000",
+}
+
+// GenerateExample builds a sample value matching a schema, e.g. to document a payload without example.
+//
+// The example, examples, const, default or first enum value of a schema are used when set. Otherwise, a value is
+// built from the type of the schema: objects get all their properties, arrays a single item, and oneOf or anyOf
+// compositions their first member. Properties which are readOnly in a RequestExample, or writeOnly in a
+// ResponseExample, are omitted. Strings honor minLength and maxLength, falling back to a plain string when
+// the sample of their format doesn't fit.
+//
+// $ref's are resolved against root, and allOf members are merged. Properties and items which would recurse
+// through a circular $ref are omitted. Schemas with allOf members which cannot be merged, or with $ref's
+// which cannot be resolved, produce an error, as well as strings with a pattern which the sample doesn't match:
+// these need an example.
+func (s Schema) GenerateExample(root any, context ExampleContext) (any, error) {
+	g := &exampleGenerator{
+		root:     root,
+		context:  context,
+		visiting: make(map[string]struct{}),
+	}
+
+	value, _, err := g.generate(s, false)
+
+	return value, err
+}
+
+type exampleGenerator struct {
+	root     any
+	context  ExampleContext
+	visiting map[string]struct{} // $ref's being generated, to detect cycles
+}
+
+// generate builds the example of a schema after resolving its $ref.
+//
+// When droppable, it tells whether the schema itself is omitted from the example.
+func (g *exampleGenerator) generate(schema Schema, droppable bool) (any, bool, error) {
+	if ref := schema.Ref.String(); ref != "" {
+		if _, isCircular := g.visiting[ref]; isCircular {
+			return nil, false, nil
+		}
+		g.visiting[ref] = struct{}{}
+		defer delete(g.visiting, ref)
+	}
+
+	resolved, err := resolveMember(schema, g.root, make(map[string]struct{}))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if droppable && g.isOmitted(*resolved) {
+		return nil, false, nil
+	}
+
+	return g.value(*resolved)
+}
+
+func (g *exampleGenerator) isOmitted(schema Schema) bool {
+	if g.context == ResponseExample {
+		return schema.WriteOnly
+	}
+
+	return schema.ReadOnly
+}
+
+func (g *exampleGenerator) value(schema Schema) (any, bool, error) {
+	if sample, ok := sampleValue(schema); ok {
+		return normalizedInstance(sample), true, nil
+	}
+
+	for _, composition := range []struct {
+		keyword string
+		members []Schema
+	}{
+		{"oneOf", schema.OneOf},
+		{"anyOf", schema.AnyOf},
+	} {
+		if len(composition.members) == 0 {
+			continue
+		}
+
+		value, ok, err := g.generate(composition.members[0], false)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s/0: %w", composition.keyword, err)
+		}
+
+		return value, ok, nil
+	}
+
+	switch exampleType(schema) {
+	case "object":
+		object := make(map[string]any, len(schema.Properties))
+		for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+			value, ok, err := g.generate(schema.Properties[name], true)
+			if err != nil {
+				return nil, false, fmt.Errorf("properties/%s: %w", name, err)
+			}
+			if ok {
+				object[name] = value
+			}
+		}
+
+		return object, true, nil
+	case "array":
+		items := []any{}
+		if schema.Items != nil && schema.Items.Schema != nil {
+			value, ok, err := g.generate(*schema.Items.Schema, false)
+			if err != nil {
+				return nil, false, fmt.Errorf("items: %w", err)
+			}
+			if ok {
+				items = append(items, value)
+			}
+		}
+
+		return items, true, nil
+	case "string":
+		value, err := stringExample(schema)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return value, true, nil
+	case "integer":
+		return math.Ceil(numberExample(schema)), true, nil
+	case "number":
+		return numberExample(schema), true, nil
+	case "boolean":
+		return true, true, nil
+	default:
+		return nil, true, nil
+	}
+}
+
+// sampleValue returns the value provided by a schema for its instances, if any.
+func sampleValue(schema Schema) (any, bool) {
+	if examples, ok := schema.ExtraProps["examples"].([]any); ok && len(examples) > 0 {
+		return examples[0], true
+	}
+
+	if schema.Example != nil {
+		return schema.Example, true
+	}
+
+	if schema.hasConst() {
+		return schema.Const, true
+	}
+
+	if schema.Default != nil {
+		return schema.Default, true
+	}
+
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0], true
+	}
+
+	return nil, false
+}
+
+// exampleType returns the type of the example of a schema: its first type other than null,
+// or the type implied by its properties or items.
+func exampleType(schema Schema) string {
+	for _, tpe := range schema.Type {
+		if tpe != "null" {
+			return tpe
+		}
+	}
+
+	switch {
+	case len(schema.Properties) > 0:
+		return "object"
+	case schema.Items != nil:
+		return "array"
+	}
+
+	return ""
+}
+
+// numberExample returns zero, unless it's out of the bounds of the schema.
+func numberExample(schema Schema) float64 {
+	var value float64
+
+	if schema.Minimum != nil && value < *schema.Minimum {
+		value = *schema.Minimum
+	}
+	if lower, ok := schema.EffectiveExclusiveMinimum(); ok && value <= lower {
+		value = lower + 1
+	}
+	if schema.Maximum != nil && value > *schema.Maximum {
+		value = *schema.Maximum
+	}
+	if upper, ok := schema.EffectiveExclusiveMaximum(); ok && value >= upper {
+		value = upper - 1
+	}
+
+	return value
+}
+
+// stringExample returns the sample of the format of a string schema, or a plain string, within the length bounds
+// of the schema.
+func stringExample(schema Schema) (string, error) {
+	sample, ok := exampleFormats[schema.Format]
+	if !ok || !fitsLength(schema, sample) {
+		const plain = "string"
+
+		length := int64(len(plain))
+		if schema.MinLength != nil {
+			length = max(length, *schema.MinLength)
+		}
+		if schema.MaxLength != nil {
+			length = max(min(length, *schema.MaxLength), 0)
+		}
+		sample = strings.Repeat(plain, int(length)/len(plain)+1)[:length]
+	}
+
+	if schema.Pattern != "" {
+		if rex, err := regexp.Compile(schema.Pattern); err == nil && !rex.MatchString(sample) {
+			return "", fmt.Errorf("cannot generate a string matching the pattern %q: %w", schema.Pattern, errors.ErrUnsupported)
+		}
+	}
+
+	return sample, nil
+}
+
+func fitsLength(schema Schema, value string) bool {
+	length := int64(utf8.RuneCountInString(value))
+
+	return (schema.MinLength == nil || length >= *schema.MinLength) && (schema.MaxLength == nil || length <= *schema.MaxLength)
+}
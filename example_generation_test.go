@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSchema_GenerateExample(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"account": {
+					"type": "object",
+					"properties": {
+						"id": {"type": "string", "format": "uuid", "readOnly": true},
+						"email": {"type": "string", "format": "email"},
+						"password": {"type": "string", "writeOnly": true},
+						"age": {"type": "integer", "minimum": 18},
+						"score": {"type": "number", "exclusiveMinimum": 0, "maximum": 0.5},
+						"role": {"type": "string", "enum": ["admin", "user"]},
+						"nickname": {"type": ["string", "null"], "examples": ["rex"]},
+						"tags": {"type": "array", "items": {"type": "string", "default": "new"}},
+						"verified": {"type": "boolean"},
+						"avatar": {"type": "string", "format": "byte"},
+						"contact": {"oneOf": [{"$ref": "#/components/schemas/phone"}, {"type": "string"}]}
+					}
+				},
+				"phone": {"type": "object", "properties": {"number": {"type": "string", "example": "+33 1 23 45 67 89"}}},
+				"tree": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"},
+						"children": {"type": "array", "items": {"$ref": "#/components/schemas/tree"}}
+					}
+				}
+			}
+		}
+	}`), &root))
+
+	account := RefSchema("#/components/schemas/account")
+
+	t.Run("should omit writeOnly properties from response examples", func(t *testing.T) {
+		example, err := account.GenerateExample(root, ResponseExample)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]any{
+			"id":       "00000000-0000-0000-0000-000000000000",
+			"email":    "user@example.com",
+			"age":      float64(18),
+			"score":    0.5,
+			"role":     "admin",
+			"nickname": "rex",
+			"tags":     []any{"new"},
+			"verified": true,
+			"avatar":   "ZXhhbXBsZQ==",
+			"contact":  map[string]any{"number": "+33 1 23 45 67 89"},
+		}, example)
+	})
+
+	t.Run("should generate valid byte strings", func(t *testing.T) {
+		byteSchema := StrFmtProperty("byte")
+		example, err := byteSchema.GenerateExample(nil, RequestExample)
+		require.NoError(t, err)
+		assert.Empty(t, byteSchema.Validate(example, nil))
+	})
+
+	t.Run("should honor the length of strings", func(t *testing.T) {
+		for _, schema := range []*Schema{
+			StringProperty().WithMinLength(10),
+			StringProperty().WithMaxLength(3),
+			StrFmtProperty("email").WithMaxLength(5),
+			StrFmtProperty("uuid").WithMinLength(36).WithMaxLength(36),
+		} {
+			example, err := schema.GenerateExample(nil, RequestExample)
+			require.NoError(t, err)
+			assert.Empty(t, schema.Validate(example, nil), "example %v", example)
+		}
+	})
+
+	t.Run("should fail on strings with a pattern the sample doesn't match", func(t *testing.T) {
+		_, err := StringProperty().WithPattern(`^[0-9]+$`).GenerateExample(nil, RequestExample)
+		require.ErrorIs(t, err, errors.ErrUnsupported)
+
+		example, err := StringProperty().WithPattern(`^[a-z]+$`).GenerateExample(nil, RequestExample)
+		require.NoError(t, err)
+		assert.Equal(t, "string", example)
+	})
+
+	t.Run("should omit readOnly properties from request examples", func(t *testing.T) {
+		example, err := account.GenerateExample(root, RequestExample)
+		require.NoError(t, err)
+
+		require.IsType(t, map[string]any{}, example)
+		object := example.(map[string]any)
+		assert.NotContains(t, object, "id")
+		assert.Equal(t, "string", object["password"])
+	})
+
+	t.Run("should stop on circular $ref", func(t *testing.T) {
+		example, err := RefSchema("#/components/schemas/tree").GenerateExample(root, ResponseExample)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]any{"name": "string", "children": []any{}}, example)
+	})
+
+	t.Run("should fail on unresolved $ref", func(t *testing.T) {
+		_, err := RefSchema("#/components/schemas/missing").GenerateExample(root, ResponseExample)
+		require.Error(t, err)
+	})
+}
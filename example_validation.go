@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// ValidateExamples checks that examples are valid against their schema.
+//
+// This applies to the "example" and "examples" of parameters and media types, as well as
+// the "example" of schemas, found in paths, webhooks and components. Example objects with an externalValue
+// are not checked, nor are parameters and media types without a schema.
+//
+// $ref's in schemas and examples are resolved against the document: examples which cannot be resolved are
+// reported as well. Each problem is reported as a *ValidationError located by a JSON pointer to the faulty value.
+func (s *Swagger) ValidateExamples() []error {
+	v := &exampleValidator{root: s}
+
+	if s.Components != nil {
+		v.schemas(s.Components.Schemas, pointerTo("components", "schemas"))
+		v.parameters(s.Components.Parameters, pointerTo("components", "parameters"))
+
+		for _, name := range slices.Sorted(maps.Keys(s.Components.RequestBodies)) {
+			v.content(s.Components.RequestBodies[name].Content, pointerTo("components", "requestBodies", name, "content"))
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(s.Components.Responses)) {
+			v.content(s.Components.Responses[name].Content, pointerTo("components", "responses", name, "content"))
+		}
+	}
+
+	if s.Components == nil {
+		// definitions are otherwise synced with components.schemas
+		v.schemas(s.Definitions, pointerTo("definitions"))
+	}
+
+	if s.Paths != nil {
+		v.pathItems(s.Paths.Paths, "paths")
+	}
+	v.pathItems(s.Webhooks, "webhooks")
+
+	return v.errs
+}
+
+type exampleValidator struct {
+	root any
+	errs []error
+}
+
+func (v *exampleValidator) pathItems(items map[string]PathItem, section string) {
+	for _, pth := range slices.Sorted(maps.Keys(items)) {
+		item := items[pth]
+		location := pointerTo(section, pth)
+
+		v.parameterList(item.Parameters, location)
+
+		for method, op := range item.operations() {
+			v.operation(op, location+pointerTo(method))
+		}
+	}
+}
+
+func (v *exampleValidator) operation(op *Operation, location string) {
+	v.parameterList(op.Parameters, location)
+
+	if op.RequestBody != nil {
+		v.content(op.RequestBody.Content, location+pointerTo("requestBody", "content"))
+	}
+
+	if op.Responses == nil {
+		return
+	}
+
+	if op.Responses.Default != nil {
+		v.content(op.Responses.Default.Content, location+pointerTo("responses", "default", "content"))
+	}
+
+	for _, code := range slices.Sorted(maps.Keys(op.Responses.StatusCodeResponses)) {
+		v.content(op.Responses.StatusCodeResponses[code].Content, location+pointerTo("responses", strconv.Itoa(code), "content"))
+	}
+
+	for _, class := range slices.Sorted(maps.Keys(op.Responses.StatusRangeResponses)) {
+		v.content(op.Responses.StatusRangeResponses[class].Content, location+pointerTo("responses", statusRange(class), "content"))
+	}
+}
+
+func (v *exampleValidator) parameterList(params []Parameter, location string) {
+	for i, param := range params {
+		v.parameter(param, location+pointerTo("parameters", strconv.Itoa(i)))
+	}
+}
+
+func (v *exampleValidator) parameters(params map[string]Parameter, location string) {
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		v.parameter(params[name], location+pointerTo(name))
+	}
+}
+
+func (v *exampleValidator) parameter(param Parameter, location string) {
+	if param.Ref.String() != "" {
+		return
+	}
+
+	v.content(param.Content, location+pointerTo("content"))
+
+	if param.Schema == nil {
+		return
+	}
+
+	v.examples(*param.Schema, param.Example, param.Examples, location)
+	v.schema(*param.Schema, location+pointerTo("schema"))
+}
+
+func (v *exampleValidator) content(content map[string]MediaType, location string) {
+	for _, mediaType := range slices.Sorted(maps.Keys(content)) {
+		media := content[mediaType]
+		if media.Schema == nil {
+			continue
+		}
+
+		mediaLocation := location + pointerTo(mediaType)
+		v.examples(*media.Schema, media.Example, media.Examples, mediaLocation)
+		v.schema(*media.Schema, mediaLocation+pointerTo("schema"))
+	}
+}
+
+// examples validates the example and examples of a parameter or a media type.
+func (v *exampleValidator) examples(schema Schema, example any, examples map[string]Example, location string) {
+	if example != nil {
+		v.value(schema, example, location+pointerTo("example"))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(examples)) {
+		ex, err := examples[name].Resolve(v.root)
+		if err != nil {
+			v.errs = append(v.errs, &ValidationError{Path: location + pointerTo("examples", name), Message: err.Error()})
+
+			continue
+		}
+		if ex.Value == nil {
+			continue
+		}
+
+		v.value(schema, ex.Value, location+pointerTo("examples", name, "value"))
+	}
+}
+
+func (v *exampleValidator) schemas(schemas map[string]Schema, location string) {
+	for _, name := range slices.Sorted(maps.Keys(schemas)) {
+		v.schema(schemas[name], location+pointerTo(name))
+	}
+}
+
+// schema validates the examples of a schema and of all the schemas nested in it.
+func (v *exampleValidator) schema(schema Schema, location string) {
+	walkSchema(schema, location, func(sch Schema, pointer string) {
+		if sch.Example != nil {
+			v.value(sch, sch.Example, pointer+pointerTo("example"))
+		}
+	})
+}
+
+func (v *exampleValidator) value(schema Schema, value any, location string) {
+	for _, err := range schema.Validate(value, v.root) {
+		var verr *ValidationError
+		if errors.As(err, &verr) {
+			verr.Path = location + verr.Path
+		}
+		v.errs = append(v.errs, err)
+	}
+}
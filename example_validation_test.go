@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSwagger_ValidateExamples(t *testing.T) {
+	var doc Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [
+						{"name": "limit", "in": "query", "schema": {"type": "integer", "maximum": 100}, "example": 500},
+						{"name": "tag", "in": "query", "schema": {"type": "string"}, "examples": {"dog": {"value": "dog"}, "cat": {"$ref": "#/components/examples/cat"}}}
+					],
+					"responses": {
+						"200": {
+							"description": "ok",
+							"content": {
+								"application/json": {
+									"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}},
+									"example": [{"name": "rex", "age": -1}]
+								}
+							}
+						}
+					}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Pet": {
+					"type": "object",
+					"required": ["name"],
+					"properties": {
+						"name": {"type": "string"},
+						"age": {"type": "integer", "minimum": 0, "example": 3}
+					},
+					"example": {"age": 2}
+				}
+			}
+		}
+	}`), &doc))
+
+	errs := doc.ValidateExamples()
+	require.Len(t, errs, 4)
+	for _, err := range errs {
+		require.ErrorIs(t, err, ErrSpec)
+	}
+	assert.EqualError(t, errs[0], `/components/schemas/Pet/example: required property "name" is missing`)
+	assert.EqualError(t, errs[1], `/paths/~1pets/get/parameters/0/example: value 500 should be less than or equal to 100`)
+	assert.ErrorContains(t, errs[2], `/paths/~1pets/get/parameters/1/examples/cat: `)
+	assert.ErrorContains(t, errs[2], `#/components/examples/cat`)
+	assert.EqualError(t, errs[3], `/paths/~1pets/get/responses/200/content/application~1json/example/0/age: value -1 should be greater than or equal to 0`)
+}
@@ -4,8 +4,12 @@
 package spec
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 )
 
 const smallPrealloc = 10
@@ -18,12 +22,101 @@ const smallPrealloc = 10
 // all relative $ref's will be resolved from there.
 //
 // PathLoader injects a document loading method. By default, this resolves to the function provided by the SpecLoader package variable.
+// Resolver takes precedence over PathLoaderContext and PathLoader, e.g. to fetch documents from a database.
+// Only one document loading method is used: Resolver when set, otherwise PathLoaderContext when set,
+// otherwise PathLoader, otherwise the package level PathLoader.
+//
+// Trace collects the provenance of expanded content. It is populated as $ref's are resolved.
+//
+// MaxExpandedSize protects against documents which reference large objects from many places:
+// expansion aborts with an *ExpandedSizeError whenever the budget is exceeded, even when ContinueOnError is set.
+//
+// PreserveNameAsTitle keeps track of the names of inlined schemas, e.g. for generators to produce meaningful type names:
+// a schema inlined from "#/components/schemas/Order" or "#/definitions/Order" gets "Order" as title, unless it has one.
+//
+// Targets selects the kinds of objects which $ref's are expanded. Other $ref's are kept, rebased on the root document.
+// Request bodies and headers are kept unless selected, with the $ref's of their content rebased as well.
+// When Targets is left empty, the selection is TargetDefault, i.e. all kinds but request bodies and headers.
+// SkipSchemas removes TargetSchemas from the selection.
 type ExpandOptions struct {
-	RelativeBase        string                                // the path to the root document to expand. This is a file, not a directory
-	SkipSchemas         bool                                  // do not expand schemas, just paths, parameters and responses
-	ContinueOnError     bool                                  // continue expanding even after and error is found
-	PathLoader          func(string) (json.RawMessage, error) `json:"-"` // the document loading method that takes a path as input and yields a json document
-	AbsoluteCircularRef bool                                  // circular $ref remaining after expansion remain absolute URLs
+	RelativeBase        string                                                 // the path to the root document to expand. This is a file, not a directory
+	SkipSchemas         bool                                                   // do not expand schemas, just paths, parameters and responses
+	Targets             ExpandTarget                                           // the kinds of objects to expand, TargetDefault when empty
+	PreserveNameAsTitle bool                                                   // inlined named schemas without a title get their name as title
+	ContinueOnError     bool                                                   // continue expanding even after and error is found
+	PathLoader          func(string) (json.RawMessage, error)                  `json:"-"` // the document loading method that takes a path as input and yields a json document
+	PathLoaderContext   func(context.Context, string) (json.RawMessage, error) `json:"-"` // when set, the document loading method preferred over PathLoader, which is passed the context of ExpandSpecContext
+	Resolver            ReferenceResolver                                      `json:"-"` // when set, fetches the documents targeted by $ref's, in place of PathLoaderContext and PathLoader
+	AbsoluteCircularRef bool                                                   // circular $ref remaining after expansion remain absolute URLs
+	Trace               *ExpansionTrace                                        `json:"-"` // when set, records where the content of each resolved $ref ended up
+	MaxExpandedSize     int                                                    // when positive, the approximate size in bytes of the serialized expanded document must not exceed this budget
+}
+
+// ExpandTarget is a kind of object which $ref's may be expanded.
+//
+// Targets are combined as a bitmask, e.g. TargetResponses | TargetSchemas.
+type ExpandTarget uint
+
+// Kinds of objects which $ref's may be expanded
+const (
+	TargetSchemas ExpandTarget = 1 << iota
+	TargetParameters
+	TargetResponses
+	TargetRequestBodies
+	TargetHeaders
+	TargetExamples
+	TargetCallbacks
+
+	TargetAll = TargetSchemas | TargetParameters | TargetResponses | TargetRequestBodies | TargetHeaders | TargetExamples | TargetCallbacks
+
+	// TargetDefault keeps request bodies and headers as they are, as the expander always did
+	TargetDefault = TargetAll &^ (TargetRequestBodies | TargetHeaders)
+)
+
+// expands tells if the $ref's of some kind of objects should be expanded.
+func (o *ExpandOptions) expands(target ExpandTarget) bool {
+	targets := o.Targets
+	if targets == 0 {
+		targets = TargetDefault
+	}
+	if o.SkipSchemas {
+		targets &^= TargetSchemas
+	}
+
+	return targets&target != 0
+}
+
+// pathLoader yields the document loading method of the options, without context.
+func (o *ExpandOptions) pathLoader() func(string) (json.RawMessage, error) {
+	switch {
+	case o.Resolver != nil:
+		loader := o.resolverLoader()
+		return func(pth string) (json.RawMessage, error) {
+			return loader(context.Background(), pth)
+		}
+	case o.PathLoaderContext != nil:
+		return func(pth string) (json.RawMessage, error) {
+			return o.PathLoaderContext(context.Background(), pth)
+		}
+	case o.PathLoader != nil:
+		return o.PathLoader
+	default:
+		return PathLoader
+	}
+}
+
+// resolverLoader adapts the Resolver of the options to a document loading method.
+func (o *ExpandOptions) resolverLoader() func(context.Context, string) (json.RawMessage, error) {
+	resolver, base := o.Resolver, o.RelativeBase
+
+	return func(ctx context.Context, pth string) (json.RawMessage, error) {
+		ref, err := NewRef(pth)
+		if err != nil {
+			return nil, err
+		}
+
+		return resolver.Resolve(ctx, ref, base)
+	}
 }
 
 func optionsOrDefault(opts *ExpandOptions) *ExpandOptions {
@@ -40,18 +133,32 @@ func optionsOrDefault(opts *ExpandOptions) *ExpandOptions {
 
 // ExpandSpec expands the references in a swagger spec
 func ExpandSpec(spec *Swagger, options *ExpandOptions) error {
+	return ExpandSpecContext(context.Background(), spec, options)
+}
+
+// ExpandSpecContext expands the references in a swagger spec, until ctx is done.
+//
+// The context is checked before each $ref is resolved: when it is done, expansion stops with the error of ctx,
+// even with the ContinueOnError option. Remote documents are fetched with the PathLoaderContext of options,
+// which receives ctx, e.g. to cancel HTTP requests. Without any loader in options, the HTTP requests of the default
+// loader are canceled with ctx as well. Other loaders are not interrupted while fetching a document.
+func ExpandSpecContext(ctx context.Context, spec *Swagger, options *ExpandOptions) error {
 	options = optionsOrDefault(options)
 	resolver := defaultSchemaLoader(spec, options, nil, nil)
+	resolver.context.ctx = ctx
+	if err := resolver.growExpandedSize(spec); err != nil {
+		return err
+	}
 
 	specBasePath := options.RelativeBase
 
 	// Handle OpenAPI 3.x Components.Schemas
-	if !options.SkipSchemas && spec.Components != nil {
+	if options.expands(TargetSchemas) && spec.Components != nil {
 		for key, schema := range spec.Components.Schemas {
 			parentRefs := make([]string, 0, smallPrealloc)
 			parentRefs = append(parentRefs, "#/components/schemas/"+key)
 
-			def, err := expandSchema(schema, parentRefs, resolver, specBasePath)
+			def, err := expandSchema(schema, parentRefs, resolver, specBasePath, pointerTo("components", "schemas", key))
 			if resolver.shouldStopOnError(err) {
 				return err
 			}
@@ -62,12 +169,12 @@ func ExpandSpec(spec *Swagger, options *ExpandOptions) error {
 	}
 
 	// Handle Swagger 2.0 Definitions (backward compatibility)
-	if !options.SkipSchemas && spec.Definitions != nil {
+	if options.expands(TargetSchemas) && spec.Definitions != nil {
 		for key, schema := range spec.Definitions {
 			parentRefs := make([]string, 0, smallPrealloc)
 			parentRefs = append(parentRefs, "#/definitions/"+key)
 
-			def, err := expandSchema(schema, parentRefs, resolver, specBasePath)
+			def, err := expandSchema(schema, parentRefs, resolver, specBasePath, pointerTo("definitions", key))
 			if resolver.shouldStopOnError(err) {
 				return err
 			}
@@ -81,7 +188,7 @@ func ExpandSpec(spec *Swagger, options *ExpandOptions) error {
 	if spec.Components != nil {
 		for key := range spec.Components.Parameters {
 			parameter := spec.Components.Parameters[key]
-			if err := expandParameterOrResponse(&parameter, resolver, specBasePath); resolver.shouldStopOnError(err) {
+			if err := expandParameterOrResponse(&parameter, resolver, specBasePath, pointerTo("components", "parameters", key)); resolver.shouldStopOnError(err) {
 				return err
 			}
 			spec.Components.Parameters[key] = parameter
@@ -89,18 +196,51 @@ func ExpandSpec(spec *Swagger, options *ExpandOptions) error {
 
 		for key := range spec.Components.Responses {
 			response := spec.Components.Responses[key]
-			if err := expandParameterOrResponse(&response, resolver, specBasePath); resolver.shouldStopOnError(err) {
+			if err := expandParameterOrResponse(&response, resolver, specBasePath, pointerTo("components", "responses", key)); resolver.shouldStopOnError(err) {
 				return err
 			}
 			spec.Components.Responses[key] = response
 		}
+
+		for key := range spec.Components.RequestBodies {
+			requestBody := spec.Components.RequestBodies[key]
+			if err := expandRequestBody(&requestBody, resolver, specBasePath, pointerTo("components", "requestBodies", key)); resolver.shouldStopOnError(err) {
+				return err
+			}
+			spec.Components.RequestBodies[key] = requestBody
+		}
+
+		for key := range spec.Components.Headers {
+			header := spec.Components.Headers[key]
+			if err := expandHeader(&header, resolver, specBasePath, pointerTo("components", "headers", key)); resolver.shouldStopOnError(err) {
+				return err
+			}
+			spec.Components.Headers[key] = header
+		}
+
+		for key := range spec.Components.Callbacks {
+			callback := spec.Components.Callbacks[key]
+			if err := expandCallback(&callback, resolver, specBasePath, pointerTo("components", "callbacks", key)); resolver.shouldStopOnError(err) {
+				return err
+			}
+			spec.Components.Callbacks[key] = callback
+		}
+
+		// OpenAPI 3.1 reusable path items
+		for key := range spec.Components.PathItems {
+			pathItem := spec.Components.PathItems[key]
+			if err := expandPathItem(&pathItem, resolver, specBasePath, pointerTo("components", "pathItems", key)); resolver.shouldStopOnError(err) {
+				return err
+			}
+			spec.Components.PathItems[key] = pathItem
+		}
 	}
 
 	// Handle Swagger 2.0 top-level Parameters (backward compatibility)
 	if spec.Parameters != nil {
 		for key := range spec.Parameters {
 			parameter := spec.Parameters[key]
-			if err := expandParameterOrResponse(&parameter, resolver, specBasePath); resolver.shouldStopOnError(err) {
+			if err := expandParameterOrResponse(&parameter, resolver, specBasePath, pointerTo("parameters", key)); resolver.shouldStopOnError(err) {
 				return err
 			}
 			spec.Parameters[key] = parameter
@@ -111,7 +251,7 @@ func ExpandSpec(spec *Swagger, options *ExpandOptions) error {
 	if spec.Responses != nil {
 		for key := range spec.Responses {
 			response := spec.Responses[key]
-			if err := expandParameterOrResponse(&response, resolver, specBasePath); resolver.shouldStopOnError(err) {
+			if err := expandParameterOrResponse(&response, resolver, specBasePath, pointerTo("responses", key)); resolver.shouldStopOnError(err) {
 				return err
 			}
 			spec.Responses[key] = response
@@ -121,16 +261,41 @@ func ExpandSpec(spec *Swagger, options *ExpandOptions) error {
 	if spec.Paths != nil {
 		for key := range spec.Paths.Paths {
 			pth := spec.Paths.Paths[key]
-			if err := expandPathItem(&pth, resolver, specBasePath); resolver.shouldStopOnError(err) {
+			if err := expandPathItem(&pth, resolver, specBasePath, pointerTo("paths", key)); resolver.shouldStopOnError(err) {
 				return err
 			}
 			spec.Paths.Paths[key] = pth
 		}
 	}
 
+	for key := range spec.Webhooks {
+		webhook := spec.Webhooks[key]
+		if err := expandPathItem(&webhook, resolver, specBasePath, pointerTo("webhooks", key)); resolver.shouldStopOnError(err) {
+			return err
+		}
+		spec.Webhooks[key] = webhook
+	}
+
 	return nil
 }
 
+// ResolvedView returns a fully expanded copy of a swagger spec, leaving the spec itself untouched.
+//
+// The copy is a deep clone of the spec, expanded with ExpandSpec: the original spec keeps its $ref's,
+// e.g. to serve both the compact and the resolved forms of a document.
+func ResolvedView(spec *Swagger, options *ExpandOptions) (*Swagger, error) {
+	view, err := deepCloneJSON(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ExpandSpec(view, options); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
 const rootBase = ".root"
 
 // baseForRoot loads in the cache the root document and produces a fake ".root" base path entry
@@ -192,6 +357,9 @@ func ExpandSchemaWithBasePath(schema *Schema, cache ResolutionCache, opts *Expan
 	opts = optionsOrDefault(opts)
 
 	resolver := defaultSchemaLoader(nil, opts, cache, nil)
+	if err := resolver.growExpandedSize(schema); err != nil {
+		return err
+	}
 
 	// Preserve $defs from the original schema, as JSON Schema 2020-12 allows
 	// $ref alongside other keywords, but our expander replaces the schema with
@@ -200,7 +368,7 @@ func ExpandSchemaWithBasePath(schema *Schema, cache ResolutionCache, opts *Expan
 	originalDefinitions := schema.Definitions
 
 	parentRefs := make([]string, 0, smallPrealloc)
-	s, err := expandSchema(*schema, parentRefs, resolver, opts.RelativeBase)
+	s, err := expandSchema(*schema, parentRefs, resolver, opts.RelativeBase, "")
 	if err != nil {
 		return err
 	}
@@ -220,14 +388,14 @@ func ExpandSchemaWithBasePath(schema *Schema, cache ResolutionCache, opts *Expan
 	return nil
 }
 
-func expandItems(target Schema, parentRefs []string, resolver *schemaLoader, basePath string) (*Schema, error) {
+func expandItems(target Schema, parentRefs []string, resolver *schemaLoader, basePath, location string) (*Schema, error) {
 	if target.Items == nil {
 		return &target, nil
 	}
 
 	// array
 	if target.Items.Schema != nil {
-		t, err := expandSchema(*target.Items.Schema, parentRefs, resolver, basePath)
+		t, err := expandSchema(*target.Items.Schema, parentRefs, resolver, basePath, location+pointerTo("items"))
 		if err != nil {
 			return nil, err
 		}
@@ -236,7 +404,7 @@ func expandItems(target Schema, parentRefs []string, resolver *schemaLoader, bas
 
 	// tuple
 	for i := range target.Items.Schemas {
-		t, err := expandSchema(target.Items.Schemas[i], parentRefs, resolver, basePath)
+		t, err := expandSchema(target.Items.Schemas[i], parentRefs, resolver, basePath, location+pointerTo("items", strconv.Itoa(i)))
 		if err != nil {
 			return nil, err
 		}
@@ -246,7 +414,7 @@ func expandItems(target Schema, parentRefs []string, resolver *schemaLoader, bas
 	return &target, nil
 }
 
-func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, basePath string) (*Schema, error) {
+func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, basePath, location string) (*Schema, error) {
 	if target.Ref.String() == "" && target.Ref.IsRoot() {
 		newRef := normalizeRef(&target.Ref, basePath)
 		target.Ref = *newRef
@@ -260,23 +428,21 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 	}
 
 	if target.Ref.String() != "" {
-		if !resolver.options.SkipSchemas {
-			return expandSchemaRef(target, parentRefs, resolver, basePath)
+		if resolver.options.expands(TargetSchemas) {
+			return expandSchemaRef(target, parentRefs, resolver, basePath, location)
 		}
 
 		// when "expand" with SkipSchema, we just rebase the existing $ref without replacing
 		// the full schema.
-		rebasedRef, err := NewRef(normalizeURI(target.Ref.String(), basePath))
-		if err != nil {
+		if err := rebaseRef(&target.Ref, resolver, basePath); err != nil {
 			return nil, err
 		}
-		target.Ref = denormalizeRef(&rebasedRef, resolver.context.basePath, resolver.context.rootID)
 
 		return &target, nil
 	}
 
 	for k := range target.Definitions {
-		tt, err := expandSchema(target.Definitions[k], parentRefs, resolver, basePath)
+		tt, err := expandSchema(target.Definitions[k], parentRefs, resolver, basePath, location+pointerTo("definitions", k))
 		if resolver.shouldStopOnError(err) {
 			return &target, err
 		}
@@ -287,7 +453,7 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 
 	// JSON Schema 2020-12 uses $defs instead of definitions
 	for k := range target.Defs {
-		tt, err := expandSchema(target.Defs[k], parentRefs, resolver, basePath)
+		tt, err := expandSchema(target.Defs[k], parentRefs, resolver, basePath, location+pointerTo("$defs", k))
 		if resolver.shouldStopOnError(err) {
 			return &target, err
 		}
@@ -296,7 +462,7 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 		}
 	}
 
-	t, err := expandItems(target, parentRefs, resolver, basePath)
+	t, err := expandItems(target, parentRefs, resolver, basePath, location)
 	if resolver.shouldStopOnError(err) {
 		return &target, err
 	}
@@ -305,7 +471,7 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 	}
 
 	for i := range target.AllOf {
-		t, err := expandSchema(target.AllOf[i], parentRefs, resolver, basePath)
+		t, err := expandSchema(target.AllOf[i], parentRefs, resolver, basePath, location+pointerTo("allOf", strconv.Itoa(i)))
 		if resolver.shouldStopOnError(err) {
 			return &target, err
 		}
@@ -315,7 +481,7 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 	}
 
 	for i := range target.AnyOf {
-		t, err := expandSchema(target.AnyOf[i], parentRefs, resolver, basePath)
+		t, err := expandSchema(target.AnyOf[i], parentRefs, resolver, basePath, location+pointerTo("anyOf", strconv.Itoa(i)))
 		if resolver.shouldStopOnError(err) {
 			return &target, err
 		}
@@ -325,7 +491,7 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 	}
 
 	for i := range target.OneOf {
-		t, err := expandSchema(target.OneOf[i], parentRefs, resolver, basePath)
+		t, err := expandSchema(target.OneOf[i], parentRefs, resolver, basePath, location+pointerTo("oneOf", strconv.Itoa(i)))
 		if resolver.shouldStopOnError(err) {
 			return &target, err
 		}
@@ -334,8 +500,18 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 		}
 	}
 
+	if target.Contains != nil {
+		t, err := expandSchema(*target.Contains, parentRefs, resolver, basePath, location+pointerTo("contains"))
+		if resolver.shouldStopOnError(err) {
+			return &target, err
+		}
+		if t != nil {
+			*target.Contains = *t
+		}
+	}
+
 	if target.Not != nil {
-		t, err := expandSchema(*target.Not, parentRefs, resolver, basePath)
+		t, err := expandSchema(*target.Not, parentRefs, resolver, basePath, location+pointerTo("not"))
 		if resolver.shouldStopOnError(err) {
 			return &target, err
 		}
@@ -345,7 +521,7 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 	}
 
 	for k := range target.Properties {
-		t, err := expandSchema(target.Properties[k], parentRefs, resolver, basePath)
+		t, err := expandSchema(target.Properties[k], parentRefs, resolver, basePath, location+pointerTo("properties", k))
 		if resolver.shouldStopOnError(err) {
 			return &target, err
 		}
@@ -355,7 +531,7 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 	}
 
 	if target.AdditionalProperties != nil && target.AdditionalProperties.Schema != nil {
-		t, err := expandSchema(*target.AdditionalProperties.Schema, parentRefs, resolver, basePath)
+		t, err := expandSchema(*target.AdditionalProperties.Schema, parentRefs, resolver, basePath, location+pointerTo("additionalProperties"))
 		if resolver.shouldStopOnError(err) {
 			return &target, err
 		}
@@ -366,7 +542,7 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 
 	for k := range target.PatternProperties {
 		if target.PatternProperties[k].Schema != nil {
-			t, err := expandSchema(*target.PatternProperties[k].Schema, parentRefs, resolver, basePath)
+			t, err := expandSchema(*target.PatternProperties[k].Schema, parentRefs, resolver, basePath, location+pointerTo("patternProperties", k))
 			if resolver.shouldStopOnError(err) {
 				return &target, err
 			}
@@ -378,9 +554,19 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 		}
 	}
 
+	if target.PropertyNames != nil {
+		t, err := expandSchema(*target.PropertyNames, parentRefs, resolver, basePath, location+pointerTo("propertyNames"))
+		if resolver.shouldStopOnError(err) {
+			return &target, err
+		}
+		if t != nil {
+			*target.PropertyNames = *t
+		}
+	}
+
 	for k := range target.Dependencies {
 		if target.Dependencies[k].Schema != nil {
-			t, err := expandSchema(*target.Dependencies[k].Schema, parentRefs, resolver, basePath)
+			t, err := expandSchema(*target.Dependencies[k].Schema, parentRefs, resolver, basePath, location+pointerTo("dependencies", k))
 			if resolver.shouldStopOnError(err) {
 				return &target, err
 			}
@@ -391,7 +577,7 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 	}
 
 	if target.AdditionalItems != nil && target.AdditionalItems.Schema != nil {
-		t, err := expandSchema(*target.AdditionalItems.Schema, parentRefs, resolver, basePath)
+		t, err := expandSchema(*target.AdditionalItems.Schema, parentRefs, resolver, basePath, location+pointerTo("additionalItems"))
 		if resolver.shouldStopOnError(err) {
 			return &target, err
 		}
@@ -402,8 +588,8 @@ func expandSchema(target Schema, parentRefs []string, resolver *schemaLoader, ba
 	return &target, nil
 }
 
-func expandSchemaRef(target Schema, parentRefs []string, resolver *schemaLoader, basePath string) (*Schema, error) {
-	// if a Ref is found, all sibling fields are skipped
+func expandSchemaRef(target Schema, parentRefs []string, resolver *schemaLoader, basePath, location string) (*Schema, error) {
+	// if a Ref is found, all sibling fields are skipped, but the description
 	// Ref also changes the resolution scope of children expandSchema
 
 	// here the resolution scope is changed because a $ref was encountered
@@ -435,24 +621,45 @@ func expandSchemaRef(target Schema, parentRefs []string, resolver *schemaLoader,
 		return &target, nil
 	}
 
+	if err := resolver.growExpandedSize(t); err != nil {
+		return nil, err
+	}
+
+	resolver.options.Trace.record(target.Ref, normalizedRef, location)
+
+	if resolver.options.PreserveNameAsTitle && t.Title == "" {
+		t.Title = schemaName(normalizedRef)
+	}
+
 	parentRefs = append(parentRefs, normalizedRef.String())
 	transitiveResolver := resolver.transitiveResolver(basePath, target.Ref)
 
 	basePath = resolver.updateBasePath(transitiveResolver, normalizedBasePath)
 
-	return expandSchema(*t, parentRefs, transitiveResolver, basePath)
+	expanded, err := expandSchema(*t, parentRefs, transitiveResolver, basePath, location)
+	if expanded != nil && target.Description != "" && resolver.context.refSiblings {
+		// as allowed since OpenAPI 3.1, a description set next to a $ref overrides the description of its target
+		expanded.Description = target.Description
+	}
+
+	return expanded, err
 }
 
-func expandPathItem(pathItem *PathItem, resolver *schemaLoader, basePath string) error {
+func expandPathItem(pathItem *PathItem, resolver *schemaLoader, basePath, location string) error {
 	if pathItem == nil {
 		return nil
 	}
 
+	original := pathItem.Ref
 	parentRefs := make([]string, 0, smallPrealloc)
 	if err := resolver.deref(pathItem, parentRefs, basePath); resolver.shouldStopOnError(err) {
 		return err
 	}
 
+	if original.String() != "" {
+		resolver.options.Trace.record(original, normalizeRef(&original, basePath), location)
+	}
+
 	if pathItem.Ref.String() != "" {
 		transitiveResolver := resolver.transitiveResolver(basePath, pathItem.Ref)
 		basePath = transitiveResolver.updateBasePath(resolver, basePath)
@@ -461,22 +668,13 @@ func expandPathItem(pathItem *PathItem, resolver *schemaLoader, basePath string)
 
 	pathItem.Ref = Ref{}
 	for i := range pathItem.Parameters {
-		if err := expandParameterOrResponse(&(pathItem.Parameters[i]), resolver, basePath); resolver.shouldStopOnError(err) {
+		if err := expandParameterOrResponse(&(pathItem.Parameters[i]), resolver, basePath, location+pointerTo("parameters", strconv.Itoa(i))); resolver.shouldStopOnError(err) {
 			return err
 		}
 	}
 
-	ops := []*Operation{
-		pathItem.Get,
-		pathItem.Head,
-		pathItem.Options,
-		pathItem.Put,
-		pathItem.Post,
-		pathItem.Patch,
-		pathItem.Delete,
-	}
-	for _, op := range ops {
-		if err := expandOperation(op, resolver, basePath); resolver.shouldStopOnError(err) {
+	for method, op := range pathItem.operations() {
+		if err := expandOperation(op, resolver, basePath, location+pointerTo(method)); resolver.shouldStopOnError(err) {
 			return err
 		}
 	}
@@ -484,36 +682,81 @@ func expandPathItem(pathItem *PathItem, resolver *schemaLoader, basePath string)
 	return nil
 }
 
-func expandOperation(op *Operation, resolver *schemaLoader, basePath string) error {
+func expandOperation(op *Operation, resolver *schemaLoader, basePath, location string) error {
 	if op == nil {
 		return nil
 	}
 
 	for i := range op.Parameters {
 		param := op.Parameters[i]
-		if err := expandParameterOrResponse(&param, resolver, basePath); resolver.shouldStopOnError(err) {
+		if err := expandParameterOrResponse(&param, resolver, basePath, location+pointerTo("parameters", strconv.Itoa(i))); resolver.shouldStopOnError(err) {
 			return err
 		}
 		op.Parameters[i] = param
 	}
 
+	if err := expandRequestBody(op.RequestBody, resolver, basePath, location+pointerTo("requestBody")); resolver.shouldStopOnError(err) {
+		return err
+	}
+
 	if op.Responses == nil {
 		return nil
 	}
 
 	responses := op.Responses
-	if err := expandParameterOrResponse(responses.Default, resolver, basePath); resolver.shouldStopOnError(err) {
+	if err := expandParameterOrResponse(responses.Default, resolver, basePath, location+pointerTo("responses", "default")); resolver.shouldStopOnError(err) {
 		return err
 	}
 
 	for code := range responses.StatusCodeResponses {
 		response := responses.StatusCodeResponses[code]
-		if err := expandParameterOrResponse(&response, resolver, basePath); resolver.shouldStopOnError(err) {
+		if err := expandParameterOrResponse(&response, resolver, basePath, location+pointerTo("responses", strconv.Itoa(code))); resolver.shouldStopOnError(err) {
 			return err
 		}
 		responses.StatusCodeResponses[code] = response
 	}
 
+	for class := range responses.StatusRangeResponses {
+		response := responses.StatusRangeResponses[class]
+		if err := expandParameterOrResponse(&response, resolver, basePath, location+pointerTo("responses", statusRange(class))); resolver.shouldStopOnError(err) {
+			return err
+		}
+		responses.StatusRangeResponses[class] = response
+	}
+
+	for key := range op.Callbacks {
+		callback := op.Callbacks[key]
+		if err := expandCallback(&callback, resolver, basePath, location+pointerTo("callbacks", key)); resolver.shouldStopOnError(err) {
+			return err
+		}
+		op.Callbacks[key] = callback
+	}
+
+	return nil
+}
+
+// expandCallback replaces a callback defined by a $ref with its target, then expands the path items of its expressions.
+func expandCallback(callback *Callback, resolver *schemaLoader, basePath, location string) error {
+	if callback.Ref.String() != "" {
+		if !resolver.options.expands(TargetCallbacks) {
+			return rebaseRef(&callback.Ref, resolver, basePath)
+		}
+
+		var err error
+		if resolver, basePath, err = derefWithTrace(callback, &callback.Ref, resolver, basePath, location); err != nil {
+			// the $ref is kept when errors are ignored
+			return err
+		}
+	}
+
+	for expression := range callback.Expressions {
+		pathItem := callback.Expressions[expression]
+		if err := expandPathItem(&pathItem, resolver, basePath, location+pointerTo(expression)); resolver.shouldStopOnError(err) {
+			return err
+		}
+		callback.Expressions[expression] = pathItem
+	}
+
 	return nil
 }
 
@@ -530,7 +773,7 @@ func ExpandResponseWithRoot(response *Response, root any, cache ResolutionCache)
 	}
 	resolver := defaultSchemaLoader(root, opts, cache, nil)
 
-	return expandParameterOrResponse(response, resolver, opts.RelativeBase)
+	return expandParameterOrResponse(response, resolver, opts.RelativeBase, "")
 }
 
 // ExpandResponse expands a response based on a basepath
@@ -542,7 +785,7 @@ func ExpandResponse(response *Response, basePath string) error {
 	})
 	resolver := defaultSchemaLoader(nil, opts, nil, nil)
 
-	return expandParameterOrResponse(response, resolver, opts.RelativeBase)
+	return expandParameterOrResponse(response, resolver, opts.RelativeBase, "")
 }
 
 // ExpandParameterWithRoot expands a parameter based on a root document, not a fetchable document.
@@ -557,7 +800,7 @@ func ExpandParameterWithRoot(parameter *Parameter, root any, cache ResolutionCac
 	}
 	resolver := defaultSchemaLoader(root, opts, cache, nil)
 
-	return expandParameterOrResponse(parameter, resolver, opts.RelativeBase)
+	return expandParameterOrResponse(parameter, resolver, opts.RelativeBase, "")
 }
 
 // ExpandParameter expands a parameter based on a basepath.
@@ -569,7 +812,7 @@ func ExpandParameter(parameter *Parameter, basePath string) error {
 	})
 	resolver := defaultSchemaLoader(nil, opts, nil, nil)
 
-	return expandParameterOrResponse(parameter, resolver, opts.RelativeBase)
+	return expandParameterOrResponse(parameter, resolver, opts.RelativeBase, "")
 }
 
 func getRefAndSchema(input any) (*Ref, *Schema, error) {
@@ -600,7 +843,7 @@ func getRefAndSchema(input any) (*Ref, *Schema, error) {
 	return ref, sch, nil
 }
 
-func expandParameterOrResponse(input any, resolver *schemaLoader, basePath string) error {
+func expandParameterOrResponse(input any, resolver *schemaLoader, basePath, location string) error {
 	ref, sch, err := getRefAndSchema(input)
 	if err != nil {
 		return err
@@ -610,13 +853,23 @@ func expandParameterOrResponse(input any, resolver *schemaLoader, basePath strin
 		return nil
 	}
 
+	if ref != nil && ref.String() != "" && !resolver.options.expands(targetOf(input)) {
+		return rebaseRef(ref, resolver, basePath)
+	}
+
 	parentRefs := make([]string, 0, smallPrealloc)
 	if ref != nil {
+		original := *ref
+
 		// dereference this $ref
 		if err = resolver.deref(input, parentRefs, basePath); resolver.shouldStopOnError(err) {
 			return err
 		}
 
+		if original.String() != "" {
+			resolver.options.Trace.record(original, normalizeRef(&original, basePath), location)
+		}
+
 		ref, sch, _ = getRefAndSchema(input)
 	}
 
@@ -626,47 +879,7 @@ func expandParameterOrResponse(input any, resolver *schemaLoader, basePath strin
 		resolver = transitiveResolver
 	}
 
-	if sch == nil {
-		// For v3, also expand schemas in Content (for both Response and Parameter)
-		if resp, ok := input.(*Response); ok && resp != nil && resp.Content != nil {
-			for mediaType, mediaTypeObj := range resp.Content {
-				if mediaTypeObj.Schema != nil {
-					sch, err := expandSchemaRef(*mediaTypeObj.Schema, parentRefs, resolver, basePath)
-					if resolver.shouldStopOnError(err) {
-						return err
-					}
-					if sch != nil {
-						mediaTypeObj.Schema = sch
-						resp.Content[mediaType] = mediaTypeObj
-					}
-				}
-			}
-		}
-
-		if param, ok := input.(*Parameter); ok && param != nil && param.Content != nil {
-			for mediaType, mediaTypeObj := range param.Content {
-				if mediaTypeObj.Schema != nil {
-					sch, err := expandSchemaRef(*mediaTypeObj.Schema, parentRefs, resolver, basePath)
-					if resolver.shouldStopOnError(err) {
-						return err
-					}
-					if sch != nil {
-						mediaTypeObj.Schema = sch
-						param.Content[mediaType] = mediaTypeObj
-					}
-				}
-			}
-		}
-
-		// nothing else to be expanded
-		if ref != nil {
-			*ref = Ref{}
-		}
-
-		return nil
-	}
-
-	if sch.Ref.String() != "" {
+	if sch != nil && sch.Ref.String() != "" {
 		rebasedRef, ern := NewRef(normalizeURI(sch.Ref.String(), basePath))
 		if ern != nil {
 			return ern
@@ -687,47 +900,211 @@ func expandParameterOrResponse(input any, resolver *schemaLoader, basePath strin
 		*ref = Ref{}
 	}
 
-	// expand schema
-	// yes, we do it even if options.SkipSchema is true: we have to go down that rabbit hole and rebase nested $ref)
-	s, err := expandSchema(*sch, parentRefs, resolver, basePath)
+	if sch != nil {
+		// expand schema
+		// yes, we do it even if options.SkipSchema is true: we have to go down that rabbit hole and rebase nested $ref)
+		s, err := expandSchema(*sch, parentRefs, resolver, basePath, location+pointerTo("schema"))
+		if resolver.shouldStopOnError(err) {
+			return err
+		}
+
+		if s != nil { // guard for when continuing on error
+			*sch = *s
+		}
+	}
+
+	// For v3, also expand schemas in Content (for both Response and Parameter)
+	switch refable := input.(type) {
+	case *Response:
+		for _, name := range slices.Sorted(maps.Keys(refable.Headers)) {
+			header := refable.Headers[name]
+			if err := expandHeader(&header, resolver, basePath, location+pointerTo("headers", name)); resolver.shouldStopOnError(err) {
+				return err
+			}
+			refable.Headers[name] = header
+		}
+
+		return expandContent(refable.Content, parentRefs, resolver, basePath, location)
+	case *Parameter:
+		if err := expandExamples(refable.Examples, resolver, basePath, location); resolver.shouldStopOnError(err) {
+			return err
+		}
+
+		return expandContent(refable.Content, parentRefs, resolver, basePath, location)
+	}
+
+	return nil
+}
+
+func expandRequestBody(requestBody *RequestBody, resolver *schemaLoader, basePath, location string) error {
+	if requestBody == nil {
+		return nil
+	}
+
+	if !resolver.options.expands(TargetRequestBodies) {
+		if requestBody.Ref.String() != "" {
+			return rebaseRef(&requestBody.Ref, resolver, basePath)
+		}
+		resolver = resolver.rebasing()
+	}
+
+	if requestBody.Ref.String() != "" {
+		var err error
+		if resolver, basePath, err = derefWithTrace(requestBody, &requestBody.Ref, resolver, basePath, location); resolver.shouldStopOnError(err) {
+			return err
+		}
+	}
+
+	return expandContent(requestBody.Content, make([]string, 0, smallPrealloc), resolver, basePath, location)
+}
+
+func expandHeader(header *Header, resolver *schemaLoader, basePath, location string) error {
+	if header == nil {
+		return nil
+	}
+
+	if !resolver.options.expands(TargetHeaders) {
+		if header.Ref.String() != "" {
+			return rebaseRef(&header.Ref, resolver, basePath)
+		}
+		resolver = resolver.rebasing()
+	}
+
+	if header.Ref.String() != "" {
+		var err error
+		if resolver, basePath, err = derefWithTrace(header, &header.Ref, resolver, basePath, location); resolver.shouldStopOnError(err) {
+			return err
+		}
+	}
+
+	if header.Schema == nil {
+		return nil
+	}
+
+	sch, err := expandSchema(*header.Schema, make([]string, 0, smallPrealloc), resolver, basePath, location+pointerTo("schema"))
 	if resolver.shouldStopOnError(err) {
 		return err
 	}
+	if sch != nil {
+		header.Schema = sch
+	}
+
+	return nil
+}
 
-	if s != nil { // guard for when continuing on error
-		*sch = *s
+// derefWithTrace replaces a $ref by its target, then yields the resolver and base path to expand
+// the content of this target.
+func derefWithTrace(input any, ref *Ref, resolver *schemaLoader, basePath, location string) (*schemaLoader, string, error) {
+	original := *ref
+	if err := resolver.deref(input, make([]string, 0, smallPrealloc), basePath); err != nil {
+		return resolver, basePath, err
 	}
 
-	// For v3, also expand schemas in Content (for both Response and Parameter)
-	if resp, ok := input.(*Response); ok && resp != nil && resp.Content != nil {
-		for mediaType, mediaTypeObj := range resp.Content {
-			if mediaTypeObj.Schema != nil {
-				sch, err := expandSchemaRef(*mediaTypeObj.Schema, parentRefs, resolver, basePath)
-				if resolver.shouldStopOnError(err) {
-					return err
-				}
-				if sch != nil {
-					mediaTypeObj.Schema = sch
-					resp.Content[mediaType] = mediaTypeObj
-				}
-			}
+	resolver.options.Trace.record(original, normalizeRef(&original, basePath), location)
+
+	if ref.String() != "" {
+		transitiveResolver := resolver.transitiveResolver(basePath, *ref)
+		basePath = resolver.updateBasePath(transitiveResolver, basePath)
+		resolver = transitiveResolver
+	}
+	*ref = Ref{}
+
+	return resolver, basePath, nil
+}
+
+// expandContent expands the schemas and examples of the media types of some content.
+func expandContent(content map[string]MediaType, parentRefs []string, resolver *schemaLoader, basePath, location string) error {
+	for mediaType, mediaTypeObj := range content {
+		mediaLocation := location + pointerTo("content", mediaType)
+
+		if err := expandExamples(mediaTypeObj.Examples, resolver, basePath, mediaLocation); resolver.shouldStopOnError(err) {
+			return err
+		}
+
+		if mediaTypeObj.Schema == nil {
+			continue
+		}
+
+		sch, err := expandSchema(*mediaTypeObj.Schema, parentRefs, resolver, basePath, mediaLocation+pointerTo("schema"))
+		if resolver.shouldStopOnError(err) {
+			return err
+		}
+		if sch != nil {
+			mediaTypeObj.Schema = sch
+			content[mediaType] = mediaTypeObj
 		}
 	}
 
-	if param, ok := input.(*Parameter); ok && param != nil && param.Content != nil {
-		for mediaType, mediaTypeObj := range param.Content {
-			if mediaTypeObj.Schema != nil {
-				sch, err := expandSchemaRef(*mediaTypeObj.Schema, parentRefs, resolver, basePath)
-				if resolver.shouldStopOnError(err) {
-					return err
-				}
-				if sch != nil {
-					mediaTypeObj.Schema = sch
-					param.Content[mediaType] = mediaTypeObj
-				}
+	return nil
+}
+
+// expandExamples replaces the examples defined by a $ref with their target.
+func expandExamples(examples map[string]Example, resolver *schemaLoader, basePath, location string) error {
+	for _, name := range slices.Sorted(maps.Keys(examples)) {
+		example := examples[name]
+		if example.Ref.String() == "" {
+			continue
+		}
+
+		if !resolver.options.expands(TargetExamples) {
+			if err := rebaseRef(&example.Ref, resolver, basePath); err != nil {
+				return err
 			}
+		} else if _, _, err := derefWithTrace(&example, &example.Ref, resolver, basePath, location+pointerTo("examples", name)); resolver.shouldStopOnError(err) {
+			return err
 		}
+
+		examples[name] = example
+	}
+
+	return nil
+}
+
+// rebasing yields a copy of the resolver which expands none of the $ref's it walks through, but rebases them
+// on the root document, e.g. for the content of request bodies and headers when they are not selected.
+func (r *schemaLoader) rebasing() *schemaLoader {
+	options := *r.options
+	options.Targets, options.SkipSchemas = TargetSchemas, true // i.e. no target at all
+
+	rebasing := *r
+	rebasing.options = &options
+
+	return &rebasing
+}
+
+// rebaseRef keeps a $ref which is not expanded, relative to the root document.
+func rebaseRef(ref *Ref, resolver *schemaLoader, basePath string) error {
+	rebasedRef, err := NewRef(normalizeURI(ref.String(), basePath))
+	if err != nil {
+		return err
 	}
+	*ref = denormalizeRef(&rebasedRef, resolver.context.basePath, resolver.context.rootID)
 
 	return nil
 }
+
+// schemaName returns the name of a schema referred to as a component or definition, or an empty string.
+func schemaName(ref *Ref) string {
+	if ref.GetURL() == nil {
+		return ""
+	}
+	tokens := SplitPointer(ref.GetURL().Fragment)
+
+	switch {
+	case len(tokens) == 3 && tokens[0] == "components" && tokens[1] == "schemas":
+		return tokens[2]
+	case len(tokens) == 2 && tokens[0] == "definitions":
+		return tokens[1]
+	}
+
+	return ""
+}
+
+// targetOf tells which kind of $ref is carried by a parameter or a response.
+func targetOf(input any) ExpandTarget {
+	if _, isParameter := input.(*Parameter); isParameter {
+		return TargetParameters
+	}
+
+	return TargetResponses
+}
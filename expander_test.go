@@ -4,11 +4,14 @@
 package spec
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/go-openapi/testify/v2/assert"
@@ -118,11 +121,11 @@ func TestExpand_EmptySpec(t *testing.T) {
 	// expansion of a nil paths
 	var paths *PathItem
 	resolver := defaultSchemaLoader(spec, nil, nil, nil)
-	require.NoError(t, expandPathItem(paths, resolver, ""))
+	require.NoError(t, expandPathItem(paths, resolver, "", ""))
 
 	// expansion of a nil Parameter
 	var param *Parameter
-	require.NoError(t, expandParameterOrResponse(param, resolver, ""))
+	require.NoError(t, expandParameterOrResponse(param, resolver, "", ""))
 }
 
 func TestExpand_Spec(t *testing.T) {
@@ -171,7 +174,7 @@ func TestExpand_InternalResponse(t *testing.T) {
 	resolver := defaultSchemaLoader(spec, nil, nil, nil)
 
 	expectedPet := spec.Components.Responses["petResponse"]
-	require.NoError(t, expandParameterOrResponse(&expectedPet, resolver, basePath))
+	require.NoError(t, expandParameterOrResponse(&expectedPet, resolver, basePath, ""))
 
 	jazon := asJSON(t, expectedPet)
 
@@ -204,12 +207,12 @@ func TestExpand_InternalResponse(t *testing.T) {
 
 	// response pointing to the same target: result is unchanged
 	another := spec.Components.Responses["anotherPet"]
-	require.NoError(t, expandParameterOrResponse(&another, resolver, basePath))
+	require.NoError(t, expandParameterOrResponse(&another, resolver, basePath, ""))
 	assert.Equal(t, expectedPet, another)
 
 	defaultResponse := spec.Paths.Paths["/"].Get.Responses.Default
 
-	require.NoError(t, expandParameterOrResponse(defaultResponse, resolver, basePath))
+	require.NoError(t, expandParameterOrResponse(defaultResponse, resolver, basePath, ""))
 
 	expectedString := spec.Components.Responses["stringResponse"]
 	assert.Equal(t, expectedString, *defaultResponse)
@@ -224,7 +227,7 @@ func TestExpand_InternalResponse(t *testing.T) {
 		"$ref": "#/components/responses/anotherPet"
   }`, jazon)
 
-	require.NoError(t, expandParameterOrResponse(&successResponse, resolver, basePath))
+	require.NoError(t, expandParameterOrResponse(&successResponse, resolver, basePath, ""))
 	assert.Equal(t, expectedPet, successResponse)
 }
 
@@ -316,14 +319,14 @@ func TestExpand_InternalParameter(t *testing.T) {
 	param := spec.Components.Parameters["query"]
 	expected := spec.Components.Parameters["tag"]
 
-	require.NoError(t, expandParameterOrResponse(&param, resolver, basePath))
+	require.NoError(t, expandParameterOrResponse(&param, resolver, basePath, ""))
 
 	assert.Equal(t, expected, param)
 
 	param = spec.Paths.Paths["/cars/{id}"].Parameters[0]
 	expected = spec.Components.Parameters["id"]
 
-	require.NoError(t, expandParameterOrResponse(&param, resolver, basePath))
+	require.NoError(t, expandParameterOrResponse(&param, resolver, basePath, ""))
 
 	assert.Equal(t, expected, param)
 }
@@ -405,6 +408,447 @@ func TestExpand_ContinueOnError(t *testing.T) {
 	t.Skip("Test fixture needs migration to OpenAPI 3.0 format")
 }
 
+func TestExpand_MaxExpandedSize(t *testing.T) {
+	const fixturePath = "fixtures/expansion/amplification.json"
+
+	t.Run("should abort when the expanded spec exceeds the budget", func(t *testing.T) {
+		doc, opts := docAndOpts(t, fixturePath)
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal(doc, spec))
+
+		opts.MaxExpandedSize = 10000
+		opts.ContinueOnError = true // does not prevent the expansion from being aborted
+		err := ExpandSpec(spec, opts)
+		require.ErrorIs(t, err, ErrMaxExpandedSize)
+
+		var sizeErr *ExpandedSizeError
+		require.ErrorAs(t, err, &sizeErr)
+		assert.Equal(t, 10000, sizeErr.Limit)
+		assert.Greater(t, sizeErr.Size, 10000)
+	})
+
+	t.Run("should expand within the budget", func(t *testing.T) {
+		doc, opts := docAndOpts(t, fixturePath)
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal(doc, spec))
+
+		opts.MaxExpandedSize = 1 << 20
+		require.NoError(t, ExpandSpec(spec, opts))
+
+		jazon, err := json.Marshal(spec)
+		require.NoError(t, err)
+		assert.NotContains(t, string(jazon), "$ref")
+	})
+
+	t.Run("should abort when expanding a schema", func(t *testing.T) {
+		sch := &Schema{
+			SchemaProps: SchemaProps{
+				Ref: MustCreateRef(fixturePath + "#/components/schemas/lol1"),
+			},
+		}
+
+		err := ExpandSchemaWithBasePath(sch, nil, &ExpandOptions{MaxExpandedSize: 1000})
+		require.ErrorIs(t, err, ErrMaxExpandedSize)
+	})
+}
+
+func TestExpandSpecContext(t *testing.T) {
+	const root = `{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"pet": {"$ref": "pet.json"},
+				"owner": {"$ref": "owner.json"}
+			}
+		}
+	}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(`{"type": "object"}`))
+	}))
+	defer server.Close()
+
+	loaderFor := func(ctx context.Context, pth string) (json.RawMessage, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, pth, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		return io.ReadAll(resp.Body)
+	}
+
+	t.Run("should stop when the context is cancelled mid-expansion", func(t *testing.T) {
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(root), spec))
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		var fetched int
+		err := ExpandSpecContext(ctx, spec, &ExpandOptions{
+			RelativeBase:    server.URL + "/root.json",
+			ContinueOnError: true, // does not prevent the expansion from being aborted
+			PathLoaderContext: func(ctx context.Context, pth string) (json.RawMessage, error) {
+				fetched++
+				defer cancel() // the first remote document is the last one
+
+				return loaderFor(ctx, pth)
+			},
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, fetched)
+	})
+
+	t.Run("should pass the context to the document loader", func(t *testing.T) {
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(root), spec))
+
+		type ctxKey struct{}
+		ctx := context.WithValue(t.Context(), ctxKey{}, "expansion")
+
+		var values []any
+		require.NoError(t, ExpandSpecContext(ctx, spec, &ExpandOptions{
+			RelativeBase: server.URL + "/root.json",
+			PathLoaderContext: func(ctx context.Context, pth string) (json.RawMessage, error) {
+				values = append(values, ctx.Value(ctxKey{}))
+
+				return loaderFor(ctx, pth)
+			},
+		}))
+		assert.Equal(t, []any{"expansion", "expansion"}, values)
+	})
+
+	t.Run("should fail with a cancelled context", func(t *testing.T) {
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(root), spec))
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		err := ExpandSpecContext(ctx, spec, &ExpandOptions{
+			RelativeBase:      server.URL + "/root.json",
+			PathLoaderContext: loaderFor,
+		})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("should expand with a live context", func(t *testing.T) {
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(root), spec))
+
+		require.NoError(t, ExpandSpecContext(t.Context(), spec, &ExpandOptions{
+			RelativeBase:      server.URL + "/root.json",
+			PathLoaderContext: loaderFor,
+		}))
+		assert.Equal(t, StringOrArray{"object"}, spec.Components.Schemas["pet"].Type)
+		assert.Equal(t, StringOrArray{"object"}, spec.Components.Schemas["owner"].Type)
+	})
+
+	t.Run("should cancel the HTTP requests of the default loader", func(t *testing.T) {
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(root), spec))
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		received := make(chan struct{})
+		aborted := make(chan struct{})
+		stalled := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+			close(received)
+			<-req.Context().Done()
+			close(aborted)
+		}))
+		defer stalled.Close()
+
+		go func() {
+			<-received
+			cancel()
+		}()
+
+		err := ExpandSpecContext(ctx, spec, &ExpandOptions{RelativeBase: stalled.URL + "/root.json"})
+		require.ErrorIs(t, err, context.Canceled)
+		<-aborted
+	})
+}
+
+// memoryResolver serves documents from memory, keyed by their URL.
+type memoryResolver struct {
+	documents map[string]string
+	fetched   []string
+	bases     []string
+}
+
+func (r *memoryResolver) Resolve(_ context.Context, ref Ref, base string) (json.RawMessage, error) {
+	r.fetched = append(r.fetched, ref.String())
+	r.bases = append(r.bases, base)
+
+	doc, ok := r.documents[ref.String()]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	return json.RawMessage(doc), nil
+}
+
+func TestExpand_ReferenceResolver(t *testing.T) {
+	const (
+		base = "https://registry.example.com/specs/root.json"
+		root = `{
+			"openapi": "3.1.0",
+			"components": {
+				"schemas": {
+					"pet": {"$ref": "pets.json#/components/schemas/pet"},
+					"tag": {"type": "string"}
+				}
+			}
+		}`
+	)
+
+	newResolver := func() *memoryResolver {
+		return &memoryResolver{documents: map[string]string{
+			"https://registry.example.com/specs/pets.json": `{
+				"components": {
+					"schemas": {
+						"pet": {
+							"type": "object",
+							"properties": {"owner": {"$ref": "common/owner.json#/owner"}}
+						}
+					}
+				}
+			}`,
+			"https://registry.example.com/specs/common/owner.json": `{
+				"owner": {"type": "object", "properties": {"name": {"type": "string"}}}
+			}`,
+		}}
+	}
+
+	t.Run("should fetch remote documents with a custom resolver", func(t *testing.T) {
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(root), spec))
+
+		resolver := newResolver()
+		require.NoError(t, ExpandSpec(spec, &ExpandOptions{
+			RelativeBase: base,
+			Resolver:     resolver,
+			PathLoader: func(string) (json.RawMessage, error) {
+				t.Fatal("the path loader should not be used when a resolver is set")
+
+				return nil, nil
+			},
+		}))
+
+		pet := spec.Components.Schemas["pet"]
+		assert.Equal(t, StringOrArray{"object"}, pet.Type)
+		owner := pet.Properties["owner"]
+		assert.Empty(t, owner.Ref.String())
+		assert.Equal(t, StringOrArray{"string"}, owner.Properties["name"].Type)
+
+		assert.Equal(t, []string{
+			"https://registry.example.com/specs/pets.json",
+			"https://registry.example.com/specs/common/owner.json",
+		}, resolver.fetched)
+		assert.Equal(t, []string{base, base}, resolver.bases)
+	})
+
+	t.Run("should report the errors of the resolver", func(t *testing.T) {
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(root), spec))
+
+		resolver := newResolver()
+		delete(resolver.documents, "https://registry.example.com/specs/common/owner.json")
+
+		err := ExpandSpec(spec, &ExpandOptions{RelativeBase: base, Resolver: resolver})
+		require.ErrorIs(t, err, os.ErrNotExist)
+
+		var fetchErr *RemoteFetchError
+		require.ErrorAs(t, err, &fetchErr)
+		assert.Equal(t, "https://registry.example.com/specs/common/owner.json", fetchErr.URL)
+	})
+
+	t.Run("should use a single document loading method, by order of precedence", func(t *testing.T) {
+		const pets = "https://registry.example.com/specs/pets.json"
+		served := newResolver()
+
+		var used []string
+		loaders := map[string]func(*ExpandOptions){
+			"Resolver": func(opts *ExpandOptions) {
+				opts.Resolver = &memoryResolver{documents: served.documents}
+			},
+			"PathLoaderContext": func(opts *ExpandOptions) {
+				opts.PathLoaderContext = func(_ context.Context, pth string) (json.RawMessage, error) {
+					used = append(used, "PathLoaderContext")
+
+					return json.RawMessage(served.documents[pth]), nil
+				}
+			},
+			"PathLoader": func(opts *ExpandOptions) {
+				opts.PathLoader = func(pth string) (json.RawMessage, error) {
+					used = append(used, "PathLoader")
+
+					return json.RawMessage(served.documents[pth]), nil
+				}
+			},
+		}
+
+		for _, tc := range []struct {
+			configured []string
+			expected   string
+		}{
+			{configured: []string{"Resolver", "PathLoaderContext", "PathLoader"}, expected: "Resolver"},
+			{configured: []string{"PathLoaderContext", "PathLoader"}, expected: "PathLoaderContext"},
+			{configured: []string{"PathLoader"}, expected: "PathLoader"},
+		} {
+			t.Run(tc.expected, func(t *testing.T) {
+				spec := new(Swagger)
+				require.NoError(t, json.Unmarshal([]byte(root), spec))
+
+				used = nil
+				opts := &ExpandOptions{RelativeBase: base}
+				for _, name := range tc.configured {
+					loaders[name](opts)
+				}
+				require.NoError(t, ExpandSpec(spec, opts))
+
+				if tc.expected == "Resolver" {
+					assert.Empty(t, used)
+					assert.Equal(t, []string{pets, "https://registry.example.com/specs/common/owner.json"}, opts.Resolver.(*memoryResolver).fetched)
+				} else {
+					assert.Equal(t, []string{tc.expected, tc.expected}, used)
+				}
+
+				used = nil
+				data, err := opts.pathLoader()(pets)
+				require.NoError(t, err)
+				assert.JSONEq(t, served.documents[pets], string(data))
+				if tc.expected != "Resolver" {
+					assert.Equal(t, []string{tc.expected}, used)
+				}
+			})
+		}
+	})
+}
+
+func TestExpand_SchemaExamples(t *testing.T) {
+	const doc = `{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"pet": {
+					"type": "object",
+					"example": {"name": "Rex"},
+					"examples": [{"name": "Rex"}, {"name": "Felix"}]
+				}
+			},
+			"examples": {
+				"rex": {"summary": "a dog", "value": "Rex"}
+			}
+		},
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [{
+						"name": "name",
+						"in": "query",
+						"schema": {"type": "string"},
+						"examples": {"dog": {"$ref": "#/components/examples/rex"}}
+					}]
+				}
+			}
+		}
+	}`
+
+	t.Run("should keep the examples of a referenced schema", func(t *testing.T) {
+		root := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(doc), root))
+
+		schema := RefSchema("#/components/schemas/pet")
+		require.NoError(t, ExpandSchema(schema, root, nil))
+
+		assert.Empty(t, schema.Ref.String())
+		assert.Equal(t, map[string]any{"name": "Rex"}, schema.Example)
+		assert.Equal(t, []any{map[string]any{"name": "Rex"}, map[string]any{"name": "Felix"}}, schema.ExtraProps["examples"])
+	})
+
+	t.Run("should resolve the examples of parameters", func(t *testing.T) {
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(doc), spec))
+		require.NoError(t, ExpandSpec(spec, nil))
+
+		example := spec.Paths.Paths["/pets"].Get.Parameters[0].Examples["dog"]
+		assert.Empty(t, example.Ref.String())
+		assert.Equal(t, "a dog", example.Summary)
+		assert.Equal(t, "Rex", example.Value)
+	})
+}
+
+func TestResolvedView(t *testing.T) {
+	spec := new(Swagger)
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"pet": {"type": "object", "properties": {"name": {"type": "string"}}},
+				"pets": {"type": "array", "items": {"$ref": "#/components/schemas/pet"}}
+			}
+		},
+		"paths": {
+			"/pets": {
+				"get": {
+					"responses": {
+						"200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/pets"}}}}
+					}
+				}
+			}
+		}
+	}`), spec))
+
+	original, err := json.Marshal(spec)
+	require.NoError(t, err)
+
+	view, err := ResolvedView(spec, nil)
+	require.NoError(t, err)
+	require.NotSame(t, spec, view)
+
+	resolved, err := json.Marshal(view)
+	require.NoError(t, err)
+	assert.NotContains(t, string(resolved), "$ref")
+
+	after, err := json.Marshal(spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(after), `"$ref":"#/components/schemas/pets"`)
+	assert.JSONEq(t, string(original), string(after))
+}
+
+func TestExpand_ParameterContent(t *testing.T) {
+	doc, opts := docAndOpts(t, "fixtures/expansion/parameterContent.json")
+	spec := new(Swagger)
+	require.NoError(t, json.Unmarshal(doc, spec))
+	require.NoError(t, ExpandSpec(spec, opts))
+
+	jazon, err := json.Marshal(spec)
+	require.NoError(t, err)
+	assert.NotContains(t, string(jazon), "$ref")
+
+	params := spec.Paths.Paths["/pets"].Get.Parameters
+	require.Len(t, params, 2)
+
+	owner := params[0].Content["application/json"].Schema
+	require.NotNil(t, owner)
+	assert.Equal(t, "email", owner.Format)
+
+	filter := params[1].Content["application/json"].Schema
+	require.NotNil(t, filter)
+	assert.Equal(t, "filter", params[1].Name)
+	assert.Equal(t, StringOrArray{"object"}, filter.Type)
+	assert.Equal(t, "email", filter.Properties["owner"].Format)
+}
+
 func TestExpand_InternalSchemas2(t *testing.T) {
 	basePath := normalizeBase(filepath.Join("fixtures", "expansion", "schemas2.json"))
 
@@ -440,7 +884,7 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 	require.NotEmpty(t, oldBrand.Items.Schema.Ref.String()) // this is a $ref
 	require.NotEqual(t, spec.Components.Schemas["brand"], oldBrand)
 
-	_, err = expandSchema(schema, []string{"#/definitions/car"}, resolver, basePath)
+	_, err = expandSchema(schema, []string{"#/definitions/car"}, resolver, basePath, "")
 	require.NoError(t, err)
 
 	// verify expanded schema for Car, in the document passed
@@ -451,7 +895,7 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 	// verify expanded schema for Truck, in the returned schema
 	schema = spec.Components.Schemas["truck"]
 	require.NotEmpty(t, schema.Items.Schema.Ref.String())
-	s, err := expandSchema(schema, []string{"#/definitions/truck"}, resolver, basePath)
+	s, err := expandSchema(schema, []string{"#/definitions/truck"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -461,12 +905,12 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 	assert.Equal(t, spec.Components.Schemas["car"], *schema.Items.Schema)
 
 	sch := new(Schema)
-	_, err = expandSchema(*sch, []string{""}, resolver, basePath)
+	_, err = expandSchema(*sch, []string{""}, resolver, basePath, "")
 	require.NoError(t, err)
 
 	// verify expanded schema for Batch, in the returned schema
 	schema = spec.Components.Schemas["batch"]
-	s, err = expandSchema(schema, []string{"#/definitions/batch"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/batch"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -476,7 +920,7 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 
 	// verify expanded schema for Batch2, in the returned schema
 	schema = spec.Components.Schemas["batch2"]
-	s, err = expandSchema(schema, []string{"#/definitions/batch2"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/batch2"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -488,7 +932,7 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 
 	// verify expanded schema for AllOfBoth, in the returned schema [expand allOf]
 	schema = spec.Components.Schemas["allofBoth"]
-	s, err = expandSchema(schema, []string{"#/definitions/allofBoth"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/allofBoth"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -500,7 +944,7 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 
 	// verify expanded schema for AnyOfBoth, in the returned schema [expand anyOf]
 	schema = spec.Components.Schemas["anyofBoth"]
-	s, err = expandSchema(schema, []string{"#/definitions/anyofBoth"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/anyofBoth"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -512,7 +956,7 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 
 	// verify expanded schema for OneOfBoth, in the returned schema [expand oneOf]
 	schema = spec.Components.Schemas["oneofBoth"]
-	s, err = expandSchema(schema, []string{"#/definitions/oneofBoth"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/oneofBoth"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -524,7 +968,7 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 
 	// verify expanded schema for NotSomething, in the returned schema [expand not]
 	schema = spec.Components.Schemas["notSomething"]
-	s, err = expandSchema(schema, []string{"#/definitions/notSomething"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/notSomething"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -534,7 +978,7 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 
 	// verify expanded schema for WithAdditional, in the returned schema [expand additionalProperties]
 	schema = spec.Components.Schemas["withAdditional"]
-	s, err = expandSchema(schema, []string{"#/definitions/withAdditional"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/withAdditional"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -544,7 +988,7 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 
 	// verify expanded schema for WithAdditionalItems, in the returned schema [expand additionalItems]
 	schema = spec.Components.Schemas["withAdditionalItems"]
-	s, err = expandSchema(schema, []string{"#/definitions/withAdditionalItems"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/withAdditionalItems"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -554,7 +998,7 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 
 	// verify expanded schema for WithPattern, in the returned schema [expand PatternProperties]
 	schema = spec.Components.Schemas["withPattern"]
-	s, err = expandSchema(schema, []string{"#/definitions/withPattern"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/withPattern"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -565,7 +1009,7 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 
 	// verify expanded schema for Deps, in the returned schema [expand dependencies]
 	schema = spec.Components.Schemas["deps"]
-	s, err = expandSchema(schema, []string{"#/definitions/deps"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/deps"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -576,7 +1020,7 @@ func TestExpand_InternalSchemas2(t *testing.T) {
 
 	// verify expanded schema for Defined, in the returned schema [expand nested definitions]
 	schema = spec.Components.Schemas["defined"]
-	s, err = expandSchema(schema, []string{"#/definitions/defined"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/defined"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -620,7 +1064,7 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	assert.NotEmpty(t, oldBrand.Ref.String())
 	assert.NotEqual(t, spec.Components.Schemas["brand"], oldBrand)
 
-	s, err := expandSchema(schema, []string{"#/definitions/car"}, resolver, basePath)
+	s, err := expandSchema(schema, []string{"#/definitions/car"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -633,7 +1077,7 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	schema = spec.Components.Schemas["truck"]
 	assert.NotEmpty(t, schema.Ref.String())
 
-	s, err = expandSchema(schema, []string{"#/definitions/truck"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/truck"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -642,11 +1086,11 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	assert.Equal(t, spec.Components.Schemas["car"], schema)
 
 	sch := new(Schema)
-	_, err = expandSchema(*sch, []string{""}, resolver, basePath)
+	_, err = expandSchema(*sch, []string{""}, resolver, basePath, "")
 	require.NoError(t, err)
 
 	schema = spec.Components.Schemas["batch"]
-	s, err = expandSchema(schema, []string{"#/definitions/batch"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/batch"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -655,7 +1099,7 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	assert.Equal(t, *schema.Items.Schema, spec.Components.Schemas["brand"])
 
 	schema = spec.Components.Schemas["batch2"]
-	s, err = expandSchema(schema, []string{"#/definitions/batch2"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/batch2"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -666,7 +1110,7 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	assert.Equal(t, schema.Items.Schemas[1], spec.Components.Schemas["tag"])
 
 	schema = spec.Components.Schemas["allofBoth"]
-	s, err = expandSchema(schema, []string{"#/definitions/allofBoth"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/allofBoth"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -677,7 +1121,7 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	assert.Equal(t, schema.AllOf[1], spec.Components.Schemas["tag"])
 
 	schema = spec.Components.Schemas["anyofBoth"]
-	s, err = expandSchema(schema, []string{"#/definitions/anyofBoth"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/anyofBoth"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -688,7 +1132,7 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	assert.Equal(t, schema.AnyOf[1], spec.Components.Schemas["tag"])
 
 	schema = spec.Components.Schemas["oneofBoth"]
-	s, err = expandSchema(schema, []string{"#/definitions/oneofBoth"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/oneofBoth"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -699,7 +1143,7 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	assert.Equal(t, schema.OneOf[1], spec.Components.Schemas["tag"])
 
 	schema = spec.Components.Schemas["notSomething"]
-	s, err = expandSchema(schema, []string{"#/definitions/notSomething"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/notSomething"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -708,7 +1152,7 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	assert.Equal(t, *schema.Not, spec.Components.Schemas["tag"])
 
 	schema = spec.Components.Schemas["withAdditional"]
-	s, err = expandSchema(schema, []string{"#/definitions/withAdditional"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/withAdditional"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -717,7 +1161,7 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	assert.Equal(t, *schema.AdditionalProperties.Schema, spec.Components.Schemas["tag"])
 
 	schema = spec.Components.Schemas["withAdditionalItems"]
-	s, err = expandSchema(schema, []string{"#/definitions/withAdditionalItems"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/withAdditionalItems"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -726,7 +1170,7 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	assert.Equal(t, *schema.AdditionalItems.Schema, spec.Components.Schemas["tag"])
 
 	schema = spec.Components.Schemas["withPattern"]
-	s, err = expandSchema(schema, []string{"#/definitions/withPattern"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/withPattern"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -736,7 +1180,7 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	assert.Equal(t, *prop.Schema, spec.Components.Schemas["tag"])
 
 	schema = spec.Components.Schemas["deps"]
-	s, err = expandSchema(schema, []string{"#/definitions/deps"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/deps"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -746,7 +1190,7 @@ func TestExpand_InternalSchemas1(t *testing.T) {
 	assert.Equal(t, *prop2.Schema, spec.Components.Schemas["tag"])
 
 	schema = spec.Components.Schemas["defined"]
-	s, err = expandSchema(schema, []string{"#/definitions/defined"}, resolver, basePath)
+	s, err = expandSchema(schema, []string{"#/definitions/defined"}, resolver, basePath, "")
 	require.NoError(t, err)
 	require.NotNil(t, s)
 
@@ -1040,6 +1484,158 @@ func TestExpand_PathItem(t *testing.T) {
 			 }`, jazon)
 }
 
+func TestExpand_ComponentsPathItems(t *testing.T) {
+	_, spec := expandThisOrDieTrying(t, "fixtures/expansion/componentsPathItems.json")
+
+	require.Contains(t, spec.Components.PathItems, "todos")
+	shared := spec.Components.PathItems["todos"]
+
+	for _, pathItem := range []PathItem{spec.Paths.Paths["/todos"], spec.Webhooks["newTodo"]} {
+		assert.Empty(t, pathItem.Ref.String())
+		require.NotNil(t, pathItem.Get)
+		require.NotNil(t, pathItem.Post)
+		assert.Equal(t, shared, pathItem)
+
+		require.Contains(t, pathItem.Get.Responses.StatusCodeResponses, 200)
+		schema := pathItem.Get.Responses.StatusCodeResponses[200].Content["application/json"].Schema
+		require.NotNil(t, schema)
+		assert.Empty(t, schema.Ref.String())
+		assert.Equal(t, StringOrArray{"array"}, schema.Type)
+	}
+}
+
+func TestExpand_Targets(t *testing.T) {
+	const fixturePath = "fixtures/expansion/targets.json"
+
+	t.Run("should only expand the selected targets", func(t *testing.T) {
+		doc, opts := docAndOpts(t, fixturePath)
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal(doc, spec))
+
+		opts.Targets = TargetResponses | TargetSchemas
+		require.NoError(t, ExpandSpec(spec, opts))
+
+		op := spec.Paths.Paths["/pets"].Post
+		require.NotNil(t, op)
+
+		// parameters and request bodies are kept as $ref's
+		assert.Equal(t, "#/components/parameters/limit", op.Parameters[0].Ref.String())
+		require.NotNil(t, op.RequestBody)
+		assert.Equal(t, "#/components/requestBodies/pet", op.RequestBody.Ref.String())
+
+		// schemas within parameters are expanded
+		require.NotNil(t, op.Parameters[1].Schema)
+		assert.Empty(t, op.Parameters[1].Schema.Ref.String())
+		assert.Equal(t, StringOrArray{"string"}, op.Parameters[1].Schema.Type)
+
+		// responses and their schemas are expanded, but not their headers
+		response := op.Responses.StatusCodeResponses[200]
+		assert.Empty(t, response.Ref.String())
+		assert.Equal(t, "a pet", response.Description)
+		rateLimit := response.Headers["X-Rate-Limit"]
+		assert.Equal(t, "#/components/headers/rateLimit", rateLimit.Ref.String())
+		schema := response.Content["application/json"].Schema
+		require.NotNil(t, schema)
+		assert.Empty(t, schema.Ref.String())
+		assert.Equal(t, StringOrArray{"string"}, schema.Properties["tag"].Type)
+	})
+
+	t.Run("should keep request bodies and headers by default", func(t *testing.T) {
+		_, spec := expandThisOrDieTrying(t, fixturePath)
+
+		op := spec.Paths.Paths["/pets"].Post
+		require.NotNil(t, op)
+
+		assert.Equal(t, "limit", op.Parameters[0].Name)
+		require.NotNil(t, op.RequestBody)
+		assert.Equal(t, "#/components/requestBodies/pet", op.RequestBody.Ref.String())
+		response := op.Responses.StatusCodeResponses[200]
+		assert.Equal(t, "a pet", response.Description)
+		rateLimit := response.Headers["X-Rate-Limit"]
+		assert.Equal(t, "#/components/headers/rateLimit", rateLimit.Ref.String())
+	})
+
+	t.Run("should rebase the $ref's of request bodies and headers kept from other documents", func(t *testing.T) {
+		_, spec := expandThisOrDieTrying(t, "fixtures/expansion/rebased.json")
+
+		op := spec.Paths.Paths["/orders"].Post
+		require.NotNil(t, op)
+		require.NotNil(t, op.RequestBody)
+		schema := op.RequestBody.Content["application/json"].Schema
+		require.NotNil(t, schema)
+		assert.Equal(t, "nested/models.json#/order", schema.Ref.String())
+
+		headers := op.Responses.StatusCodeResponses[201].Headers
+		rateLimit := headers["X-Rate-Limit"]
+		assert.Equal(t, "nested/models.json#/rateLimit", rateLimit.Ref.String())
+		requestID := headers["X-Request-Id"]
+		require.NotNil(t, requestID.Schema)
+		assert.Equal(t, "nested/models.json#/requestId", requestID.Schema.Ref.String())
+	})
+
+	t.Run("should expand all targets", func(t *testing.T) {
+		doc, opts := docAndOpts(t, fixturePath)
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal(doc, spec))
+
+		opts.Targets = TargetAll
+		require.NoError(t, ExpandSpec(spec, opts))
+
+		op := spec.Paths.Paths["/pets"].Post
+		require.NotNil(t, op)
+
+		assert.Equal(t, "limit", op.Parameters[0].Name)
+		require.NotNil(t, op.RequestBody)
+		assert.Empty(t, op.RequestBody.Ref.String())
+		schema := op.RequestBody.Content["application/json"].Schema
+		require.NotNil(t, schema)
+		assert.Equal(t, StringOrArray{"object"}, schema.Type)
+
+		header := op.Responses.StatusCodeResponses[200].Headers["X-Rate-Limit"]
+		assert.Empty(t, header.Ref.String())
+		require.NotNil(t, header.Schema)
+		assert.Equal(t, StringOrArray{"integer"}, header.Schema.Type)
+
+		assert.NotContains(t, asJSON(t, spec), `"$ref"`)
+	})
+}
+
+func TestExpand_PreserveNameAsTitle(t *testing.T) {
+	const fixturePath = "fixtures/expansion/titles.json"
+
+	expand := func(t *testing.T, preserve bool) *Schema {
+		doc, opts := docAndOpts(t, fixturePath)
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal(doc, spec))
+
+		opts.PreserveNameAsTitle = preserve
+		require.NoError(t, ExpandSpec(spec, opts))
+
+		schema := spec.Paths.Paths["/orders"].Get.Responses.StatusCodeResponses[200].Content["application/json"].Schema
+		require.NotNil(t, schema)
+		require.NotNil(t, schema.Items)
+		require.NotNil(t, schema.Items.Schema)
+
+		return schema.Items.Schema
+	}
+
+	t.Run("should set the name of inlined schemas as title", func(t *testing.T) {
+		order := expand(t, true)
+
+		assert.Empty(t, order.Ref.String())
+		assert.Equal(t, "Order", order.Title)
+		assert.Equal(t, "A customer", order.Properties["customer"].Title, "an existing title should be kept")
+		assert.Empty(t, order.Properties["status"].Title, "only named schemas should get a title")
+	})
+
+	t.Run("should leave titles alone by default", func(t *testing.T) {
+		order := expand(t, false)
+
+		assert.Empty(t, order.Ref.String())
+		assert.Empty(t, order.Title)
+	})
+}
+
 func TestExpand_ExtraItems(t *testing.T) {
 	// TODO: This test expected JSON needs update for OpenAPI 3.0 format
 	// The fixture was migrated but the expected JSON still uses Swagger 2.0 structure
@@ -1375,3 +1971,167 @@ const PetStore20 = `{
   }
 }
 `
+
+func TestExpand_RefDescriptionOverride(t *testing.T) {
+	const doc = `{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"pet": {"type": "object", "description": "a pet"}
+			}
+		},
+		"paths": {
+			"/pets": {
+				"get": {
+					"responses": {
+						"200": {
+							"description": "ok",
+							"content": {
+								"application/json": {"schema": {"$ref": "#/components/schemas/pet", "description": "the pet found"}},
+								"application/xml": {"schema": {"$ref": "#/components/schemas/pet"}}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	root := new(Swagger)
+	require.NoError(t, json.Unmarshal([]byte(doc), root))
+	content := root.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Content
+
+	t.Run("should keep the description next to a $ref", func(t *testing.T) {
+		schema := content["application/json"].Schema
+		require.NotNil(t, schema)
+		assert.Equal(t, "the pet found", schema.Description)
+		assertSerializeJSON(t, schema, `{"description":"the pet found","$ref":"#/components/schemas/pet"}`)
+	})
+
+	t.Run("should override the description of the target on expansion", func(t *testing.T) {
+		require.NoError(t, ExpandSpec(root, nil))
+
+		expanded := root.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Content
+		overridden := expanded["application/json"].Schema
+		require.NotNil(t, overridden)
+		assert.Empty(t, overridden.Ref.String())
+		assert.Equal(t, StringOrArray{"object"}, overridden.Type)
+		assert.Equal(t, "the pet found", overridden.Description)
+
+		inherited := expanded["application/xml"].Schema
+		require.NotNil(t, inherited)
+		assert.Equal(t, "a pet", inherited.Description)
+
+		assert.Equal(t, "a pet", root.Components.Schemas["pet"].Description)
+	})
+
+	t.Run("should ignore the description next to a $ref with OpenAPI 3.0", func(t *testing.T) {
+		legacy := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(strings.Replace(doc, "3.1.0", "3.0.3", 1)), legacy))
+		require.NoError(t, ExpandSpec(legacy, nil))
+
+		schema := legacy.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Content["application/json"].Schema
+		require.NotNil(t, schema)
+		assert.Empty(t, schema.Ref.String())
+		assert.Equal(t, "a pet", schema.Description)
+	})
+}
+
+func TestExpand_Not(t *testing.T) {
+	root := new(Swagger)
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"deleted": {"enum": ["deleted", "archived"]}
+			}
+		}
+	}`), root))
+
+	schema := StringProperty().WithNot(RefSchema("#/components/schemas/deleted"))
+	require.NoError(t, ExpandSchema(schema, root, nil))
+
+	require.NotNil(t, schema.Not)
+	assert.Empty(t, schema.Not.Ref.String())
+	assert.Equal(t, []any{"deleted", "archived"}, schema.Not.Enum)
+
+	assert.Empty(t, schema.Validate("available", nil))
+	assert.Len(t, schema.Validate("archived", nil), 1)
+}
+
+func TestExpand_Callbacks(t *testing.T) {
+	doc, opts := docAndOpts(t, "fixtures/expansion/callbacks.json")
+	opts.Targets = TargetAll
+	spec := new(Swagger)
+	require.NoError(t, json.Unmarshal(doc, spec))
+	require.NoError(t, ExpandSpec(spec, opts))
+
+	jazon, err := json.Marshal(spec)
+	require.NoError(t, err)
+	assert.NotContains(t, string(jazon), "$ref")
+
+	assertExpandedEvent := func(t *testing.T, op *Operation) {
+		t.Helper()
+
+		require.NotNil(t, op)
+		require.NotNil(t, op.RequestBody)
+		assert.True(t, op.RequestBody.Required)
+
+		schema := op.RequestBody.Content["application/json"].Schema
+		require.NotNil(t, schema)
+		assert.Equal(t, "uuid", schema.Properties["id"].Format)
+		assert.Equal(t, "acknowledged", op.Responses.StatusCodeResponses[204].Description)
+	}
+
+	callbacks := spec.Paths.Paths["/subscriptions"].Post.Callbacks
+
+	t.Run("should expand the operations of callbacks", func(t *testing.T) {
+		assertExpandedEvent(t, callbacks["onEvent"].Expressions["{$request.body#/callbackUrl}"].Post)
+	})
+
+	t.Run("should expand callbacks defined by a $ref", func(t *testing.T) {
+		onCancel := callbacks["onCancel"]
+		assert.Empty(t, onCancel.Ref.String())
+		assert.Equal(t, "partners", onCancel.Extensions["x-audience"])
+		require.Len(t, onCancel.Expressions, 1)
+		assertExpandedEvent(t, onCancel.Expressions["{$request.body#/cancelUrl}"].Post)
+	})
+
+	t.Run("should expand the operations of webhooks and reusable callbacks", func(t *testing.T) {
+		assertExpandedEvent(t, spec.Webhooks["event"].Post)
+		assertExpandedEvent(t, spec.Components.Callbacks["cancel"].Expressions["{$request.body#/cancelUrl}"].Post)
+	})
+
+	t.Run("should keep callbacks defined by a $ref without TargetCallbacks", func(t *testing.T) {
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal(doc, spec))
+
+		targetOpts := *opts
+		targetOpts.Targets = TargetAll &^ TargetCallbacks
+		require.NoError(t, ExpandSpec(spec, &targetOpts))
+
+		onCancel := spec.Paths.Paths["/subscriptions"].Post.Callbacks["onCancel"]
+		assert.Equal(t, "#/components/callbacks/cancel", onCancel.Ref.String())
+		assert.Empty(t, onCancel.Expressions)
+		assertExpandedEvent(t, spec.Paths.Paths["/subscriptions"].Post.Callbacks["onEvent"].Expressions["{$request.body#/callbackUrl}"].Post)
+	})
+
+	t.Run("should keep an unresolvable callback $ref with ContinueOnError", func(t *testing.T) {
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal(doc, spec))
+		onCancel := spec.Paths.Paths["/subscriptions"].Post.Callbacks["onCancel"]
+		onCancel.Ref = MustCreateRef("#/components/callbacks/missing")
+		spec.Paths.Paths["/subscriptions"].Post.Callbacks["onCancel"] = onCancel
+
+		continueOpts := *opts
+		continueOpts.ContinueOnError = true
+		continueOpts.Trace = &ExpansionTrace{}
+		require.NoError(t, ExpandSpec(spec, &continueOpts))
+
+		onCancel = spec.Paths.Paths["/subscriptions"].Post.Callbacks["onCancel"]
+		assert.Equal(t, "#/components/callbacks/missing", onCancel.Ref.String())
+		for _, entry := range continueOpts.Trace.Entries {
+			assert.NotEqual(t, "#/components/callbacks/missing", entry.Ref)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+// ExpansionTrace records the provenance of the content inlined by the expander.
+//
+// Set ExpandOptions.Trace to collect a trace while expanding. Entries are appended
+// in the order the $ref's are resolved, so the same $ref may appear several times,
+// once for each location it has been inlined at.
+type ExpansionTrace struct {
+	Entries []TraceEntry
+}
+
+// TraceEntry tells where the content of a resolved $ref ended up.
+type TraceEntry struct {
+	// Ref is the $ref as found in the document that declared it
+	Ref string
+	// Resolved is the canonical URI of the $ref target
+	Resolved string
+	// Location is the JSON pointer to the expanded content, relative to the expanded object
+	Location string
+}
+
+// Locations returns the locations at which the content of the $ref with the given
+// resolved URI has been inlined.
+func (t *ExpansionTrace) Locations(resolved string) []string {
+	if t == nil {
+		return nil
+	}
+
+	var locations []string
+	for _, entry := range t.Entries {
+		if entry.Resolved == resolved {
+			locations = append(locations, entry.Location)
+		}
+	}
+
+	return locations
+}
+
+func (t *ExpansionTrace) record(ref Ref, resolved *Ref, location string) {
+	if t == nil || ref.String() == "" {
+		return
+	}
+
+	t.Entries = append(t.Entries, TraceEntry{
+		Ref:      ref.String(),
+		Resolved: resolved.String(),
+		Location: location,
+	})
+}
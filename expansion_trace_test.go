@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestExpansionTrace(t *testing.T) {
+	const fixturePath = "fixtures/expansion/trace.json"
+
+	doc, opts := docAndOpts(t, fixturePath)
+	spec := new(Swagger)
+	require.NoError(t, json.Unmarshal(doc, spec))
+
+	trace := new(ExpansionTrace)
+	opts.Trace = trace
+	require.NoError(t, ExpandSpec(spec, opts))
+
+	rootURI := normalizeBase(fixturePath)
+	modelsURI := normalizeBase("fixtures/expansion/traceModels.json")
+
+	t.Run("should record an entry for each resolved $ref", func(t *testing.T) {
+		assert.Equal(t, []TraceEntry{
+			{
+				Ref:      "traceModels.json#/pet",
+				Resolved: modelsURI + "#/pet",
+				Location: "/components/schemas/pet",
+			},
+			{
+				Ref:      "#/tag",
+				Resolved: modelsURI + "#/tag",
+				Location: "/components/schemas/pet/properties/tag",
+			},
+			{
+				Ref:      "#/components/schemas/pet",
+				Resolved: rootURI + "#/components/schemas/pet",
+				Location: "/components/responses/pets/content/application~1json/schema/items",
+			},
+			{
+				Ref:      "#/components/responses/pets",
+				Resolved: rootURI + "#/components/responses/pets",
+				Location: "/paths/~1pets/get/responses/200",
+			},
+		}, trace.Entries)
+	})
+
+	t.Run("should lookup the locations of a $ref", func(t *testing.T) {
+		assert.Equal(t, []string{"/components/schemas/pet/properties/tag"}, trace.Locations(modelsURI+"#/tag"))
+		assert.Empty(t, trace.Locations(modelsURI+"#/unknown"))
+	})
+
+	t.Run("should leave the trace alone when not requested", func(t *testing.T) {
+		var nilTrace *ExpansionTrace
+		assert.Empty(t, nilTrace.Locations(rootURI))
+
+		doc, opts := docAndOpts(t, fixturePath)
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal(doc, spec))
+		require.NoError(t, ExpandSpec(spec, opts))
+		assert.Nil(t, opts.Trace)
+	})
+}
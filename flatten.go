@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// flattenedKeywords are the tokens of JSON pointers left out of the names of flattened schemas.
+var flattenedKeywords = map[string]bool{
+	"components":  true,
+	"schemas":     true,
+	"definitions": true,
+	"paths":       true,
+	"webhooks":    true,
+	"properties":  true,
+	"content":     true,
+	"schema":      true,
+}
+
+// FlattenSpec moves the inline object schemas of the document to the components
+// (or to definitions, with Swagger 2.0 documents), and replaces them with a $ref.
+//
+// Inline object schemas are schemas without $ref which declare properties, other than the component schemas themselves.
+// Object schemas nested in flattened schemas are flattened as well.
+//
+// A flattened schema is named after its title when it has one, e.g. a schema with title "Address" becomes
+// "#/components/schemas/Address". Otherwise, or when this name is already used, it is named after its location,
+// e.g. the property "shipping" of the "Order" schema becomes "OrderShipping".
+// When both names are used, a number is appended to the name derived from the location.
+//
+// It returns a map of the JSON pointers of the flattened schemas to their component name.
+// Schemas nested in flattened schemas are located in their new component.
+func (s *Swagger) FlattenSpec() map[string]string {
+	schemas, section := s.flattenSection()
+
+	flattened := make(map[string]string)
+	for {
+		var found bool
+
+		_ = s.EachSchema(func(pointer string, schema *Schema) error {
+			if !isInlineObject(pointer, schema) {
+				return nil
+			}
+
+			name := flattenedName(schemas, pointer, schema.Title)
+			schemas[name] = *schema
+			*schema = *RefSchema("#" + section + pointerTo(name))
+
+			flattened[pointer] = name
+			found = true
+
+			return nil
+		})
+
+		if !found {
+			return flattened
+		}
+	}
+}
+
+// flattenSection returns the schemas receiving the flattened schemas, and their JSON pointer.
+func (s *Swagger) flattenSection() (map[string]Schema, string) {
+	if s.Components == nil && s.OpenAPI == "" {
+		if s.Definitions == nil {
+			s.Definitions = make(Definitions)
+		}
+
+		return s.Definitions, pointerTo("definitions")
+	}
+
+	if s.Components == nil {
+		s.Components = &Components{}
+	}
+	if s.Components.Schemas == nil {
+		s.Components.Schemas = make(map[string]Schema)
+		s.Definitions = s.Components.Schemas
+	}
+
+	return s.Components.Schemas, pointerTo("components", "schemas")
+}
+
+// isInlineObject tells whether a schema declaring properties is located elsewhere than at the root of a component.
+func isInlineObject(pointer string, schema *Schema) bool {
+	if schema.Ref.String() != "" || len(schema.Properties) == 0 {
+		return false
+	}
+
+	tokens := SplitPointer(pointer)
+	switch {
+	case len(tokens) == 2 && tokens[0] == "definitions":
+		return false
+	case len(tokens) == 3 && tokens[0] == "components" && tokens[1] == "schemas":
+		return false
+	}
+
+	return true
+}
+
+// flattenedName picks an unused name for a flattened schema, preferring its title.
+func flattenedName(schemas map[string]Schema, pointer, title string) string {
+	located := locatedName(pointer)
+
+	for _, name := range []string{componentName(title), located} {
+		if _, used := schemas[name]; name != "" && !used {
+			return name
+		}
+	}
+
+	for i := 2; ; i++ {
+		name := located + strconv.Itoa(i)
+		if _, used := schemas[name]; !used {
+			return name
+		}
+	}
+}
+
+// locatedName derives the name of a schema from its JSON pointer, e.g. "OrderShipping"
+// for "/components/schemas/Order/properties/shipping".
+func locatedName(pointer string) string {
+	var words []string
+
+	tokens := SplitPointer(pointer)
+	for i, token := range tokens {
+		var parent string
+		if i > 0 {
+			parent = tokens[i-1]
+		}
+
+		switch {
+		case parent == "properties":
+			// property names are kept, even when they collide with a keyword
+		case parent == "content", flattenedKeywords[token]:
+			// media types and structural keywords
+			continue
+		}
+
+		words = append(words, token)
+	}
+
+	return componentName(words...)
+}
+
+// componentName builds a name made of ASCII letters and digits only from words, e.g. "ShippingAddress"
+// for "shipping address".
+func componentName(words ...string) string {
+	var b strings.Builder
+
+	for _, word := range words {
+		parts := strings.FieldsFunc(word, func(r rune) bool {
+			return r > unicode.MaxASCII || !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+
+		for _, part := range parts {
+			runes := []rune(part)
+			b.WriteRune(unicode.ToUpper(runes[0]))
+			b.WriteString(string(runes[1:]))
+		}
+	}
+
+	return b.String()
+}
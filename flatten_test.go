@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSwagger_FlattenSpec(t *testing.T) {
+	t.Run("should name flattened schemas after their title, then their location", func(t *testing.T) {
+		var doc Swagger
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"openapi": "3.1.0",
+			"components": {
+				"schemas": {
+					"Order": {
+						"type": "object",
+						"properties": {
+							"billing": {
+								"title": "Address",
+								"type": "object",
+								"properties": {
+									"street": {"type": "string"},
+									"geo": {"type": "object", "properties": {"lat": {"type": "number"}}}
+								}
+							},
+							"shipping": {"title": "Address", "type": "object", "properties": {"street": {"type": "string"}}},
+							"status": {"type": "string"}
+						}
+					}
+				}
+			},
+			"paths": {
+				"/pets/{id}": {
+					"put": {
+						"requestBody": {
+							"content": {
+								"application/json": {"schema": {"type": "object", "properties": {"name": {"type": "string"}}}}
+							}
+						}
+					}
+				}
+			}
+		}`), &doc))
+
+		flattened := doc.FlattenSpec()
+		assert.Equal(t, map[string]string{
+			"/components/schemas/Order/properties/shipping":                        "OrderShipping",
+			"/components/schemas/Order/properties/billing":                         "Address",
+			"/components/schemas/Address/properties/geo":                           "AddressGeo",
+			"/paths/~1pets~1{id}/put/requestBody/content/application~1json/schema": "PetsIdPutRequestBody",
+		}, flattened)
+
+		order := doc.Components.Schemas["Order"]
+		shipping, billing := order.Properties["shipping"], order.Properties["billing"]
+		assert.Equal(t, "#/components/schemas/Address", billing.Ref.String())
+		assert.Equal(t, "#/components/schemas/OrderShipping", shipping.Ref.String())
+		assert.Equal(t, StringProperty().Type, order.Properties["status"].Type)
+
+		address := doc.Components.Schemas["Address"]
+		assert.Equal(t, "Address", address.Title)
+		geo := address.Properties["geo"]
+		assert.Equal(t, "#/components/schemas/AddressGeo", geo.Ref.String())
+		assert.Contains(t, doc.Components.Schemas, "AddressGeo")
+
+		body := doc.Paths.Paths["/pets/{id}"].Put.RequestBody.Content["application/json"].Schema
+		require.NotNil(t, body)
+		assert.Equal(t, "#/components/schemas/PetsIdPutRequestBody", body.Ref.String())
+
+		assert.Empty(t, doc.FlattenSpec())
+	})
+
+	t.Run("should append a number to names already used", func(t *testing.T) {
+		var doc Swagger
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"openapi": "3.0.3",
+			"components": {
+				"schemas": {
+					"Address": {"type": "string"},
+					"OrderShipping": {"type": "string"},
+					"Order": {
+						"properties": {
+							"shipping": {"title": "Address", "properties": {"street": {"type": "string"}}}
+						}
+					}
+				}
+			}
+		}`), &doc))
+
+		assert.Equal(t, map[string]string{
+			"/components/schemas/Order/properties/shipping": "OrderShipping2",
+		}, doc.FlattenSpec())
+		assert.Equal(t, "Address", doc.Components.Schemas["OrderShipping2"].Title)
+	})
+
+	t.Run("should flatten into definitions with Swagger 2.0 documents", func(t *testing.T) {
+		var doc Swagger
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"swagger": "2.0",
+			"definitions": {
+				"Order": {"properties": {"shipping": {"title": "Shipping address", "properties": {"street": {"type": "string"}}}}}
+			}
+		}`), &doc))
+
+		assert.Equal(t, map[string]string{
+			"/definitions/Order/properties/shipping": "ShippingAddress",
+		}, doc.FlattenSpec())
+		shipping := doc.Definitions["Order"].Properties["shipping"]
+		assert.Equal(t, "#/definitions/ShippingAddress", shipping.Ref.String())
+	})
+}
@@ -8,13 +8,11 @@ require (
 	github.com/go-openapi/swag/jsonutils v0.25.4
 	github.com/go-openapi/swag/loading v0.25.4
 	github.com/go-openapi/swag/stringutils v0.25.4
+	github.com/go-openapi/swag/yamlutils v0.25.4
 	github.com/go-openapi/testify/v2 v2.0.2
 	go.yaml.in/yaml/v3 v3.0.4
 )
 
-require (
-	github.com/go-openapi/swag/typeutils v0.25.4 // indirect
-	github.com/go-openapi/swag/yamlutils v0.25.4 // indirect
-)
+require github.com/go-openapi/swag/typeutils v0.25.4 // indirect
 
 go 1.24.0
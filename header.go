@@ -17,13 +17,15 @@ const (
 
 // HeaderProps describes a response header
 type HeaderProps struct {
-	Description string `json:"description,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"` // OpenAPI 3.x
 }
 
 // Header describes a header for a response of the API
 //
 // For more information: http://goo.gl/8us55a#headerObject
 type Header struct {
+	Refable
 	CommonValidations
 	SimpleSchema
 	VendorExtensible
@@ -138,6 +140,10 @@ func (h *Header) WithValidations(val CommonValidations) *Header {
 
 // MarshalJSON marshal this to JSON
 func (h Header) MarshalJSON() ([]byte, error) {
+	b0, err := json.Marshal(h.Refable)
+	if err != nil {
+		return nil, err
+	}
 	b1, err := json.Marshal(h.CommonValidations)
 	if err != nil {
 		return nil, err
@@ -150,11 +156,14 @@ func (h Header) MarshalJSON() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return jsonutils.ConcatJSON(b1, b2, b3), nil
+	return jsonutils.ConcatJSON(b0, b1, b2, b3), nil
 }
 
 // UnmarshalJSON unmarshals this header from JSON
 func (h *Header) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &h.Refable); err != nil {
+		return err
+	}
 	if err := json.Unmarshal(data, &h.CommonValidations); err != nil {
 		return err
 	}
@@ -167,11 +176,36 @@ func (h *Header) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, &h.HeaderProps)
 }
 
+// Resolve returns the header targeted by the $ref of this header, resolved against root,
+// e.g. a header defined in the components of an OpenAPI 3.x document.
+//
+// Chains of $ref's are followed. As allowed by OpenAPI 3.1, a description set next to a $ref
+// overrides the description of its target. A header without $ref is returned as is.
+func (h Header) Resolve(root any) (*Header, error) {
+	description := h.Description
+
+	resolved, err := followRefs(h, root, func(h *Header) *Ref { return &h.Ref }, ResolveHeader, func(target *Header) {
+		if description == "" {
+			description = target.Description
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolved.Description = description
+
+	return resolved, nil
+}
+
 // JSONLookup look up a value by the json property name
 func (h Header) JSONLookup(token string) (any, error) {
 	if ex, ok := h.Extensions[token]; ok {
 		return &ex, nil
 	}
+	if token == jsonRef {
+		return &h.Ref, nil
+	}
 
 	r, _, err := jsonpointer.GetForToken(h.CommonValidations, token)
 	if err != nil && !strings.HasPrefix(err.Error(), "object has no field") {
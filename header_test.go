@@ -164,3 +164,90 @@ func TestHeaderWithValidation(t *testing.T) {
 	h := new(Header).WithValidations(CommonValidations{MaxLength: conv.Pointer(int64(15))})
 	assert.Equal(t, conv.Pointer(int64(15)), h.MaxLength)
 }
+
+func TestHeader_Resolve(t *testing.T) {
+	const doc = `{
+		"openapi": "3.1.0",
+		"components": {
+			"headers": {
+				"RateLimit": {"description": "requests left", "schema": {"$ref": "#/components/schemas/count"}},
+				"Alias": {"$ref": "#/components/headers/RateLimit"},
+				"Loop": {"$ref": "#/components/headers/Loop"}
+			},
+			"schemas": {
+				"count": {"type": "integer", "minimum": 0}
+			}
+		},
+		"paths": {
+			"/pets": {
+				"get": {
+					"responses": {
+						"200": {
+							"description": "ok",
+							"headers": {
+								"X-Rate-Limit": {"$ref": "#/components/headers/RateLimit"},
+								"X-Alias": {"$ref": "#/components/headers/Alias", "description": "an alias"}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	load := func(t *testing.T) *Swagger {
+		t.Helper()
+
+		var spec Swagger
+		require.NoError(t, json.Unmarshal([]byte(doc), &spec))
+
+		return &spec
+	}
+
+	t.Run("should resolve a response header declared as a $ref", func(t *testing.T) {
+		spec := load(t)
+		header := spec.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Headers["X-Rate-Limit"]
+		require.Equal(t, "#/components/headers/RateLimit", header.Ref.String())
+
+		resolved, err := header.Resolve(spec)
+		require.NoError(t, err)
+		assert.Empty(t, resolved.Ref.String())
+		assert.Equal(t, "requests left", resolved.Description)
+		require.NotNil(t, resolved.Schema)
+		assert.Equal(t, "#/components/schemas/count", resolved.Schema.Ref.String())
+	})
+
+	t.Run("should follow chains of $ref's", func(t *testing.T) {
+		spec := load(t)
+		header := spec.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Headers["X-Alias"]
+
+		resolved, err := header.Resolve(spec)
+		require.NoError(t, err)
+		assert.Equal(t, "an alias", resolved.Description)
+		require.NotNil(t, resolved.Schema)
+	})
+
+	t.Run("should fail on unresolved or circular $ref's", func(t *testing.T) {
+		spec := load(t)
+
+		_, err := Header{Refable: Refable{Ref: MustCreateRef("#/components/headers/missing")}}.Resolve(spec)
+		require.Error(t, err)
+
+		_, err = spec.Components.Headers["Loop"].Resolve(spec)
+		var circular *CircularReferenceError
+		require.ErrorAs(t, err, &circular)
+	})
+
+	t.Run("should expand a response header declared as a $ref", func(t *testing.T) {
+		spec := load(t)
+		delete(spec.Components.Headers, "Loop")
+		require.NoError(t, ExpandSpec(spec, &ExpandOptions{Targets: TargetAll}))
+
+		header := spec.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Headers["X-Rate-Limit"]
+		assert.Empty(t, header.Ref.String())
+		assert.Equal(t, "requests left", header.Description)
+		require.NotNil(t, header.Schema)
+		assert.Empty(t, header.Schema.Ref.String())
+		assert.Equal(t, StringOrArray{"integer"}, header.Schema.Type)
+	})
+}
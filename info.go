@@ -5,6 +5,7 @@ package spec
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -93,6 +94,38 @@ func (v *VendorExtensible) AddExtension(key string, value any) {
 	v.Extensions.Add(key, value)
 }
 
+// DecodeExtension decodes the value of an extension into target, e.g. a struct describing some x-go-type.
+//
+// The value is converted through its JSON representation, so target may be any type accepted by json.Unmarshal.
+// Extension keys are matched case-insensitively. A missing extension produces an error which matches
+// ErrExtensionNotFound.
+func (v VendorExtensible) DecodeExtension(key string, target any) error {
+	value, ok := v.Extensions[key]
+	if !ok {
+		for k, vv := range v.Extensions {
+			if strings.EqualFold(k, key) {
+				value, ok = vv, true
+
+				break
+			}
+		}
+	}
+	if !ok {
+		return fmt.Errorf("extension %q: %w", key, ErrExtensionNotFound)
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("extension %q: %w", key, err)
+	}
+
+	if err := json.Unmarshal(raw, target); err != nil {
+		return fmt.Errorf("extension %q: %w", key, err)
+	}
+
+	return nil
+}
+
 // MarshalJSON marshals the extensions to json
 func (v VendorExtensible) MarshalJSON() ([]byte, error) {
 	toser := make(map[string]any)
@@ -0,0 +1,376 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Validate checks that a value, such as an example, is valid against the schema.
+//
+// Supported keywords are "type", "enum", "const", the validations of numbers, strings, arrays
+// and objects, as well as "allOf", "anyOf", "oneOf" and "not". Formats are not checked, except the ranges
+// of the "int32", "int64" and "float" numeric formats, and "byte", whose strings must be base64 encoded.
+//
+// When root is not nil, $ref's are resolved against root. Otherwise, a schema defined by a $ref accepts any value.
+// Each problem is reported as a *ValidationError located by a JSON pointer relative to value, e.g. "/items/2/price".
+// Its SchemaPath locates the schema rejecting the value, relative to s.
+func (s Schema) Validate(value, root any) []error {
+	v := &instanceValidator{root: root}
+
+	return v.validate(s, normalizedInstance(value), "", "")
+}
+
+type instanceValidator struct {
+	root any
+}
+
+// normalizedInstance converts a go value to the types produced by JSON unmarshaling,
+// e.g. float64 for numbers and map[string]any for objects.
+func normalizedInstance(value any) any {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalized any
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return value
+	}
+
+	return normalized
+}
+
+// validate checks a value located by location, against a schema located by schemaLocation.
+func (v *instanceValidator) validate(schema Schema, value any, location, schemaLocation string) []error {
+	schema, known, schemaLocation, err := v.resolve(schema, schemaLocation)
+	if err != nil {
+		return []error{&ValidationError{Path: location, SchemaPath: schemaLocation, Message: err.Error()}}
+	}
+	if !known {
+		return nil
+	}
+
+	if !schema.allowsType(value) {
+		return []error{&ValidationError{
+			Path:       location,
+			SchemaPath: schemaLocation,
+			Message:    fmt.Sprintf("value %s does not match type %v", enumKey(value), []string(schema.Type)),
+		}}
+	}
+
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, &ValidationError{Path: location, SchemaPath: schemaLocation, Message: fmt.Sprintf(format, args...)})
+	}
+
+	key := enumKey(value)
+	if schema.hasConst() && key != enumKey(schema.Const) {
+		fail("value %s is not the constant %s", key, enumKey(schema.Const))
+	}
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(member any) bool { return enumKey(member) == key }) {
+		fail("value %s is not one of the allowed values %s", key, enumKey(schema.Enum))
+	}
+
+	switch instance := value.(type) {
+	case float64:
+		for _, msg := range schema.numberViolations(instance) {
+			fail("%s", msg)
+		}
+	case string:
+		for _, msg := range schema.stringViolations(instance) {
+			fail("%s", msg)
+		}
+	case []any:
+		errs = append(errs, v.validateArray(schema, instance, location, schemaLocation)...)
+	case map[string]any:
+		errs = append(errs, v.validateObject(schema, instance, location, schemaLocation)...)
+	}
+
+	for i, member := range schema.AllOf {
+		errs = append(errs, v.validate(member, value, location, schemaLocation+pointerTo("allOf", strconv.Itoa(i)))...)
+	}
+
+	if len(schema.AnyOf) > 0 && v.countValid(schema.AnyOf, value, location, schemaLocation+pointerTo("anyOf")) == 0 {
+		fail("value does not match any schema of anyOf")
+	}
+
+	if len(schema.OneOf) > 0 {
+		if matched := v.countValid(schema.OneOf, value, location, schemaLocation+pointerTo("oneOf")); matched != 1 {
+			fail("value matches %d schemas of oneOf, but exactly one is expected", matched)
+		}
+	}
+
+	if schema.Not != nil && len(v.validate(*schema.Not, value, location, schemaLocation+pointerTo("not"))) == 0 {
+		fail("value should not match the schema of not")
+	}
+
+	return errs
+}
+
+// resolve follows the chain of $ref's of a schema, located by schemaLocation.
+//
+// Like the keyword locations of JSON Schema, the location of the resolved schema is the path followed
+// to reach it, i.e. with a "$ref" token for every $ref. It returns false when the schema is a $ref
+// which cannot be resolved without root.
+func (v *instanceValidator) resolve(schema Schema, schemaLocation string) (Schema, bool, string, error) {
+	if schema.Ref.String() != "" && v.root == nil {
+		return schema, false, schemaLocation, nil
+	}
+
+	resolved, err := followRefs(schema, v.root, schemaRefOf, resolveSchemaRef, func(*Schema) {
+		schemaLocation += pointerTo("$ref")
+	})
+	if err != nil {
+		return schema, false, schemaLocation, err
+	}
+
+	return *resolved, true, schemaLocation, nil
+}
+
+func (v *instanceValidator) countValid(schemas []Schema, value any, location, schemaLocation string) int {
+	var valid int
+	for i, member := range schemas {
+		if len(v.validate(member, value, location, schemaLocation+pointerTo(strconv.Itoa(i)))) == 0 {
+			valid++
+		}
+	}
+
+	return valid
+}
+
+func (s Schema) numberViolations(f float64) []string {
+	var violations []string
+	value := enumKey(f)
+
+	if s.Maximum != nil {
+		if s.ExclusiveMaximum && f >= *s.Maximum {
+			violations = append(violations, fmt.Sprintf("value %s should be less than %s", value, enumKey(*s.Maximum)))
+		} else if f > *s.Maximum {
+			violations = append(violations, fmt.Sprintf("value %s should be less than or equal to %s", value, enumKey(*s.Maximum)))
+		}
+	}
+
+	if s.Minimum != nil {
+		if s.ExclusiveMinimum && f <= *s.Minimum {
+			violations = append(violations, fmt.Sprintf("value %s should be greater than %s", value, enumKey(*s.Minimum)))
+		} else if f < *s.Minimum {
+			violations = append(violations, fmt.Sprintf("value %s should be greater than or equal to %s", value, enumKey(*s.Minimum)))
+		}
+	}
+
+	if s.ExclusiveMaxValue != nil && f >= *s.ExclusiveMaxValue {
+		violations = append(violations, fmt.Sprintf("value %s should be less than %s", value, enumKey(*s.ExclusiveMaxValue)))
+	}
+
+	if s.ExclusiveMinValue != nil && f <= *s.ExclusiveMinValue {
+		violations = append(violations, fmt.Sprintf("value %s should be greater than %s", value, enumKey(*s.ExclusiveMinValue)))
+	}
+
+	if msg := s.checkNumericFormat(f); msg != "" {
+		violations = append(violations, msg)
+	}
+
+	if s.MultipleOf != nil && *s.MultipleOf > 0 {
+		// tolerate the rounding errors of decimal multiples, such as 0.1
+		const epsilon = 1e-9
+		if q := f / *s.MultipleOf; math.Abs(q-math.Round(q)) > epsilon {
+			violations = append(violations, fmt.Sprintf("value %s is not a multiple of %s", value, enumKey(*s.MultipleOf)))
+		}
+	}
+
+	return violations
+}
+
+func (s Schema) stringViolations(str string) []string {
+	var violations []string
+	length := int64(utf8.RuneCountInString(str))
+
+	if s.MaxLength != nil && length > *s.MaxLength {
+		violations = append(violations, fmt.Sprintf("string of length %d is longer than %d", length, *s.MaxLength))
+	}
+	if s.MinLength != nil && length < *s.MinLength {
+		violations = append(violations, fmt.Sprintf("string of length %d is shorter than %d", length, *s.MinLength))
+	}
+
+	if msg := s.checkByteFormat(str); msg != "" {
+		violations = append(violations, msg)
+	}
+
+	if s.Pattern != "" {
+		// invalid patterns are not reported here
+		if rex, err := regexp.Compile(s.Pattern); err == nil && !rex.MatchString(str) {
+			violations = append(violations, fmt.Sprintf("string %q does not match pattern %q", str, s.Pattern))
+		}
+	}
+
+	return violations
+}
+
+func (v *instanceValidator) validateArray(schema Schema, items []any, location, schemaLocation string) []error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, &ValidationError{Path: location, SchemaPath: schemaLocation, Message: fmt.Sprintf(format, args...)})
+	}
+
+	size := int64(len(items))
+	if schema.MaxItems != nil && size > *schema.MaxItems {
+		fail("array of %d items has more than %d items", size, *schema.MaxItems)
+	}
+	if schema.MinItems != nil && size < *schema.MinItems {
+		fail("array of %d items has less than %d items", size, *schema.MinItems)
+	}
+
+	if schema.UniqueItems {
+		seen := make(map[string]int, len(items))
+		for i, item := range items {
+			key := enumKey(item)
+			if first, duplicate := seen[key]; duplicate {
+				fail("item %d duplicates the item at index %d", i, first)
+
+				continue
+			}
+			seen[key] = i
+		}
+	}
+
+	if schema.Contains != nil {
+		containsLocation := schemaLocation + pointerTo("contains")
+
+		var matching int64
+		for i, item := range items {
+			if len(v.validate(*schema.Contains, item, location+pointerTo(strconv.Itoa(i)), containsLocation)) == 0 {
+				matching++
+			}
+		}
+
+		switch {
+		case schema.MinContains != nil && matching < *schema.MinContains:
+			fail("array has %d items matching the schema of contains, less than minContains %d", matching, *schema.MinContains)
+		case schema.MinContains == nil && matching == 0:
+			fail("array has no item matching the schema of contains")
+		}
+		if schema.MaxContains != nil && matching > *schema.MaxContains {
+			fail("array has %d items matching the schema of contains, more than maxContains %d", matching, *schema.MaxContains)
+		}
+	}
+
+	if schema.Items == nil {
+		return errs
+	}
+
+	for i, item := range items {
+		itemLocation := location + pointerTo(strconv.Itoa(i))
+
+		switch {
+		case schema.Items.Schema != nil:
+			errs = append(errs, v.validate(*schema.Items.Schema, item, itemLocation, schemaLocation+pointerTo("items"))...)
+		case i < len(schema.Items.Schemas):
+			errs = append(errs, v.validate(schema.Items.Schemas[i], item, itemLocation, schemaLocation+pointerTo("items", strconv.Itoa(i)))...)
+		case schema.AdditionalItems == nil:
+		case schema.AdditionalItems.Schema != nil:
+			errs = append(errs, v.validate(*schema.AdditionalItems.Schema, item, itemLocation, schemaLocation+pointerTo("additionalItems"))...)
+		case !schema.AdditionalItems.Allows:
+			errs = append(errs, &ValidationError{
+				Path:       itemLocation,
+				SchemaPath: schemaLocation + pointerTo("additionalItems"),
+				Message:    "additional items are not allowed",
+			})
+		}
+	}
+
+	return errs
+}
+
+func (v *instanceValidator) validateObject(schema Schema, object map[string]any, location, schemaLocation string) []error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, &ValidationError{Path: location, SchemaPath: schemaLocation, Message: fmt.Sprintf(format, args...)})
+	}
+
+	size := int64(len(object))
+	if schema.MaxProperties != nil && size > *schema.MaxProperties {
+		fail("object of %d properties has more than %d properties", size, *schema.MaxProperties)
+	}
+	if schema.MinProperties != nil && size < *schema.MinProperties {
+		fail("object of %d properties has less than %d properties", size, *schema.MinProperties)
+	}
+
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			fail("required property %q is missing", name)
+		}
+	}
+
+	type patternProperty struct {
+		rex      *regexp.Regexp
+		schema   *Schema
+		location string
+	}
+	patterns := make([]patternProperty, 0, len(schema.PatternProperties))
+	for _, pattern := range slices.Sorted(maps.Keys(schema.PatternProperties)) {
+		if rex, err := regexp.Compile(pattern); err == nil {
+			patterns = append(patterns, patternProperty{
+				rex:      rex,
+				schema:   schema.PatternProperties[pattern].Schema,
+				location: schemaLocation + pointerTo("patternProperties", pattern),
+			})
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(object)) {
+		property := object[name]
+		propertyLocation := location + pointerTo(name)
+		matched := false
+
+		namesLocation := schemaLocation + pointerTo("propertyNames")
+		if schema.PropertyNames != nil && len(v.validate(*schema.PropertyNames, name, propertyLocation, namesLocation)) > 0 {
+			errs = append(errs, &ValidationError{
+				Path:       propertyLocation,
+				SchemaPath: namesLocation,
+				Message:    fmt.Sprintf("property name %q does not match the schema of propertyNames", name),
+			})
+		}
+
+		if sch, ok := schema.Properties[name]; ok {
+			matched = true
+			errs = append(errs, v.validate(sch, property, propertyLocation, schemaLocation+pointerTo("properties", name))...)
+		}
+
+		for _, pattern := range patterns {
+			if !pattern.rex.MatchString(name) {
+				continue
+			}
+
+			matched = true
+			if pattern.schema != nil {
+				errs = append(errs, v.validate(*pattern.schema, property, propertyLocation, pattern.location)...)
+			}
+		}
+
+		if matched || schema.AdditionalProperties == nil {
+			continue
+		}
+
+		switch {
+		case schema.AdditionalProperties.Schema != nil:
+			errs = append(errs, v.validate(*schema.AdditionalProperties.Schema, property, propertyLocation, schemaLocation+pointerTo("additionalProperties"))...)
+		case !schema.AdditionalProperties.Allows:
+			errs = append(errs, &ValidationError{
+				Path:       propertyLocation,
+				SchemaPath: schemaLocation + pointerTo("additionalProperties"),
+				Message:    fmt.Sprintf("additional property %q is not allowed", name),
+			})
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schema   string
+		value    any
+		expected []string
+	}{
+		{
+			name:   "valid object",
+			schema: `{"type": "object", "required": ["id"], "properties": {"id": {"type": "integer"}}}`,
+			value:  map[string]any{"id": 1, "name": "rex"},
+		},
+		{
+			name:     "mismatched type",
+			schema:   `{"type": "string"}`,
+			value:    12,
+			expected: []string{`value 12 does not match type [string]`},
+		},
+		{
+			name:     "exclusive maximum and multiple",
+			schema:   `{"type": "number", "maximum": 10, "exclusiveMaximum": true, "multipleOf": 3}`,
+			value:    10,
+			expected: []string{`value 10 should be less than 10`, `value 10 is not a multiple of 3`},
+		},
+		{
+			name:     "numeric exclusive bounds",
+			schema:   `{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 10}`,
+			value:    0,
+			expected: []string{`value 0 should be greater than 0`},
+		},
+		{
+			name:     "int32 overflow",
+			schema:   `{"type": "integer", "format": "int32"}`,
+			value:    int64(1 << 40),
+			expected: []string{`value 1099511627776 overflows format int32`},
+		},
+		{
+			name:   "int32 value",
+			schema: `{"type": "integer", "format": "int32"}`,
+			value:  int64(1 << 30),
+		},
+		{
+			name:   "base64 byte string",
+			schema: `{"type": "string", "format": "byte"}`,
+			value:  "aGVsbG8gd29ybGQ=",
+		},
+		{
+			name:     "invalid byte string",
+			schema:   `{"type": "string", "format": "byte"}`,
+			value:    "hello world",
+			expected: []string{`string "hello world" is not base64 encoded, as required by format byte`},
+		},
+		{
+			name:   "decimal multiple",
+			schema: `{"type": "number", "multipleOf": 0.1}`,
+			value:  0.3,
+		},
+		{
+			name:     "string validations",
+			schema:   `{"type": "string", "minLength": 4, "pattern": "^[a-z]+$"}`,
+			value:    "Rex",
+			expected: []string{`string of length 3 is shorter than 4`, `string "Rex" does not match pattern "^[a-z]+$"`},
+		},
+		{
+			name:     "enum and const",
+			schema:   `{"enum": ["a", "b"], "const": "a"}`,
+			value:    "c",
+			expected: []string{`value "c" is not the constant "a"`, `value "c" is not one of the allowed values ["a","b"]`},
+		},
+		{
+			name:     "array items",
+			schema:   `{"type": "array", "maxItems": 2, "uniqueItems": true, "items": {"type": "integer"}}`,
+			value:    []any{1, 1, "x"},
+			expected: []string{`array of 3 items has more than 2 items`, `item 1 duplicates the item at index 0`, `/2: value "x" does not match type [integer]`},
+		},
+		{
+			name: "closed object",
+			schema: `{
+				"type": "object",
+				"required": ["id"],
+				"properties": {"id": {"type": "integer"}},
+				"patternProperties": {"^x-": {"type": "string"}},
+				"additionalProperties": false
+			}`,
+			value: map[string]any{"x-tag": true, "name": "rex"},
+			expected: []string{
+				`required property "id" is missing`,
+				`/name: additional property "name" is not allowed`,
+				`/x-tag: value true does not match type [string]`,
+			},
+		},
+		{
+			name:     "too few properties",
+			schema:   `{"type": "object", "minProperties": 2, "maxProperties": 3}`,
+			value:    map[string]any{"id": 1},
+			expected: []string{`object of 1 properties has less than 2 properties`},
+		},
+		{
+			name:     "property names",
+			schema:   `{"type": "object", "propertyNames": {"pattern": "^[a-z]+$"}}`,
+			value:    map[string]any{"name": "rex", "Tag": "dog"},
+			expected: []string{`/Tag: property name "Tag" does not match the schema of propertyNames`},
+		},
+		{
+			name:     "contains",
+			schema:   `{"type": "array", "contains": {"type": "string"}}`,
+			value:    []any{1, 2},
+			expected: []string{`array has no item matching the schema of contains`},
+		},
+		{
+			name:     "min and max contains",
+			schema:   `{"type": "array", "contains": {"type": "string"}, "minContains": 0, "maxContains": 1}`,
+			value:    []any{"a", 1, "b"},
+			expected: []string{`array has 2 items matching the schema of contains, more than maxContains 1`},
+		},
+		{
+			name:     "oneOf",
+			schema:   `{"oneOf": [{"type": "number"}, {"type": "integer"}]}`,
+			value:    1,
+			expected: []string{`value matches 2 schemas of oneOf, but exactly one is expected`},
+		},
+		{
+			name:     "anyOf and not",
+			schema:   `{"anyOf": [{"type": "string"}, {"type": "boolean"}], "not": {"type": "integer"}}`,
+			value:    1,
+			expected: []string{`value does not match any schema of anyOf`, `value should not match the schema of not`},
+		},
+		{
+			name:   "unresolved $ref",
+			schema: `{"$ref": "#/definitions/pet"}`,
+			value:  "anything",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var schema Schema
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+
+			errs := schema.Validate(tc.value, nil)
+			require.Len(t, errs, len(tc.expected))
+			for i, err := range errs {
+				require.ErrorIs(t, err, ErrSpec)
+				assert.EqualError(t, err, tc.expected[i])
+			}
+		})
+	}
+
+	t.Run("should build a schema with property names", func(t *testing.T) {
+		schema := MapProperty(StringProperty()).WithPropertyNames(new(Schema).WithPattern("^x-"))
+		assertSerializeJSON(t, schema.PropertyNames, `{"pattern":"^x-"}`)
+
+		assert.Empty(t, schema.Validate(map[string]any{"x-tag": "dog"}, nil))
+		assert.Len(t, schema.Validate(map[string]any{"tag": "dog"}, nil), 1)
+	})
+
+	t.Run("should forbid values with not", func(t *testing.T) {
+		schema := StringProperty().WithNot(new(Schema).WithEnum("deleted"))
+
+		assert.Empty(t, schema.Validate("available", nil))
+
+		errs := schema.Validate("deleted", nil)
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], `value should not match the schema of not`)
+	})
+
+	t.Run("should build a schema requiring at least 2 matching items", func(t *testing.T) {
+		admin := new(Schema).Typed("object", "").WithRequired("role")
+		admin.SetProperty("role", *StringProperty().WithEnum("admin"))
+		schema := ArrayProperty(new(Schema).Typed("object", "")).WithContains(admin).WithMinContains(2)
+		assertSerializeJSON(t, schema, `{"type":"array","items":{"type":"object"},`+
+			`"contains":{"type":"object","required":["role"],"properties":{"role":{"type":"string","enum":["admin"]}}},"minContains":2}`)
+
+		alice := map[string]any{"name": "alice", "role": "admin"}
+		bob := map[string]any{"name": "bob", "role": "admin"}
+		carol := map[string]any{"name": "carol"}
+
+		assert.Empty(t, schema.Validate([]any{alice, carol, bob}, nil))
+
+		errs := schema.Validate([]any{alice, carol}, nil)
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], `array has 1 items matching the schema of contains, less than minContains 2`)
+	})
+
+	t.Run("should reject a parameter value out of its enum", func(t *testing.T) {
+		param := QueryParam("status")
+		param.Schema = StringProperty().WithEnum("available", "pending", "sold")
+
+		assert.Empty(t, param.Schema.Validate("pending", nil))
+
+		errs := param.Schema.Validate("lost", nil)
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], `value "lost" is not one of the allowed values ["available","pending","sold"]`)
+	})
+
+	t.Run("should resolve $ref's against root", func(t *testing.T) {
+		root := map[string]any{
+			"definitions": map[string]any{
+				"pet": map[string]any{"type": "object", "required": []any{"name"}},
+			},
+		}
+		schema := ArrayProperty(RefSchema("#/definitions/pet"))
+
+		errs := schema.Validate([]any{map[string]any{"name": "rex"}, map[string]any{}}, root)
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], `/1: required property "name" is missing`)
+	})
+
+	t.Run("should locate errors in the instance and in the schema", func(t *testing.T) {
+		var root Swagger
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"openapi": "3.1.0",
+			"components": {
+				"schemas": {
+					"line": {
+						"type": "object",
+						"properties": {"price": {"type": "number", "minimum": 0}}
+					}
+				}
+			}
+		}`), &root))
+		body := new(Schema).Typed("object", "").
+			SetProperty("items", *ArrayProperty(RefSchema("#/components/schemas/line")))
+
+		errs := body.Validate(map[string]any{
+			"items": []any{
+				map[string]any{"price": 1.5},
+				map[string]any{"price": 0},
+				map[string]any{"price": -1},
+			},
+		}, root)
+		require.Len(t, errs, 1)
+
+		var verr *ValidationError
+		require.ErrorAs(t, errs[0], &verr)
+		assert.Equal(t, "/items/2/price", verr.Path)
+		assert.Equal(t, "/properties/items/items/$ref/properties/price", verr.SchemaPath)
+		assert.EqualError(t, verr, `/items/2/price: value -1 should be greater than or equal to 0`)
+	})
+}
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// keywords specific to OpenAPI, which JSON Schema validators do not know about
+var openAPIKeywords = []string{"nullable", "discriminator", "xml", "externalDocs", "example"}
+
+// ToJSONSchema produces a standalone JSON Schema document of the given dialect from this schema.
+//
+// The dialect is one of JSONSchemaURL (draft 4) or JSONSchema2020URL, the latter being the default
+// when empty. It is declared by the "$schema" keyword of the document.
+//
+// OpenAPI constructs are translated into the dialect:
+//   - "nullable: true" adds "null" to the type, and null to the enum, if any
+//   - "example" becomes a member of "examples" (2020-12 only)
+//   - boolean exclusive bounds become numeric ones with 2020-12, and numeric ones boolean with draft 4
+//   - "const" becomes a single value enum with draft 4
+//   - "definitions", tuple "items" and "additionalItems" become "$defs", "prefixItems" and "items" with 2020-12
+//
+// "discriminator", "xml" and "externalDocs" are dropped: a discriminated composition is still validated
+// by its "oneOf" or "anyOf". Vendor extensions are kept.
+//
+// $ref's are not resolved: expand the schema beforehand to get a self-contained document.
+func (s Schema) ToJSONSchema(dialect string) ([]byte, error) {
+	if dialect == "" {
+		dialect = JSONSchema2020URL
+	}
+	if dialect != JSONSchemaURL && dialect != JSONSchema2020URL {
+		return nil, fmt.Errorf("JSON Schema dialect %q: %w", dialect, errors.ErrUnsupported)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	translateSchema(doc, dialect == JSONSchema2020URL)
+	doc["$schema"] = dialect
+
+	return json.Marshal(doc)
+}
+
+// ToJSONSchema produces a standalone JSON Schema document from a schema of this document, see Schema.ToJSONSchema.
+//
+// The empty dialect defaults to the dialect of the OpenAPI version of the document, as told by SpecVersion:
+// OpenAPI 3.0 schemas are an extension of JSON Schema draft 4, later versions of JSON Schema 2020-12.
+func (s *Swagger) ToJSONSchema(schema Schema, dialect string) ([]byte, error) {
+	if dialect == "" {
+		_, minor, err := s.SpecVersion()
+		if err != nil {
+			return nil, err
+		}
+
+		dialect = JSONSchema2020URL
+		if minor == 0 {
+			dialect = JSONSchemaURL
+		}
+	}
+
+	return schema.ToJSONSchema(dialect)
+}
+
+// translateSchema translates in place the OpenAPI constructs of a generic schema and of its nested schemas.
+func translateSchema(schema map[string]any, to2020 bool) {
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		addNull(schema)
+	}
+
+	if example, ok := schema["example"]; ok && to2020 {
+		examples, _ := schema["examples"].([]any)
+		schema["examples"] = append(examples, example)
+	}
+
+	for _, keyword := range openAPIKeywords {
+		delete(schema, keyword)
+	}
+
+	if to2020 {
+		translateBound(schema, "maximum", "exclusiveMaximum")
+		translateBound(schema, "minimum", "exclusiveMinimum")
+		renameKeyword(schema, "definitions", "$defs")
+
+		if tuple, isTuple := schema["items"].([]any); isTuple {
+			delete(schema, "items")
+			renameKeyword(schema, "additionalItems", "items")
+			schema["prefixItems"] = tuple
+		}
+	} else {
+		if value, ok := schema["const"]; ok {
+			delete(schema, "const")
+			schema["enum"] = []any{value}
+		}
+
+		inclusiveBound(schema, "maximum", "exclusiveMaximum", func(a, b float64) bool { return a < b })
+		inclusiveBound(schema, "minimum", "exclusiveMinimum", func(a, b float64) bool { return a > b })
+		renameKeyword(schema, "$defs", "definitions")
+	}
+
+	for _, keyword := range []string{"items", "additionalItems", "additionalProperties", "not", "contains", "propertyNames"} {
+		translateNested(schema[keyword], to2020)
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf", "prefixItems", "items"} {
+		if members, ok := schema[keyword].([]any); ok {
+			for _, member := range members {
+				translateNested(member, to2020)
+			}
+		}
+	}
+
+	for _, keyword := range []string{"properties", "patternProperties", "$defs", "definitions", "dependencies", "dependentSchemas"} {
+		if schemas, ok := schema[keyword].(map[string]any); ok {
+			for _, nested := range schemas {
+				translateNested(nested, to2020)
+			}
+		}
+	}
+}
+
+// translateNested translates a value which is a schema, leaving booleans and arrays of property names alone.
+func translateNested(value any, to2020 bool) {
+	if nested, ok := value.(map[string]any); ok {
+		translateSchema(nested, to2020)
+	}
+}
+
+func addNull(schema map[string]any) {
+	switch tpe := schema["type"].(type) {
+	case string:
+		if tpe != "null" {
+			schema["type"] = []any{tpe, "null"}
+		}
+	case []any:
+		if !slices.Contains(tpe, any("null")) {
+			schema["type"] = append(tpe, "null")
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, nil) {
+		schema["enum"] = append(enum, nil)
+	}
+}
+
+// translateBound converts a boolean exclusive bound (draft 4) into its numeric form (2020-12).
+func translateBound(schema map[string]any, bound, exclusive string) {
+	isExclusive, ok := schema[exclusive].(bool)
+	if !ok {
+		return
+	}
+
+	delete(schema, exclusive)
+	if limit, ok := schema[bound]; ok && isExclusive {
+		delete(schema, bound)
+		schema[exclusive] = limit
+	}
+}
+
+func renameKeyword(schema map[string]any, from, to string) {
+	value, ok := schema[from]
+	if !ok {
+		return
+	}
+
+	delete(schema, from)
+	schema[to] = value
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSchema_ToJSONSchema(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schema   string
+		dialect  string
+		expected string
+	}{
+		{
+			name: "nullable 3.0 schema to 2020-12",
+			schema: `{
+				"type": "object",
+				"properties": {
+					"tag": {"type": "string", "nullable": true, "example": "dog"},
+					"size": {"type": "string", "enum": ["S", "M"], "nullable": true},
+					"age": {"type": "integer", "minimum": 0, "maximum": 30, "exclusiveMaximum": true}
+				}
+			}`,
+			expected: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {
+					"tag": {"type": ["string", "null"], "examples": ["dog"]},
+					"size": {"type": ["string", "null"], "enum": ["S", "M", null]},
+					"age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 30}
+				}
+			}`,
+		},
+		{
+			name: "discriminated composition",
+			schema: `{
+				"oneOf": [{"$ref": "#/$defs/Cat"}, {"$ref": "#/$defs/Dog"}],
+				"discriminator": {"propertyName": "petType"},
+				"externalDocs": {"url": "https://example.com"},
+				"x-go-type": "Pet"
+			}`,
+			dialect: JSONSchema2020URL,
+			expected: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"oneOf": [{"$ref": "#/$defs/Cat"}, {"$ref": "#/$defs/Dog"}],
+				"x-go-type": "Pet"
+			}`,
+		},
+		{
+			name:   "tuples and definitions to 2020-12",
+			schema: `{"items": [{"type": "string"}, {"type": "integer", "nullable": true}], "additionalItems": false, "definitions": {"id": {"type": "string"}}}`,
+			expected: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"prefixItems": [{"type": "string"}, {"type": ["integer", "null"]}],
+				"items": false,
+				"$defs": {"id": {"type": "string"}}
+			}`,
+		},
+		{
+			name:    "const and nullable to draft 4",
+			schema:  `{"type": "string", "const": "dog", "nullable": true, "example": "dog", "$defs": {"id": {"type": "string"}}}`,
+			dialect: JSONSchemaURL,
+			expected: `{
+				"$schema": "http://json-schema.org/draft-04/schema#",
+				"type": ["string", "null"],
+				"enum": ["dog"],
+				"definitions": {"id": {"type": "string"}}
+			}`,
+		},
+		{
+			name: "numeric exclusive bounds to draft 4",
+			schema: `{
+				"type": "object",
+				"properties": {
+					"age": {"type": "integer", "exclusiveMinimum": 0, "maximum": 30, "exclusiveMaximum": 40}
+				},
+				"propertyNames": {"type": "string", "exclusiveMaximum": 10},
+				"dependentSchemas": {"age": {"minimum": 5, "exclusiveMinimum": 1}}
+			}`,
+			dialect: JSONSchemaURL,
+			expected: `{
+				"$schema": "http://json-schema.org/draft-04/schema#",
+				"type": "object",
+				"properties": {
+					"age": {"type": "integer", "minimum": 0, "exclusiveMinimum": true, "maximum": 30}
+				},
+				"propertyNames": {"type": "string", "maximum": 10, "exclusiveMaximum": true},
+				"dependentSchemas": {"age": {"minimum": 5}}
+			}`,
+		},
+		{
+			name: "nested property names and dependent schemas to 2020-12",
+			schema: `{
+				"propertyNames": {"maxLength": 3, "nullable": true},
+				"dependentSchemas": {"age": {"minimum": 5, "exclusiveMinimum": true}}
+			}`,
+			expected: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"propertyNames": {"maxLength": 3},
+				"dependentSchemas": {"age": {"exclusiveMinimum": 5}}
+			}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var schema Schema
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+
+			doc, err := schema.ToJSONSchema(tc.dialect)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(doc))
+		})
+	}
+
+	t.Run("should fail on an unsupported dialect", func(t *testing.T) {
+		_, err := StringProperty().ToJSONSchema("http://json-schema.org/draft-07/schema#")
+		require.ErrorIs(t, err, errors.ErrUnsupported)
+	})
+}
+
+func TestSwagger_ToJSONSchema(t *testing.T) {
+	schema := Float64Property().WithMinimum(0, true)
+
+	t.Run("should default to draft 4 with OpenAPI 3.0", func(t *testing.T) {
+		doc, err := (&Swagger{SwaggerProps: SwaggerProps{OpenAPI: "3.0.3"}}).ToJSONSchema(*schema, "")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "http://json-schema.org/draft-04/schema#",
+			"type": "number", "format": "double", "minimum": 0, "exclusiveMinimum": true
+		}`, string(doc))
+	})
+
+	t.Run("should default to 2020-12 with later versions", func(t *testing.T) {
+		for _, version := range []string{"3.1.0", OpenAPIVersion} {
+			doc, err := (&Swagger{SwaggerProps: SwaggerProps{OpenAPI: version}}).ToJSONSchema(*schema, "")
+			require.NoError(t, err)
+			assert.JSONEq(t, `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "number", "format": "double", "exclusiveMinimum": 0
+			}`, string(doc))
+		}
+	})
+
+	t.Run("should fail without a supported version", func(t *testing.T) {
+		_, err := new(Swagger).ToJSONSchema(*schema, "")
+		require.ErrorIs(t, err, ErrSpec)
+	})
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var pathItemMethods = operationKeys(reflect.TypeFor[PathItemProps]())
+
+// operationKeys collects the JSON names of the operations of a path item.
+func operationKeys(typ reflect.Type) []string {
+	var keys []string
+	for i := range typ.NumField() {
+		if field := typ.Field(i); field.Type == reflect.TypeFor[*Operation]() {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			keys = append(keys, name)
+		}
+	}
+
+	return keys
+}
+
+// genericWalker visits the operations and schemas of a spec decoded as generic JSON, i.e. as a map[string]any.
+//
+// Schemas are found in components, definitions and operations, as well as nested in other schemas.
+// Callbacks may modify the visited objects: nested objects are looked up after the callback returns.
+// A nil callback skips the corresponding objects.
+type genericWalker struct {
+	operation func(op map[string]any, location string)
+	schema    func(schema map[string]any, location string)
+}
+
+func (w genericWalker) document(doc map[string]any) {
+	w.schemas(doc["definitions"], pointerTo("definitions"))
+
+	if components, ok := doc["components"].(map[string]any); ok {
+		w.components(components)
+	}
+
+	for _, section := range []string{"paths", "webhooks"} {
+		items, _ := doc[section].(map[string]any)
+		for _, pth := range slices.Sorted(maps.Keys(items)) {
+			w.pathItem(items[pth], pointerTo(section, pth))
+		}
+	}
+}
+
+func (w genericWalker) components(components map[string]any) {
+	location := pointerTo("components")
+	w.schemas(components["schemas"], location+pointerTo("schemas"))
+
+	named := func(section string, visit func(value any, location string)) {
+		objects, _ := components[section].(map[string]any)
+		for _, name := range slices.Sorted(maps.Keys(objects)) {
+			visit(objects[name], location+pointerTo(section, name))
+		}
+	}
+
+	named("parameters", w.parameter)
+	named("headers", w.parameter)
+	named("requestBodies", w.requestBody)
+	named("responses", w.response)
+	named("pathItems", w.pathItem)
+}
+
+func (w genericWalker) pathItem(value any, location string) {
+	item, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+
+	w.parameters(item["parameters"], location+pointerTo("parameters"))
+	for _, method := range pathItemMethods {
+		if op, ok := item[method].(map[string]any); ok {
+			if w.operation != nil {
+				w.operation(op, location+pointerTo(method))
+			}
+			w.operationContent(op, location+pointerTo(method))
+		}
+	}
+}
+
+func (w genericWalker) operationContent(op map[string]any, location string) {
+	w.parameters(op["parameters"], location+pointerTo("parameters"))
+	w.requestBody(op["requestBody"], location+pointerTo("requestBody"))
+
+	responses, _ := op["responses"].(map[string]any)
+	for _, code := range slices.Sorted(maps.Keys(responses)) {
+		w.response(responses[code], location+pointerTo("responses", code))
+	}
+}
+
+func (w genericWalker) parameters(value any, location string) {
+	params, _ := value.([]any)
+	for i, param := range params {
+		w.parameter(param, location+pointerTo(strconv.Itoa(i)))
+	}
+}
+
+// parameter visits a parameter, or a header, which share the schema and content fields.
+func (w genericWalker) parameter(value any, location string) {
+	param, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+
+	w.nestedSchema(param["schema"], location+pointerTo("schema"))
+	w.content(param["content"], location+pointerTo("content"))
+}
+
+func (w genericWalker) requestBody(value any, location string) {
+	if body, ok := value.(map[string]any); ok {
+		w.content(body["content"], location+pointerTo("content"))
+	}
+}
+
+func (w genericWalker) response(value any, location string) {
+	response, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+
+	w.content(response["content"], location+pointerTo("content"))
+
+	headers, _ := response["headers"].(map[string]any)
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		w.parameter(headers[name], location+pointerTo("headers", name))
+	}
+}
+
+func (w genericWalker) content(value any, location string) {
+	content, _ := value.(map[string]any)
+	for _, mediaType := range slices.Sorted(maps.Keys(content)) {
+		if media, ok := content[mediaType].(map[string]any); ok {
+			w.nestedSchema(media["schema"], location+pointerTo(mediaType, "schema"))
+		}
+	}
+}
+
+func (w genericWalker) schemas(value any, location string) {
+	schemas, _ := value.(map[string]any)
+	for _, name := range slices.Sorted(maps.Keys(schemas)) {
+		w.nestedSchema(schemas[name], location+pointerTo(name))
+	}
+}
+
+// nestedSchema visits a schema and the schemas nested in it. Boolean schemas are skipped.
+func (w genericWalker) nestedSchema(value any, location string) {
+	schema, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+
+	if w.schema != nil {
+		w.schema(schema, location)
+	}
+
+	// tuples of items are arrays, which are skipped here
+	for _, keyword := range []string{
+		"items", "additionalProperties", "additionalItems", "not", "contains", "if", "then", "else",
+		"propertyNames", "unevaluatedItems", "unevaluatedProperties", "contentSchema",
+	} {
+		w.nestedSchema(schema[keyword], location+pointerTo(keyword))
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf", "prefixItems", "items"} {
+		members, _ := schema[keyword].([]any)
+		for i, member := range members {
+			w.nestedSchema(member, location+pointerTo(keyword, strconv.Itoa(i)))
+		}
+	}
+
+	for _, keyword := range []string{"properties", "patternProperties", "dependentSchemas", "$defs", "definitions"} {
+		w.schemas(schema[keyword], location+pointerTo(keyword))
+	}
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"strings"
+
+	"github.com/go-openapi/swag/loading"
+	"github.com/go-openapi/swag/yamlutils"
+)
+
+// LoadFromReader reads a JSON spec document from r.
+//
+// The returned document is not expanded: $ref's are left unresolved.
+func LoadFromReader(r io.Reader) (*Swagger, error) {
+	doc := new(Swagger)
+	if err := json.NewDecoder(r).Decode(doc); err != nil {
+		return nil, &ParseError{Err: err}
+	}
+
+	return doc, nil
+}
+
+// LoadFromFS loads the JSON or YAML spec document located at pth in fsys, then expands it.
+//
+// Relative $ref's are resolved within fsys, so a spec split over several files
+// may be loaded from an embed.FS. JSON and YAML documents may refer to each other freely. Remote $ref's are loaded with the PathLoader
+// set in the options, or the package level default.
+//
+// The RelativeBase option is ignored: the root document is pth.
+func LoadFromFS(fsys fs.FS, pth string, opts *ExpandOptions) (*Swagger, error) {
+	data, err := fs.ReadFile(fsys, pth)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := documentJSON(pth, data)
+	if err != nil {
+		return nil, &ParseError{Err: err}
+	}
+
+	doc, err := LoadFromReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	options := optionsOrDefault(opts)
+	options.PathLoader = fsLoader(fsys, options.pathLoader())
+	options.PathLoaderContext = nil
+	options.Resolver = nil
+	options.RelativeBase = (&url.URL{Scheme: fileScheme, Path: "/" + pth}).String()
+
+	if err := ExpandSpec(doc, options); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// LoadFromURL fetches the JSON spec document located at an http or https URL, then expands it.
+//
+// The document is fetched with the PathLoader set in the options, or the package level default.
+// Relative $ref's are resolved against the URL of the root document.
+//
+// The RelativeBase option is ignored: the root document is u.
+func LoadFromURL(u string, opts *ExpandOptions) (*Swagger, error) {
+	parsed, err := parseURL(u)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("cannot load %q: only http and https URLs are supported", u)
+	}
+
+	options := optionsOrDefault(opts)
+	data, err := options.pathLoader()(u)
+	if err != nil {
+		return nil, &RemoteFetchError{URL: u, Err: err}
+	}
+
+	doc, err := LoadFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	options.RelativeBase = normalizeBase(u)
+
+	if err := ExpandSpec(doc, options); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// fsLoader builds a document loader that reads local files from fsys.
+//
+// Documents which are not local files are fetched with fallback.
+func fsLoader(fsys fs.FS, fallback func(string) (json.RawMessage, error)) func(string) (json.RawMessage, error) {
+	if fallback == nil {
+		fallback = PathLoader
+	}
+
+	return func(pth string) (json.RawMessage, error) {
+		u, err := parseURL(pth)
+		if err != nil || u.Scheme != fileScheme {
+			return fallback(pth)
+		}
+
+		data, err := fs.ReadFile(fsys, strings.TrimPrefix(u.Path, "/"))
+		if err != nil {
+			return nil, err
+		}
+
+		return documentJSON(u.Path, data)
+	}
+}
+
+// documentJSON returns the JSON form of a document loaded from pth.
+//
+// The parser is picked from the extension of pth: .yaml and .yml documents are converted from YAML,
+// .json ones are kept as is. Documents without either extension are converted from YAML unless they look like JSON.
+func documentJSON(pth string, data []byte) (json.RawMessage, error) {
+	switch {
+	case loading.YAMLMatcher(pth):
+	case loading.JSONMatcher(pth), looksLikeJSON(data):
+		return json.RawMessage(data), nil
+	}
+
+	doc, err := yamlutils.BytesToYAMLDoc(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return yamlutils.YAMLToJSON(doc)
+}
+
+func looksLikeJSON(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
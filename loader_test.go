@@ -0,0 +1,268 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	spec "github.com/allons-y/openapi-spec"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestLoadFromReader(t *testing.T) {
+	t.Run("should load a spec from a reader", func(t *testing.T) {
+		doc, err := spec.LoadFromReader(strings.NewReader(`{"openapi":"3.1.0","info":{"title":"pets","version":"1.0"}}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "3.1.0", doc.OpenAPI)
+		require.NotNil(t, doc.Info)
+		assert.Equal(t, "pets", doc.Info.Title)
+	})
+
+	t.Run("should fail on invalid JSON", func(t *testing.T) {
+		_, err := spec.LoadFromReader(strings.NewReader(`{"openapi":`))
+		require.Error(t, err)
+	})
+}
+
+func TestLoadStrict(t *testing.T) {
+	const misspelled = `{
+		"openapi": "3.1.0",
+		"info": {"title": "pets", "version": "1.0"},
+		"x-owner": "pets team",
+		"paths": {
+			"/pets": {
+				"get": {
+					"descripton": "list pets",
+					"response": {},
+					"responses": {
+						"200": {
+							"description": "ok",
+							"content": {
+								"application/json": {
+									"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}
+								}
+							}
+						}
+					}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Pet": {
+					"type": "object",
+					"examples": [{"name": "Rex"}],
+					"properties": {"name": {"type": "string", "maxLenght": 80}}
+				}
+			}
+		}
+	}`
+
+	t.Run("should accept misspelled fields in lenient mode", func(t *testing.T) {
+		doc, err := spec.LoadFromReader(strings.NewReader(misspelled))
+		require.NoError(t, err)
+		assert.Equal(t, "3.1.0", doc.OpenAPI)
+	})
+
+	t.Run("should reject misspelled fields in strict mode", func(t *testing.T) {
+		_, err := spec.LoadStrict(strings.NewReader(misspelled))
+		require.ErrorIs(t, err, spec.ErrSpec)
+
+		var verr *spec.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Equal(t, "/components/schemas/Pet/properties/name/maxLenght", verr.Pointer())
+
+		assert.EqualError(t, err, strings.Join([]string{
+			`/components/schemas/Pet/properties/name/maxLenght: unknown field "maxLenght"`,
+			`/paths/~1pets/get/descripton: unknown field "descripton"`,
+			`/paths/~1pets/get/response: unknown field "response"`,
+		}, "\n"))
+	})
+
+	t.Run("should load a valid spec in strict mode", func(t *testing.T) {
+		doc, err := spec.LoadStrict(strings.NewReader(`{
+			"openapi": "3.1.0",
+			"info": {"title": "pets", "version": "1.0"},
+			"paths": {"/pets": {"get": {"x-internal": true, "responses": {"204": {"description": "none"}}}}}
+		}`))
+		require.NoError(t, err)
+		assert.Equal(t, "3.1.0", doc.OpenAPI)
+	})
+}
+
+func TestLoadFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"api/openapi.json": {Data: []byte(`{
+			"openapi": "3.1.0",
+			"info": {"title": "pets", "version": "1.0"},
+			"paths": {
+				"/pets": {
+					"get": {
+						"responses": {
+							"200": {
+								"description": "ok",
+								"content": {
+									"application/json": {"schema": {"$ref": "models/pet.json#/Pet"}}
+								}
+							}
+						}
+					}
+				}
+			}
+		}`)},
+		"api/models/pet.json": {Data: []byte(`{
+			"Pet": {
+				"type": "object",
+				"properties": {
+					"id": {"type": "integer"},
+					"tag": {"$ref": "../common/tag.json"}
+				}
+			}
+		}`)},
+		"api/common/tag.json": {Data: []byte(`{"type": "string"}`)},
+	}
+
+	t.Run("should resolve relative refs within the FS", func(t *testing.T) {
+		doc, err := spec.LoadFromFS(fsys, "api/openapi.json", nil)
+		require.NoError(t, err)
+
+		require.NotNil(t, doc.Paths)
+		pathItem := doc.Paths.Paths["/pets"]
+		require.NotNil(t, pathItem.Get)
+		resp := pathItem.Get.Responses.StatusCodeResponses[200]
+		require.Contains(t, resp.Content, "application/json")
+		schema := resp.Content["application/json"].Schema
+		require.NotNil(t, schema)
+
+		assert.Empty(t, schema.Ref.String())
+		assert.Equal(t, spec.StringOrArray{"object"}, schema.Type)
+		require.Contains(t, schema.Properties, "tag")
+		tag := schema.Properties["tag"]
+		assert.Empty(t, tag.Ref.String())
+		assert.Equal(t, spec.StringOrArray{"string"}, tag.Type)
+	})
+
+	t.Run("should fail when the root document is missing", func(t *testing.T) {
+		_, err := spec.LoadFromFS(fsys, "api/missing.json", nil)
+		require.Error(t, err)
+	})
+
+	t.Run("should fail when a referenced document is missing", func(t *testing.T) {
+		broken := fstest.MapFS{
+			"openapi.json": {Data: []byte(`{
+				"openapi": "3.1.0",
+				"components": {"schemas": {"Pet": {"$ref": "missing.json"}}}
+			}`)},
+		}
+
+		_, err := spec.LoadFromFS(broken, "openapi.json", nil)
+		require.Error(t, err)
+	})
+}
+
+func TestLoadFromFS_MixedFormats(t *testing.T) {
+	// the same spec is split over JSON and YAML files, the other way round in each fixture
+	fsys := os.DirFS(filepath.Join("fixtures", "mixed"))
+
+	fromJSON, err := spec.LoadFromFS(fsys, "json-root/openapi.json", nil)
+	require.NoError(t, err)
+	fromYAML, err := spec.LoadFromFS(fsys, "yaml-root/openapi.yaml", nil)
+	require.NoError(t, err)
+
+	expanded := asJSON(t, fromJSON)
+	assert.NotContains(t, expanded, "$ref")
+
+	t.Run("should resolve refs across formats", func(t *testing.T) {
+		schema := fromJSON.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Content["application/json"].Schema
+		require.NotNil(t, schema)
+		require.NotNil(t, schema.Items)
+		require.NotNil(t, schema.Items.Schema)
+
+		owner := schema.Items.Schema.Properties["owner"]
+		assert.Equal(t, spec.StringOrArray{"object"}, owner.Type)
+		require.Contains(t, owner.Properties, "name")
+		assert.Equal(t, spec.StringOrArray{"string"}, owner.Properties["name"].Type)
+	})
+
+	t.Run("should expand to the same spec regardless of the format of each file", func(t *testing.T) {
+		assert.JSONEq(t, expanded, asJSON(t, fromYAML))
+	})
+
+	t.Run("should load YAML refs with the default path loader", func(t *testing.T) {
+		path := filepath.Join("fixtures", "mixed", "json-root", "openapi.json")
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		var doc spec.Swagger
+		require.NoError(t, json.Unmarshal(data, &doc))
+		require.NoError(t, spec.ExpandSpec(&doc, &spec.ExpandOptions{RelativeBase: path}))
+
+		assert.JSONEq(t, expanded, asJSON(t, &doc))
+	})
+}
+
+func TestLoadFromURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/openapi.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"openapi": "3.1.0",
+			"info": {"title": "pets", "version": "1.0"},
+			"paths": {
+				"/pets": {
+					"get": {
+						"responses": {
+							"200": {
+								"description": "ok",
+								"content": {
+									"application/json": {"schema": {"$ref": "pet.json#/Pet"}}
+								}
+							}
+						}
+					}
+				}
+			}
+		}`))
+	})
+	mux.HandleFunc("/api/pet.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"Pet": {"type": "object", "properties": {"id": {"type": "integer"}}}}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("should resolve relative refs against the URL of the root document", func(t *testing.T) {
+		doc, err := spec.LoadFromURL(server.URL+"/api/openapi.json", nil)
+		require.NoError(t, err)
+
+		require.NotNil(t, doc.Paths)
+		pathItem := doc.Paths.Paths["/pets"]
+		require.NotNil(t, pathItem.Get)
+		resp := pathItem.Get.Responses.StatusCodeResponses[200]
+		schema := resp.Content["application/json"].Schema
+		require.NotNil(t, schema)
+
+		assert.Empty(t, schema.Ref.String())
+		assert.Equal(t, spec.StringOrArray{"object"}, schema.Type)
+		assert.Contains(t, schema.Properties, "id")
+	})
+
+	t.Run("should fail on a missing document", func(t *testing.T) {
+		_, err := spec.LoadFromURL(server.URL+"/api/missing.json", nil)
+		var fetchErr *spec.RemoteFetchError
+		require.ErrorAs(t, err, &fetchErr)
+	})
+
+	t.Run("should fail on a URL which is not http", func(t *testing.T) {
+		_, err := spec.LoadFromURL("file:///api/openapi.json", nil)
+		require.Error(t, err)
+	})
+}
@@ -5,6 +5,10 @@ package spec
 
 import (
 	"encoding/json"
+	"maps"
+	"mime"
+	"slices"
+	"strings"
 
 	"github.com/go-openapi/jsonpointer"
 	"github.com/go-openapi/swag/jsonutils"
@@ -88,6 +92,18 @@ func (m MediaType) JSONLookup(token string) (any, error) {
 	return r, err
 }
 
+// ExamplesOrdered returns the examples of this media type, sorted by name.
+func (m MediaType) ExamplesOrdered() []NamedExample {
+	return orderedExamples(m.Examples)
+}
+
+// Clone returns a deep copy of this media type.
+//
+// The schema, examples and encodings are copied as well. $ref's are kept as is, not resolved.
+func (m MediaType) Clone() MediaType {
+	return cloneJSON(m)
+}
+
 // MarshalJSON marshals this to JSON
 func (m MediaType) MarshalJSON() ([]byte, error) {
 	b1, err := json.Marshal(m.MediaTypeProps)
@@ -131,10 +147,38 @@ func (e Example) JSONLookup(token string) (any, error) {
 	if ex, ok := e.Extensions[token]; ok {
 		return &ex, nil
 	}
+	if token == jsonRef {
+		return &e.Ref, nil
+	}
 	r, _, err := jsonpointer.GetForToken(e.ExampleProps, token)
 	return r, err
 }
 
+// Resolve returns the example targeted by the $ref of this example, resolved against root.
+//
+// Chains of $ref's are followed, e.g. to an entry of components.examples. As allowed by OpenAPI 3.1,
+// a summary or description set next to a $ref overrides the one of its target.
+// An example without $ref is returned as is.
+func (e Example) Resolve(root any) (*Example, error) {
+	summary, description := e.Summary, e.Description
+
+	resolved, err := followRefs(e, root, func(e *Example) *Ref { return &e.Ref }, ResolveExample, func(target *Example) {
+		if summary == "" {
+			summary = target.Summary
+		}
+		if description == "" {
+			description = target.Description
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolved.Summary, resolved.Description = summary, description
+
+	return resolved, nil
+}
+
 // MarshalJSON marshals this to JSON
 func (e Example) MarshalJSON() ([]byte, error) {
 	b1, err := json.Marshal(e.Refable)
@@ -162,3 +206,64 @@ func (e *Example) UnmarshalJSON(data []byte) error {
 	}
 	return json.Unmarshal(data, &e.VendorExtensible)
 }
+
+// NamedExample is an example together with the name it is registered with.
+type NamedExample struct {
+	Name string
+	Example
+}
+
+func orderedExamples(examples map[string]Example) []NamedExample {
+	if len(examples) == 0 {
+		return nil
+	}
+
+	ordered := make([]NamedExample, 0, len(examples))
+	for _, name := range slices.Sorted(maps.Keys(examples)) {
+		ordered = append(ordered, NamedExample{Name: name, Example: examples[name]})
+	}
+
+	return ordered
+}
+
+// MatchMediaType finds the key of content which best matches a concrete media type,
+// such as the Content-Type of a request or a media type accepted by a client.
+//
+// An exact key is preferred. Otherwise, media type parameters are ignored, and the most specific
+// of "type/subtype", "type/*" and "*/*" keys is chosen. It returns false when no key matches.
+func MatchMediaType(content map[string]MediaType, mediaType string) (string, bool) {
+	if _, ok := content[mediaType]; ok {
+		return mediaType, true
+	}
+
+	wanted, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return "", false
+	}
+	mainType, _, _ := strings.Cut(wanted, "/")
+
+	var best string
+	bestScore := 0
+	for _, key := range slices.Sorted(maps.Keys(content)) {
+		candidate, _, err := mime.ParseMediaType(key)
+		if err != nil {
+			continue
+		}
+
+		var score int
+		switch candidate {
+		case wanted:
+			score = 3
+		case mainType + "/*":
+			score = 2
+		case "*/*":
+			score = 1
+		}
+
+		if score > bestScore {
+			best, bestScore = key, score
+		}
+	}
+
+	return best, bestScore > 0
+}
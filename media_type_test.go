@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestMediaType_ExamplesOrdered(t *testing.T) {
+	var media MediaType
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"schema": {"type": "object"},
+		"examples": {
+			"zebra": {"summary": "a zebra", "value": {"stripes": true}},
+			"cat": {"summary": "a cat", "description": "a **domestic** cat", "value": {"name": "Tom"}},
+			"large": {"summary": "a large payload", "externalValue": "https://example.com/examples/large.json"},
+			"shared": {"$ref": "#/components/examples/shared"}
+		}
+	}`), &media))
+
+	t.Run("should sort examples by name", func(t *testing.T) {
+		for range 10 {
+			ordered := media.ExamplesOrdered()
+			require.Len(t, ordered, 4)
+
+			names := make([]string, 0, len(ordered))
+			for _, example := range ordered {
+				names = append(names, example.Name)
+			}
+			assert.Equal(t, []string{"cat", "large", "shared", "zebra"}, names)
+		}
+	})
+
+	t.Run("should carry summaries, descriptions and external values", func(t *testing.T) {
+		ordered := media.ExamplesOrdered()
+
+		assert.Equal(t, "a cat", ordered[0].Summary)
+		assert.Equal(t, "a **domestic** cat", ordered[0].Description)
+		assert.Equal(t, map[string]any{"name": "Tom"}, ordered[0].Value)
+
+		assert.Equal(t, "a large payload", ordered[1].Summary)
+		assert.Equal(t, "https://example.com/examples/large.json", ordered[1].ExternalValue)
+		assert.Nil(t, ordered[1].Value)
+
+		assert.Equal(t, "#/components/examples/shared", ordered[2].Ref.String())
+	})
+
+	t.Run("should return nothing without examples", func(t *testing.T) {
+		assert.Empty(t, MediaType{}.ExamplesOrdered())
+	})
+}
+
+func TestMediaType_Clone(t *testing.T) {
+	var media MediaType
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"schema": {"$ref": "#/components/schemas/Pet"},
+		"examples": {"rex": {"value": {"name": "rex"}}},
+		"encoding": {"photo": {"contentType": "image/png"}},
+		"x-origin": "legacy"
+	}`), &media))
+
+	clone := media.Clone()
+	assert.Equal(t, media, clone)
+
+	require.NotNil(t, clone.Schema)
+	assert.Equal(t, "#/components/schemas/Pet", clone.Schema.Ref.String())
+	assert.NotSame(t, media.Schema, clone.Schema)
+
+	clone.Encoding["photo"] = Encoding{EncodingProps: EncodingProps{ContentType: "image/jpeg"}}
+	value, ok := clone.Examples["rex"].Value.(map[string]any)
+	require.True(t, ok)
+	value["name"] = "max"
+	clone.Schema.Ref = MustCreateRef("#/components/schemas/Dog")
+
+	assert.Equal(t, "image/png", media.Encoding["photo"].ContentType)
+	assert.Equal(t, map[string]any{"name": "rex"}, media.Examples["rex"].Value)
+	assert.Equal(t, "#/components/schemas/Pet", media.Schema.Ref.String())
+}
+
+func TestExample_Resolve(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [
+						{
+							"name": "filter",
+							"in": "query",
+							"schema": {"type": "object", "properties": {"name": {"type": "string"}}},
+							"examples": {
+								"rex": {"$ref": "#/components/examples/PetExample", "summary": "a dog"},
+								"inline": {"value": {"name": "max"}}
+							}
+						}
+					],
+					"responses": {
+						"200": {
+							"description": "ok",
+							"content": {
+								"application/json": {
+									"schema": {"type": "object"},
+									"examples": {"rex": {"$ref": "#/components/examples/PetAlias"}}
+								}
+							}
+						}
+					}
+				}
+			}
+		},
+		"components": {
+			"examples": {
+				"PetAlias": {"$ref": "#/components/examples/PetExample"},
+				"PetExample": {"summary": "a pet", "value": {"name": 12}}
+			}
+		}
+	}`), &root))
+	param := root.Paths.Paths["/pets"].Get.Parameters[0]
+
+	t.Run("should resolve an example $ref to components", func(t *testing.T) {
+		resolved, err := param.Examples["rex"].Resolve(&root)
+		require.NoError(t, err)
+
+		assert.Empty(t, resolved.Ref.String())
+		assert.Equal(t, "a dog", resolved.Summary)
+		assert.Equal(t, map[string]any{"name": float64(12)}, resolved.Value)
+	})
+
+	t.Run("should return an example without $ref as is", func(t *testing.T) {
+		resolved, err := param.Examples["inline"].Resolve(&root)
+		require.NoError(t, err)
+		assert.Equal(t, param.Examples["inline"], *resolved)
+	})
+
+	t.Run("should fail on a missing example", func(t *testing.T) {
+		example := Example{Refable: Refable{Ref: MustCreateRef("#/components/examples/Missing")}}
+
+		_, err := example.Resolve(&root)
+		var notFound *RefNotFoundError
+		require.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("should validate resolved examples", func(t *testing.T) {
+		errs := root.ValidateExamples()
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], `/paths/~1pets/get/parameters/0/examples/rex/value/name: value 12 does not match type [string]`)
+	})
+
+	t.Run("should expand example $ref's", func(t *testing.T) {
+		var expanded Swagger
+		require.NoError(t, json.Unmarshal([]byte(asJSON(t, &root)), &expanded))
+		require.NoError(t, ExpandSpec(&expanded, nil))
+
+		op := expanded.Paths.Paths["/pets"].Get
+		rex := op.Parameters[0].Examples["rex"]
+		assert.Empty(t, rex.Ref.String())
+		assert.Equal(t, "a pet", rex.Summary)
+
+		response := op.Responses.StatusCodeResponses[200]
+		rex = response.Content["application/json"].Examples["rex"]
+		assert.Empty(t, rex.Ref.String())
+		assert.Equal(t, map[string]any{"name": float64(12)}, rex.Value)
+	})
+
+	t.Run("should keep example $ref's when not targeted", func(t *testing.T) {
+		var expanded Swagger
+		require.NoError(t, json.Unmarshal([]byte(asJSON(t, &root)), &expanded))
+		require.NoError(t, ExpandSpec(&expanded, &ExpandOptions{Targets: TargetSchemas | TargetParameters}))
+
+		rex := expanded.Paths.Paths["/pets"].Get.Parameters[0].Examples["rex"]
+		assert.Equal(t, "#/components/examples/PetExample", rex.Ref.String())
+	})
+}
+
+func TestMatchMediaType(t *testing.T) {
+	var response Response
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"description": "any payload",
+		"content": {"*/*": {"schema": {"type": "string"}}}
+	}`), &response))
+
+	t.Run("should match */* when there is no exact match", func(t *testing.T) {
+		key, ok := MatchMediaType(response.Content, "application/json")
+		require.True(t, ok)
+		assert.Equal(t, "*/*", key)
+	})
+
+	content := map[string]MediaType{
+		"*/*":                       {},
+		"application/*":             {},
+		"application/json":          {},
+		"text/plain; charset=utf-8": {},
+	}
+
+	for _, tc := range []struct {
+		mediaType string
+		expected  string
+	}{
+		{mediaType: "application/json", expected: "application/json"},
+		{mediaType: "application/json; charset=utf-8", expected: "application/json"},
+		{mediaType: "application/xml", expected: "application/*"},
+		{mediaType: "text/plain; charset=utf-8", expected: "text/plain; charset=utf-8"},
+		{mediaType: "TEXT/plain", expected: "text/plain; charset=utf-8"},
+		{mediaType: "image/png", expected: "*/*"},
+	} {
+		t.Run("should prefer the most specific match for "+tc.mediaType, func(t *testing.T) {
+			key, ok := MatchMediaType(content, tc.mediaType)
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, key)
+		})
+	}
+
+	t.Run("should not match without wildcard", func(t *testing.T) {
+		_, ok := MatchMediaType(map[string]MediaType{"application/json": {}}, "application/xml")
+		assert.False(t, ok)
+
+		_, ok = MatchMediaType(map[string]MediaType{"*/*": {}}, "not a media type")
+		assert.False(t, ok)
+	})
+}
@@ -154,6 +154,12 @@ func TestNormalizer_NormalizeURI(t *testing.T) {
 				base:      "file:///base/path.json",
 				expOutput: "file:///other/another.json#/definitions/X",
 			},
+			{
+				// sibling directory, with spaces in directory names
+				refPath:   "../shared types/types.json#/components/schemas/X",
+				base:      "file:///base/Program Files (x86)/specs/v1/api.json",
+				expOutput: "file:///base/Program%20Files%20%28x86%29/specs/shared%20types/types.json#/components/schemas/X",
+			},
 			{
 				// invalid URI
 				refPath:   "\x7f\x9a",
@@ -7,7 +7,12 @@ import (
 	"bytes"
 	"encoding/gob"
 	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/go-openapi/jsonpointer"
 	"github.com/go-openapi/swag/jsonutils"
@@ -87,6 +92,9 @@ func NewOperation(id string) *Operation {
 }
 
 // SuccessResponse gets a success response model
+//
+// The response with the lowest 2xx status code is preferred, then the response for the "2XX" range,
+// which comes with a 0 status code.
 func (o *Operation) SuccessResponse() (*Response, int, bool) {
 	if o.Responses == nil {
 		return nil, 0, false
@@ -104,9 +112,337 @@ func (o *Operation) SuccessResponse() (*Response, int, bool) {
 		return &v, responseCodes[0], true
 	}
 
+	if v, ok := o.Responses.StatusRangeResponses[2]; ok {
+		return &v, 0, true
+	}
+
 	return o.Responses.Default, 0, false
 }
 
+// RequestSchema gets the schema of the request body for a given media type.
+//
+// Wildcard media types of the request body, such as "application/*", match when there is no exact match:
+// see MatchMediaType. When the request body is a $ref, it is resolved against root.
+// The returned schema is nil when the media type is declared without a schema.
+// Any $ref in the schema itself is left unresolved.
+func (o Operation) RequestSchema(mediaType string, root any) (*Schema, error) {
+	if o.RequestBody == nil {
+		return nil, fmt.Errorf("operation %q has no request body: %w", o.ID, ErrMediaTypeNotFound)
+	}
+
+	body, err := resolveRequestBody(*o.RequestBody, root)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := MatchMediaType(body.Content, mediaType)
+	if !ok {
+		return nil, fmt.Errorf("operation %q has no request body for %q: %w", o.ID, mediaType, ErrMediaTypeNotFound)
+	}
+
+	return body.Content[key].Schema, nil
+}
+
+// ValidateParameterUniqueness checks that no two parameters of the operation share the same name and location.
+//
+// Parameters defined by a $ref are resolved against root. Parameters which cannot be resolved are ignored.
+// Parameters with the same name in distinct locations, such as a query parameter and a header, are accepted.
+// Header names are compared case-insensitively. Each duplicate is reported as a *ValidationError located by a JSON pointer relative to the operation.
+func (o Operation) ValidateParameterUniqueness(root any) []error {
+	var errs []error
+
+	type paramKey struct{ name, in string }
+	seen := make(map[paramKey]int, len(o.Parameters))
+
+	for i, param := range o.Parameters {
+		resolved, err := param.Resolve(root)
+		if err != nil {
+			continue
+		}
+
+		key := paramKey{name: resolved.Name, in: resolved.In}
+		if key.in == "header" {
+			key.name = strings.ToLower(key.name)
+		}
+		if first, duplicate := seen[key]; duplicate {
+			errs = append(errs, &ValidationError{
+				Path:    pointerTo("parameters", strconv.Itoa(i)),
+				Message: fmt.Sprintf("parameter %q in %s duplicates the parameter at index %d", resolved.Name, key.in, first),
+			})
+
+			continue
+		}
+		seen[key] = i
+	}
+
+	return errs
+}
+
+// Parameter looks up a parameter of the operation by name and location, e.g. to bind a request.
+//
+// Parameters defined by a $ref are resolved against root, and the resolved parameter is returned.
+// Parameters which cannot be resolved are ignored. Header names are matched case-insensitively.
+//
+// Parameters defined at the level of the path are not known by the operation: since an operation
+// parameter overrides a path parameter with the same name and location, they should be looked up
+// with PathItem.Parameter when the operation does not define the parameter.
+func (o Operation) Parameter(name, in string, root any) (*Parameter, bool) {
+	return findParameter(o.Parameters, name, in, root)
+}
+
+func findParameter(params []Parameter, name, in string, root any) (*Parameter, bool) {
+	for _, param := range params {
+		resolved, err := param.Resolve(root)
+		if err != nil {
+			continue
+		}
+
+		if resolved.In != in {
+			continue
+		}
+
+		if resolved.Name == name || (in == "header" && strings.EqualFold(resolved.Name, name)) {
+			return resolved, true
+		}
+	}
+
+	return nil, false
+}
+
+// AcceptedMediaTypes returns the media types of the request body of the operation, sorted.
+//
+// When the request body is a $ref, it is resolved against root.
+// The result is empty when the operation has no request body.
+func (o Operation) AcceptedMediaTypes(root any) ([]string, error) {
+	if o.RequestBody == nil {
+		return nil, nil
+	}
+
+	body, err := resolveRequestBody(*o.RequestBody, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Sorted(maps.Keys(body.Content)), nil
+}
+
+// ProducedMediaTypes returns the media types of all the responses of the operation, sorted and without duplicates.
+//
+// Responses defined by a $ref are resolved against root.
+func (o Operation) ProducedMediaTypes(root any) ([]string, error) {
+	produced := make(map[string]struct{})
+	for _, response := range o.allResponses() {
+		resolved, err := response.Resolve(root)
+		if err != nil {
+			return nil, err
+		}
+
+		for mediaType := range resolved.Content {
+			produced[mediaType] = struct{}{}
+		}
+	}
+
+	return slices.Sorted(maps.Keys(produced)), nil
+}
+
+// allResponses returns the responses of the operation, including the default response, in no particular order.
+func (o Operation) allResponses() []Response {
+	if o.Responses == nil {
+		return nil
+	}
+
+	responses := slices.Collect(maps.Values(o.Responses.StatusCodeResponses))
+	responses = slices.AppendSeq(responses, maps.Values(o.Responses.StatusRangeResponses))
+	if o.Responses.Default != nil {
+		responses = append(responses, *o.Responses.Default)
+	}
+
+	return responses
+}
+
+// ValidateDeprecationPolicy reports the deprecated operations which document no "Sunset" header
+// (RFC 8594) in any of their responses.
+//
+// This is a lint rather than a validation of the spec. Header names are compared case-insensitively,
+// and responses defined by a $ref are resolved against the spec; unresolved responses are ignored.
+// Each warning is located by a JSON pointer to the operation.
+func (s *Swagger) ValidateDeprecationPolicy() []Warning {
+	var warnings []Warning
+
+	validate := func(key OperationKey, op *Operation) {
+		if !op.Deprecated || op.declaresResponseHeader("Sunset", s) {
+			return
+		}
+
+		warnings = append(warnings, Warning{
+			Path:    key.Pointer(),
+			Message: "deprecated operation documents no Sunset header in its responses",
+		})
+	}
+
+	for key, op := range s.Operations() {
+		validate(key, op)
+	}
+
+	for key, op := range s.WebhookOperations() {
+		validate(key, op)
+	}
+
+	return warnings
+}
+
+// declaresResponseHeader tells if any response of the operation declares a header, compared case-insensitively.
+func (o Operation) declaresResponseHeader(name string, root any) bool {
+	for _, response := range o.allResponses() {
+		resolved, err := response.Resolve(root)
+		if err != nil {
+			continue
+		}
+
+		for header := range resolved.Headers {
+			if strings.EqualFold(header, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// resolveRequestBody follows the chain of $ref's of a request body.
+func resolveRequestBody(body RequestBody, root any) (*RequestBody, error) {
+	return followRefs(body, root, func(body *RequestBody) *Ref { return &body.Ref }, ResolveRequestBody, nil)
+}
+
+// ApplyLegacyContentTypes converts the Swagger 2.0 produces and consumes media types of an operation
+// into the content of its request body and responses.
+//
+// Each media type gets its own copy of the schema: the schema of the body parameter or,
+// when there is none, some schema already found in the content. A body parameter is moved
+// to the request body. Likewise, the schema of a response is moved to its content.
+//
+// Existing media types are left unchanged. Request bodies and responses defined by a $ref are ignored.
+func (o *Operation) ApplyLegacyContentTypes(produces, consumes []string) {
+	if len(consumes) > 0 {
+		o.applyConsumes(consumes)
+	}
+
+	if len(produces) == 0 || o.Responses == nil {
+		return
+	}
+
+	if o.Responses.Default != nil {
+		applyProduces(o.Responses.Default, produces)
+	}
+
+	for code, response := range o.Responses.StatusCodeResponses {
+		applyProduces(&response, produces)
+		o.Responses.StatusCodeResponses[code] = response
+	}
+
+	for class, response := range o.Responses.StatusRangeResponses {
+		applyProduces(&response, produces)
+		o.Responses.StatusRangeResponses[class] = response
+	}
+}
+
+func (o *Operation) applyConsumes(consumes []string) {
+	if idx := slices.IndexFunc(o.Parameters, func(p Parameter) bool { return p.In == "body" }); idx >= 0 && o.RequestBody == nil {
+		param := o.Parameters[idx]
+		o.Parameters = slices.Delete(slices.Clone(o.Parameters), idx, idx+1)
+		o.RequestBody = &RequestBody{
+			RequestBodyProps: RequestBodyProps{
+				Description: param.Description,
+				Content:     distributeSchema(nil, param.Schema, consumes),
+				Required:    param.Required,
+			},
+		}
+
+		return
+	}
+
+	if o.RequestBody == nil || o.RequestBody.Ref.String() != "" {
+		return
+	}
+
+	o.RequestBody.Content = distributeSchema(o.RequestBody.Content, nil, consumes)
+}
+
+func applyProduces(response *Response, produces []string) {
+	if response.Ref.String() != "" {
+		return
+	}
+
+	response.Content = distributeSchema(response.Content, response.Schema, produces)
+	response.Schema = nil
+}
+
+// distributeSchema adds media types to some content, with a copy of schema.
+//
+// When schema is nil, the schema of the first media type found in the content is copied.
+// Content without any schema is left unchanged.
+func distributeSchema(content map[string]MediaType, schema *Schema, mediaTypes []string) map[string]MediaType {
+	if schema == nil {
+		for _, key := range slices.Sorted(maps.Keys(content)) {
+			if schema = content[key].Schema; schema != nil {
+				break
+			}
+		}
+	}
+
+	if schema == nil {
+		return content
+	}
+
+	distributed := maps.Clone(content)
+	if distributed == nil {
+		distributed = make(map[string]MediaType, len(mediaTypes))
+	}
+
+	for _, mediaType := range mediaTypes {
+		if _, exists := distributed[mediaType]; exists {
+			continue
+		}
+
+		distributed[mediaType] = MediaType{MediaTypeProps: MediaTypeProps{Schema: cloneSchema(schema)}}
+	}
+
+	return distributed
+}
+
+// cloneSchema returns a deep copy of a schema.
+func cloneSchema(schema *Schema) *Schema {
+	clone := cloneJSON(*schema)
+
+	return &clone
+}
+
+// cloneJSON returns a deep copy of a spec object, made by a JSON round trip.
+//
+// $ref's are copied, not resolved. When the value cannot be serialized, a shallow copy is returned.
+func cloneJSON[T any](value T) T {
+	clone, err := deepCloneJSON(value)
+	if err != nil {
+		return value
+	}
+
+	return clone
+}
+
+// deepCloneJSON is like cloneJSON, but fails when the value cannot be serialized.
+func deepCloneJSON[T any](value T) (T, error) {
+	var clone T
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return clone, err
+	}
+
+	err = json.Unmarshal(data, &clone)
+
+	return clone, err
+}
+
 // JSONLookup look up a value by the json property name
 func (o Operation) JSONLookup(token string) (any, error) {
 	if ex, ok := o.Extensions[token]; ok {
@@ -208,6 +544,20 @@ func (o *Operation) WithTags(tags ...string) *Operation {
 	return o
 }
 
+// AddTag appends a tag to this operation.
+//
+// Tags are kept in order and are not deduplicated: see Swagger.ValidateTags to find duplicates.
+func (o *Operation) AddTag(tag string) *Operation {
+	o.Tags = append(o.Tags, tag)
+	return o
+}
+
+// SetTags replaces the tags of this operation
+func (o *Operation) SetTags(tags ...string) *Operation {
+	o.Tags = append([]string(nil), tags...)
+	return o
+}
+
 // AddParam adds a parameter to this operation, when a parameter for that location
 // and with that name already exists it will be replaced
 func (o *Operation) AddParam(param *Parameter) *Operation {
@@ -248,6 +598,17 @@ func (o *Operation) SecuredWith(name string, scopes ...string) *Operation {
 	return o
 }
 
+// WithSecurity adds security requirements to this operation.
+//
+// Any of the requirements must be satisfied to authorize a request.
+// An empty requirement makes security optional.
+func (o *Operation) WithSecurity(reqs ...SecurityRequirement) *Operation {
+	for _, req := range reqs {
+		o.Security = append(o.Security, req)
+	}
+	return o
+}
+
 // WithDefaultResponse adds a default response to the operation.
 // Passing a nil value will remove the response
 func (o *Operation) WithDefaultResponse(response *Response) *Operation {
@@ -330,3 +330,334 @@ func doTestAnyGobEncoding(t *testing.T, src, dst any) {
 	require.NoError(t, err)
 	assert.JSONEq(t, string(expectedJSON), string(jazon))
 }
+
+func TestOperation_RequestSchema(t *testing.T) {
+	root := new(Swagger)
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"paths": {
+			"/pets": {
+				"post": {
+					"operationId": "addPet",
+					"requestBody": {"$ref": "#/components/requestBodies/petAlias"}
+				},
+				"put": {
+					"operationId": "updatePet",
+					"requestBody": {
+						"content": {"application/octet-stream": {}}
+					}
+				},
+				"delete": {
+					"operationId": "deletePet"
+				}
+			}
+		},
+		"components": {
+			"requestBodies": {
+				"petAlias": {"$ref": "#/components/requestBodies/pet"},
+				"pet": {
+					"required": true,
+					"content": {
+						"application/json": {"schema": {"$ref": "#/components/schemas/pet"}},
+						"application/xml": {"schema": {"type": "object"}}
+					}
+				}
+			}
+		}
+	}`), root))
+	pathItem := root.Paths.Paths["/pets"]
+
+	t.Run("should resolve a request body $ref", func(t *testing.T) {
+		schema, err := pathItem.Post.RequestSchema("application/json", root)
+		require.NoError(t, err)
+		require.NotNil(t, schema)
+		assert.Equal(t, "#/components/schemas/pet", schema.Ref.String())
+
+		schema, err = pathItem.Post.RequestSchema("application/xml", root)
+		require.NoError(t, err)
+		require.NotNil(t, schema)
+		assert.Equal(t, StringOrArray{"object"}, schema.Type)
+	})
+
+	t.Run("should return a nil schema for a media type without schema", func(t *testing.T) {
+		schema, err := pathItem.Put.RequestSchema("application/octet-stream", root)
+		require.NoError(t, err)
+		assert.Nil(t, schema)
+	})
+
+	t.Run("should match a wildcard media type", func(t *testing.T) {
+		op := Operation{OperationProps: OperationProps{
+			RequestBody: &RequestBody{RequestBodyProps: RequestBodyProps{Content: map[string]MediaType{
+				"image/*": {MediaTypeProps: MediaTypeProps{Schema: &Schema{SchemaProps: SchemaProps{Format: "binary"}}}},
+			}}},
+		}}
+
+		schema, err := op.RequestSchema("image/png", root)
+		require.NoError(t, err)
+		require.NotNil(t, schema)
+		assert.Equal(t, "binary", schema.Format)
+	})
+
+	t.Run("should fail on an unknown media type", func(t *testing.T) {
+		_, err := pathItem.Post.RequestSchema("text/plain", root)
+		require.ErrorIs(t, err, ErrMediaTypeNotFound)
+	})
+
+	t.Run("should fail without a request body", func(t *testing.T) {
+		_, err := pathItem.Delete.RequestSchema("application/json", root)
+		require.ErrorIs(t, err, ErrMediaTypeNotFound)
+	})
+
+	t.Run("should fail on an unresolved $ref", func(t *testing.T) {
+		op := Operation{OperationProps: OperationProps{
+			RequestBody: &RequestBody{Refable: Refable{Ref: MustCreateRef("#/components/requestBodies/missing")}},
+		}}
+
+		_, err := op.RequestSchema("application/json", root)
+		var notFound *RefNotFoundError
+		require.ErrorAs(t, err, &notFound)
+	})
+}
+
+func TestOperation_ValidateParameterUniqueness(t *testing.T) {
+	root := &Swagger{SwaggerProps: SwaggerProps{
+		Components: &Components{ComponentsProps: ComponentsProps{
+			Parameters: map[string]Parameter{"limit": *QueryParam("limit")},
+		}},
+	}}
+
+	t.Run("should accept the same name in distinct locations", func(t *testing.T) {
+		op := new(Operation)
+		op.Parameters = []Parameter{*QueryParam("limit"), *HeaderParam("limit")}
+
+		assert.Empty(t, op.ValidateParameterUniqueness(root))
+	})
+
+	t.Run("should report duplicate parameters, after resolving $ref's", func(t *testing.T) {
+		op := new(Operation)
+		op.Parameters = []Parameter{
+			*QueryParam("limit"),
+			*HeaderParam("limit"),
+			*ParamRef("#/components/parameters/limit"),
+			*ParamRef("#/components/parameters/missing"),
+		}
+
+		errs := op.ValidateParameterUniqueness(root)
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], ErrSpec)
+		assert.EqualError(t, errs[0], `/parameters/2: parameter "limit" in query duplicates the parameter at index 0`)
+	})
+
+	t.Run("should compare header names case-insensitively", func(t *testing.T) {
+		op := new(Operation)
+		op.Parameters = []Parameter{
+			*HeaderParam("X-Request-ID"),
+			*HeaderParam("x-request-id"),
+			*QueryParam("Limit"),
+			*QueryParam("limit"),
+		}
+
+		errs := op.ValidateParameterUniqueness(root)
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], `/parameters/1: parameter "x-request-id" in header duplicates the parameter at index 0`)
+	})
+}
+
+func TestOperation_MediaTypes(t *testing.T) {
+	root := new(Swagger)
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"paths": {
+			"/pets": {
+				"post": {
+					"requestBody": {"$ref": "#/components/requestBodies/pet"},
+					"responses": {
+						"200": {"description": "ok", "content": {"application/json": {}, "application/xml": {}}},
+						"404": {"$ref": "#/components/responses/error"},
+						"default": {"description": "error", "content": {"text/plain": {}, "application/json": {}}}
+					}
+				},
+				"delete": {
+					"responses": {"204": {"description": "no content"}}
+				}
+			}
+		},
+		"components": {
+			"requestBodies": {
+				"pet": {"content": {"application/xml": {}, "application/json": {}}}
+			},
+			"responses": {
+				"error": {"description": "error", "content": {"application/problem+json": {}}}
+			}
+		}
+	}`), root))
+	pathItem := root.Paths.Paths["/pets"]
+
+	t.Run("should list the media types of the request body", func(t *testing.T) {
+		accepted, err := pathItem.Post.AcceptedMediaTypes(root)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"application/json", "application/xml"}, accepted)
+
+		accepted, err = pathItem.Delete.AcceptedMediaTypes(root)
+		require.NoError(t, err)
+		assert.Empty(t, accepted)
+	})
+
+	t.Run("should list the media types of all responses", func(t *testing.T) {
+		produced, err := pathItem.Post.ProducedMediaTypes(root)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"application/json", "application/problem+json", "application/xml", "text/plain"}, produced)
+
+		produced, err = pathItem.Delete.ProducedMediaTypes(root)
+		require.NoError(t, err)
+		assert.Empty(t, produced)
+	})
+
+	t.Run("should fail on an unresolved $ref", func(t *testing.T) {
+		op := new(Operation).RespondsWith(200, ResponseRef("#/components/responses/missing"))
+
+		_, err := op.ProducedMediaTypes(root)
+		var notFound *RefNotFoundError
+		require.ErrorAs(t, err, &notFound)
+	})
+}
+
+func TestOperation_ApplyLegacyContentTypes(t *testing.T) {
+	var op Operation
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"parameters": [
+			{"name": "limit", "in": "query", "type": "integer"},
+			{"name": "pet", "in": "body", "required": true, "description": "the pet", "schema": {"$ref": "#/definitions/pet"}}
+		],
+		"responses": {
+			"200": {"description": "ok", "schema": {"type": "array", "items": {"$ref": "#/definitions/pet"}}},
+			"204": {"description": "no content"},
+			"default": {
+				"description": "error",
+				"content": {"application/problem+json": {"schema": {"$ref": "#/definitions/error"}}}
+			}
+		}
+	}`), &op))
+
+	op.ApplyLegacyContentTypes([]string{"application/json", "application/xml"}, []string{"application/json"})
+
+	t.Run("should move the body parameter to the request body", func(t *testing.T) {
+		require.Len(t, op.Parameters, 1)
+		assert.Equal(t, "limit", op.Parameters[0].Name)
+
+		require.NotNil(t, op.RequestBody)
+		assert.True(t, op.RequestBody.Required)
+		assert.Equal(t, "the pet", op.RequestBody.Description)
+		require.Contains(t, op.RequestBody.Content, "application/json")
+		assert.Equal(t, "#/definitions/pet", op.RequestBody.Content["application/json"].Schema.Ref.String())
+	})
+
+	t.Run("should create a response content entry for each produced media type", func(t *testing.T) {
+		ok := op.Responses.StatusCodeResponses[200]
+		assert.Nil(t, ok.Schema)
+		require.Len(t, ok.Content, 2)
+
+		jsonSchema := ok.Content["application/json"].Schema
+		xmlSchema := ok.Content["application/xml"].Schema
+		require.NotNil(t, jsonSchema)
+		require.NotNil(t, xmlSchema)
+		assert.Equal(t, jsonSchema, xmlSchema)
+
+		jsonSchema.Items.Schema.Ref = MustCreateRef("#/definitions/other")
+		assert.Equal(t, "#/definitions/pet", xmlSchema.Items.Schema.Ref.String(), "each media type should get its own schema")
+	})
+
+	t.Run("should copy the schema of existing content", func(t *testing.T) {
+		require.Len(t, op.Responses.Default.Content, 3)
+		assert.Equal(t, "#/definitions/error", op.Responses.Default.Content["application/xml"].Schema.Ref.String())
+	})
+
+	t.Run("should leave responses without schema alone", func(t *testing.T) {
+		assert.Empty(t, op.Responses.StatusCodeResponses[204].Content)
+	})
+}
+
+func TestSwagger_ValidateDeprecationPolicy(t *testing.T) {
+	sunset := NewResponse().WithDescription("ok").AddHeader("sunset", ResponseHeader().Typed("string", ""))
+	doc := &Swagger{SwaggerProps: SwaggerProps{
+		Paths: &Paths{Paths: map[string]PathItem{
+			"/pets": {PathItemProps: PathItemProps{
+				Get:    new(Operation).Deprecate().RespondsWith(200, NewResponse().WithDescription("ok")),
+				Post:   new(Operation).Deprecate().RespondsWith(201, sunset),
+				Delete: new(Operation).RespondsWith(204, NewResponse()),
+			}},
+			"/owners": {PathItemProps: PathItemProps{
+				Get: new(Operation).Deprecate().WithDefaultResponse(ResponseRef("#/components/responses/Sunset")),
+			}},
+		}},
+		Components: &Components{ComponentsProps: ComponentsProps{Responses: map[string]Response{"Sunset": *sunset}}},
+	}}
+
+	warnings := doc.ValidateDeprecationPolicy()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "/paths/~1pets/get: deprecated operation documents no Sunset header in its responses", warnings[0].String())
+}
+
+func TestOperation_Parameter(t *testing.T) {
+	root := new(Swagger)
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"parameters": {
+				"petId": {"name": "petId", "in": "path", "required": true, "schema": {"type": "integer"}},
+				"trace": {"name": "X-Trace-Id", "in": "header", "schema": {"type": "string"}}
+			}
+		},
+		"paths": {
+			"/pets/{petId}": {
+				"parameters": [{"name": "verbose", "in": "query", "schema": {"type": "boolean"}}],
+				"get": {
+					"parameters": [
+						{"$ref": "#/components/parameters/petId"},
+						{"$ref": "#/components/parameters/trace"},
+						{"$ref": "#/components/parameters/missing"},
+						{"name": "petId", "in": "query", "schema": {"type": "string"}}
+					]
+				}
+			}
+		}
+	}`), root))
+
+	item := root.Paths.Paths["/pets/{petId}"]
+	op := item.Get
+
+	t.Run("should resolve a parameter defined as a $ref", func(t *testing.T) {
+		param, ok := op.Parameter("petId", "path", root)
+		require.True(t, ok)
+		require.NotNil(t, param)
+		assert.Empty(t, param.Ref.String())
+		assert.Equal(t, "petId", param.Name)
+		assert.True(t, param.Required)
+		require.NotNil(t, param.Schema)
+		assert.Equal(t, StringOrArray{"integer"}, param.Schema.Type)
+	})
+
+	t.Run("should match the location", func(t *testing.T) {
+		param, ok := op.Parameter("petId", "query", root)
+		require.True(t, ok)
+		assert.Equal(t, StringOrArray{"string"}, param.Schema.Type)
+
+		_, ok = op.Parameter("petId", "header", root)
+		assert.False(t, ok)
+	})
+
+	t.Run("should match header names case-insensitively", func(t *testing.T) {
+		param, ok := op.Parameter("x-trace-id", "header", root)
+		require.True(t, ok)
+		assert.Equal(t, "X-Trace-Id", param.Name)
+	})
+
+	t.Run("should look up path level parameters", func(t *testing.T) {
+		_, ok := op.Parameter("verbose", "query", root)
+		assert.False(t, ok)
+
+		param, ok := item.Parameter("verbose", "query", root)
+		require.True(t, ok)
+		assert.Equal(t, "verbose", param.Name)
+	})
+}
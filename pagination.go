@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import "cmp"
+
+// PaginationStyle tells how the clients of a paginated list request the next page.
+type PaginationStyle int
+
+const (
+	// CursorPagination passes an opaque cursor to the next page
+	CursorPagination PaginationStyle = iota
+	// OffsetPagination passes the position of the first item of the page, and the size of the page
+	OffsetPagination
+)
+
+// PaginationOptions describes the envelope of a paginated list response.
+//
+// Empty field names get their default.
+type PaginationOptions struct {
+	Style       PaginationStyle // the kind of pagination, cursor-based by default
+	ItemsField  string          // the property holding the items of the page, "data" by default
+	CursorField string          // with cursor pagination, the property holding the cursor of the next page, "nextCursor" by default
+	OffsetField string          // with offset pagination, the property holding the offset of the page, "offset" by default
+	LimitField  string          // with offset pagination, the property holding the size of the page, "limit" by default
+	TotalField  string          // the property holding the total count of items, "total" by default
+	OmitTotal   bool            // when the total count of items is not known, e.g. for large collections
+}
+
+// PaginatedResponseSchema creates the schema of a page of items, e.g. { "data": [items], "nextCursor": "...", "total": 42 }.
+//
+// Only the items are required: the cursor of the next page is missing from the last page.
+func PaginatedResponseSchema(itemSchema *Schema, opts PaginationOptions) *Schema {
+	itemsField := cmp.Or(opts.ItemsField, "data")
+
+	schema := new(Schema).Typed("object", "").WithRequired(itemsField)
+	schema.SetProperty(itemsField, *ArrayProperty(itemSchema))
+
+	switch opts.Style {
+	case OffsetPagination:
+		schema.SetProperty(cmp.Or(opts.OffsetField, "offset"),
+			*Int64Property().WithMinimum(0, false).WithDescription("the position of the first item of the page"))
+		schema.SetProperty(cmp.Or(opts.LimitField, "limit"),
+			*Int64Property().WithMinimum(1, false).WithDescription("the maximum number of items of the page"))
+	default:
+		schema.SetProperty(cmp.Or(opts.CursorField, "nextCursor"),
+			*StringProperty().WithDescription("the cursor of the next page, missing from the last page"))
+	}
+
+	if !opts.OmitTotal {
+		schema.SetProperty(cmp.Or(opts.TotalField, "total"),
+			*Int64Property().WithMinimum(0, false).WithDescription("the total number of items"))
+	}
+
+	return schema
+}
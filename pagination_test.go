@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestPaginatedResponseSchema(t *testing.T) {
+	pet := RefSchema("#/components/schemas/Pet")
+
+	t.Run("should build a cursor envelope with default field names", func(t *testing.T) {
+		schema := PaginatedResponseSchema(pet, PaginationOptions{})
+
+		assert.Equal(t, StringOrArray{"object"}, schema.Type)
+		assert.Equal(t, []string{"data"}, schema.Required)
+		require.Contains(t, schema.Properties, "data")
+		assert.Equal(t, StringOrArray{"array"}, schema.Properties["data"].Type)
+		assert.Equal(t, pet, schema.Properties["data"].Items.Schema)
+		assert.Equal(t, StringOrArray{"string"}, schema.Properties["nextCursor"].Type)
+		assert.Equal(t, StringOrArray{"integer"}, schema.Properties["total"].Type)
+		assert.Len(t, schema.Properties, 3)
+	})
+
+	t.Run("should build an offset envelope with custom field names", func(t *testing.T) {
+		schema := PaginatedResponseSchema(pet, PaginationOptions{
+			Style:       OffsetPagination,
+			ItemsField:  "pets",
+			OffsetField: "start",
+			OmitTotal:   true,
+		})
+
+		assertSerializeJSON(t, schema, `{"type":"object","required":["pets"],"properties":{`+
+			`"limit":{"description":"the maximum number of items of the page","type":"integer","format":"int64","minimum":1},`+
+			`"pets":{"type":"array","items":{"$ref":"#/components/schemas/Pet"}},`+
+			`"start":{"description":"the position of the first item of the page","type":"integer","format":"int64","minimum":0}}}`)
+	})
+}
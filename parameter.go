@@ -57,6 +57,20 @@ func SimpleArrayParam(name, tpe, fmt string) *Parameter {
 			Items: &Items{SimpleSchema: SimpleSchema{Type: tpe, Format: fmt}}}}
 }
 
+// ArrayQueryParam creates a query parameter for an array of simple values, with a form style.
+//
+// When explode is true, each value is serialized as its own parameter (e.g. "id=1&id=2"),
+// otherwise the values are joined by commas (e.g. "id=1,2").
+func ArrayQueryParam(name, itemType, itemFormat string, explode bool) *Parameter {
+	return &Parameter{ParamProps: ParamProps{
+		Name:    name,
+		In:      "query",
+		Style:   "form",
+		Explode: &explode,
+		Schema:  ArrayProperty(new(Schema).Typed(itemType, itemFormat)),
+	}}
+}
+
 // ParamRef creates a parameter that's a json reference
 func ParamRef(uri string) *Parameter {
 	p := new(Parameter)
@@ -71,6 +85,7 @@ func ParamRef(uri string) *Parameter {
 // - body and formData are replaced by requestBody
 // - Schema is now used for all parameter types (not just body)
 // - AllowEmptyValue is allowed where "in" == "query"
+// - the "example" of a parameter is held by its SimpleSchema, so that each JSON key belongs to a single embedded struct
 type ParamProps struct {
 	Name            string  `json:"name,omitempty"`
 	In              string  `json:"in,omitempty"`
@@ -82,7 +97,6 @@ type ParamProps struct {
 	Explode         *bool   `json:"explode,omitempty"`
 	AllowReserved   bool    `json:"allowReserved,omitempty"`
 	Schema          *Schema `json:"schema,omitempty"`
-	Example         any     `json:"example,omitempty"`
 	Examples        map[string]Example `json:"examples,omitempty"`
 	Content         map[string]MediaType `json:"content,omitempty"`
 }
@@ -133,6 +147,59 @@ func (p Parameter) JSONLookup(token string) (any, error) {
 	return r, err
 }
 
+// ExamplesOrdered returns the examples of this parameter, sorted by name.
+func (p Parameter) ExamplesOrdered() []NamedExample {
+	return orderedExamples(p.Examples)
+}
+
+// Resolve returns the parameter targeted by the $ref of this parameter, resolved against root.
+//
+// Chains of $ref's are followed. As allowed by OpenAPI 3.1, a description set next to a $ref
+// overrides the description of its target. A parameter without $ref is returned as is.
+func (p Parameter) Resolve(root any) (*Parameter, error) {
+	description := p.Description
+
+	resolved, err := followRefs(p, root, func(p *Parameter) *Ref { return &p.Ref }, ResolveParameter, func(target *Parameter) {
+		if description == "" {
+			description = target.Description
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolved.Description = description
+
+	return resolved, nil
+}
+
+// EqualSemantic tells if two parameters are equivalent once the defaults defined by OpenAPI 3.x are applied.
+//
+// Unlike a plain comparison, a parameter relying on a default, such as the "form" style of query parameters
+// or the explode behavior of this style, is equal to a parameter setting the same value explicitly.
+func (p Parameter) EqualSemantic(other Parameter) bool {
+	return enumKey(p.withDefaults()) == enumKey(other.withDefaults())
+}
+
+// withDefaults sets the style and explode defaults of the parameter explicitly.
+func (p Parameter) withDefaults() Parameter {
+	if p.Style == "" {
+		switch p.In {
+		case "query", "cookie":
+			p.Style = "form"
+		case "path", "header":
+			p.Style = "simple"
+		}
+	}
+
+	if p.Explode == nil {
+		explode := p.Style == "form"
+		p.Explode = &explode
+	}
+
+	return p
+}
+
 // WithDescription a fluent builder method for the description of the parameter
 func (p *Parameter) WithDescription(description string) *Parameter {
 	p.Description = description
@@ -158,6 +225,12 @@ func (p *Parameter) Typed(tpe, format string) *Parameter {
 	return p
 }
 
+// WithFormat a fluent builder method for the format of the parameter value, leaving its type unchanged
+func (p *Parameter) WithFormat(format string) *Parameter {
+	p.Format = format
+	return p
+}
+
 // CollectionOf a fluent builder method for an array parameter
 func (p *Parameter) CollectionOf(items *Items, format string) *Parameter {
 	p.Type = jsonArray
@@ -200,6 +273,32 @@ func (p *Parameter) AsRequired() *Parameter {
 	return p
 }
 
+// IsEffectivelyRequired tells if a value must be supplied for this parameter.
+//
+// A parameter with a default value is optional, even when flagged as required:
+// the default is then set either on the parameter or, for OpenAPI 3.x, on its schema.
+func (p Parameter) IsEffectivelyRequired() bool {
+	_, hasDefault := p.EffectiveDefault()
+
+	return p.Required && !hasDefault
+}
+
+// EffectiveDefault returns the default value of this parameter.
+//
+// The default set on the parameter takes precedence over the default of its schema.
+// It returns false when no default is set.
+func (p Parameter) EffectiveDefault() (any, bool) {
+	if p.Default != nil {
+		return p.Default, true
+	}
+
+	if p.Schema != nil && p.Schema.Default != nil {
+		return p.Schema.Default, true
+	}
+
+	return nil, false
+}
+
 // WithMaxLength sets a max length value
 func (p *Parameter) WithMaxLength(maximum int64) *Parameter {
 	p.MaxLength = &maximum
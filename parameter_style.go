@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Serialize returns the raw values of a parameter, in the form expected by ParseAndValidate.
+//
+// Arrays are joined according to the style and explode properties of the parameter, e.g. ".1.2" for an
+// exploded label parameter, or ";id=1;id=2" for an exploded matrix parameter. Exploded form parameters
+// return a value for each item. Values of the label and matrix styles are prefixed, e.g. "." or ";name=".
+//
+// Objects are not supported.
+func (p Parameter) Serialize(value any) ([]string, error) {
+	param := p.withDefaults()
+
+	items, isArray := value.([]any)
+	if !isArray {
+		raw, err := serializedScalar(value)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q in %s: %w", param.Name, param.In, err)
+		}
+
+		return []string{param.prefixed(raw)}, nil
+	}
+
+	raws := make([]string, 0, len(items))
+	for _, item := range items {
+		raw, err := serializedScalar(item)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q in %s: %w", param.Name, param.In, err)
+		}
+		raws = append(raws, raw)
+	}
+
+	if param.Style == "form" && *param.Explode {
+		return raws, nil
+	}
+
+	return []string{param.prefixed(strings.Join(raws, param.delimiter()))}, nil
+}
+
+// splitArray returns the items of an array parameter.
+//
+// Exploded form parameters repeat the parameter for each item. Otherwise, the items are joined by a delimiter
+// which depends on the style of the parameter, e.g. "." for exploded label parameters or ";name=" for exploded
+// matrix parameters.
+func (p Parameter) splitArray(values []string) ([]string, error) {
+	param := p.withDefaults()
+	if param.Style == "form" && *param.Explode {
+		return values, nil
+	}
+
+	value, err := param.unprefixed(values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if value == "" {
+		return []string{}, nil
+	}
+
+	return strings.Split(value, param.delimiter()), nil
+}
+
+// delimiter returns the delimiter of the items of an array parameter which is not an exploded form parameter.
+func (p Parameter) delimiter() string {
+	switch {
+	case p.Style == "spaceDelimited":
+		return " "
+	case p.Style == "pipeDelimited":
+		return "|"
+	case p.Style == "label" && *p.Explode:
+		return "."
+	case p.Style == "matrix" && *p.Explode:
+		return ";" + p.Name + "="
+	default:
+		return ","
+	}
+}
+
+// prefixed adds the prefix of the label and matrix styles to a raw value, e.g. "." or ";name=".
+func (p Parameter) prefixed(value string) string {
+	switch p.Style {
+	case "label":
+		return "." + value
+	case "matrix":
+		if value == "" {
+			return ";" + p.Name
+		}
+
+		return ";" + p.Name + "=" + value
+	default:
+		return value
+	}
+}
+
+// unprefixed removes the prefix of the label and matrix styles from a raw value, e.g. "." or ";name=".
+func (p Parameter) unprefixed(value string) (string, error) {
+	var prefix string
+	switch p.Style {
+	case "label":
+		prefix = "."
+	case "matrix":
+		if value == ";"+p.Name {
+			// an empty value
+			return "", nil
+		}
+		prefix = ";" + p.Name + "="
+	default:
+		return value, nil
+	}
+
+	unprefixed, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return "", fmt.Errorf("value %q does not start with %q, as required by the %s style", value, prefix, p.Style)
+	}
+
+	return unprefixed, nil
+}
+
+// serializedScalar converts a JSON scalar to a raw value.
+func serializedScalar(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	default:
+		return "", fmt.Errorf("value of type %T is not a scalar: %w", value, errors.ErrUnsupported)
+	}
+}
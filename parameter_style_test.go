@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestParameter_Styles(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1.0"},
+		"components": {
+			"schemas": {
+				"Id": {"type": "integer", "minimum": 1},
+				"Ids": {"type": "array", "items": {"$ref": "#/components/schemas/Id"}, "maxItems": 2}
+			}
+		}
+	}`), &root))
+
+	t.Run("should remove the prefix of the label style", func(t *testing.T) {
+		label := Parameter{ParamProps: ParamProps{
+			Name: "petId", In: "path", Required: true, Style: "label", Schema: RefSchema("#/components/schemas/Id"),
+		}}
+
+		value, err := label.ParseAndValidate([]string{".5"}, root)
+		require.NoError(t, err)
+		assert.Equal(t, float64(5), value)
+
+		_, err = label.ParseAndValidate([]string{"5"}, root)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `does not start with "."`)
+
+		label.Schema = RefSchema("#/components/schemas/Ids")
+		value, err = label.ParseAndValidate([]string{".1,2"}, root)
+		require.NoError(t, err)
+		assert.Equal(t, []any{float64(1), float64(2)}, value)
+
+		explode := true
+		label.Explode = &explode
+		value, err = label.ParseAndValidate([]string{".1.2"}, root)
+		require.NoError(t, err)
+		assert.Equal(t, []any{float64(1), float64(2)}, value)
+	})
+
+	t.Run("should remove the prefix of the matrix style", func(t *testing.T) {
+		matrix := Parameter{ParamProps: ParamProps{
+			Name: "id", In: "path", Required: true, Style: "matrix", Schema: RefSchema("#/components/schemas/Id"),
+		}}
+
+		value, err := matrix.ParseAndValidate([]string{";id=1"}, root)
+		require.NoError(t, err)
+		assert.Equal(t, float64(1), value)
+
+		_, err = matrix.ParseAndValidate([]string{";other=1"}, root)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `does not start with ";id="`)
+
+		matrix.Schema = RefSchema("#/components/schemas/Ids")
+		value, err = matrix.ParseAndValidate([]string{";id=1,2"}, root)
+		require.NoError(t, err)
+		assert.Equal(t, []any{float64(1), float64(2)}, value)
+
+		explode := true
+		matrix.Explode = &explode
+		value, err = matrix.ParseAndValidate([]string{";id=1;id=2"}, root)
+		require.NoError(t, err)
+		assert.Equal(t, []any{float64(1), float64(2)}, value)
+	})
+
+	t.Run("should serialize an array path parameter with the label and matrix styles", func(t *testing.T) {
+		for _, tc := range []struct {
+			style   string
+			explode bool
+			raw     string
+		}{
+			{style: "label", raw: ".1,2"},
+			{style: "label", explode: true, raw: ".1.2"},
+			{style: "matrix", raw: ";ids=1,2"},
+			{style: "matrix", explode: true, raw: ";ids=1;ids=2"},
+			{style: "simple", raw: "1,2"},
+		} {
+			t.Run(tc.raw, func(t *testing.T) {
+				explode := tc.explode
+				param := Parameter{ParamProps: ParamProps{
+					Name: "ids", In: "path", Required: true, Style: tc.style, Explode: &explode,
+					Schema: RefSchema("#/components/schemas/Ids"),
+				}}
+
+				values, err := param.Serialize([]any{float64(1), float64(2)})
+				require.NoError(t, err)
+				assert.Equal(t, []string{tc.raw}, values)
+
+				value, err := param.ParseAndValidate(values, root)
+				require.NoError(t, err)
+				assert.Equal(t, []any{float64(1), float64(2)}, value)
+			})
+		}
+	})
+
+	t.Run("should serialize empty values and scalars", func(t *testing.T) {
+		matrix := Parameter{ParamProps: ParamProps{Name: "id", In: "path", Style: "matrix"}}
+		values, err := matrix.Serialize("")
+		require.NoError(t, err)
+		assert.Equal(t, []string{";id"}, values)
+
+		values, err = matrix.Serialize(float64(5))
+		require.NoError(t, err)
+		assert.Equal(t, []string{";id=5"}, values)
+
+		label := Parameter{ParamProps: ParamProps{Name: "id", In: "path", Style: "label"}}
+		values, err = label.Serialize([]any{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"."}, values)
+
+		query := Parameter{ParamProps: ParamProps{Name: "ids", In: "query"}}
+		values, err = query.Serialize([]any{"a", true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "true"}, values)
+
+		_, err = query.Serialize(map[string]any{"a": 1})
+		require.ErrorIs(t, err, errors.ErrUnsupported)
+	})
+}
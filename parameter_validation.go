@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseAndValidate parses the raw values received for a parameter, then validates the parsed value against
+// the schema of the parameter.
+//
+// values are the values found in a request: all the occurrences of a query parameter, or the single value of
+// a path, header or cookie parameter. The prefixes of the label and matrix styles are removed, arrays are split
+// according to the style and explode properties of the parameter, and strings are converted to the type of
+// the schema, or of its items. Parameters without style are serialized as defined by OpenAPI, e.g. query
+// parameters with the form style, exploded.
+//
+// Objects are only supported with the deepObject style: values are then the "name[property]=value" pairs
+// of the query, and properties are converted to the type of their schema.
+//
+// $ref's are resolved against root, both for the parameter and for its schema: a parameter with a schema
+// referencing a component is validated against this component, but its own fields, such as required, still apply.
+// Without values, a parameter gets its default value, or the default of its schema, e.g. the default object of
+// a deepObject parameter, with the same types as parsed values. Without default, a required parameter is
+// rejected.
+//
+// Parameters defined by content rather than by a schema are not supported. The problems of the value are
+// reported as *ValidationError's aggregated by a *MultiError.
+func (p Parameter) ParseAndValidate(values []string, root any) (any, error) {
+	param, err := p.Resolve(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) == 0 {
+		return param.defaultValue(root)
+	}
+
+	if param.Schema == nil {
+		return nil, fmt.Errorf("parameter %q in %s has no schema: %w", param.Name, param.In, errors.ErrUnsupported)
+	}
+
+	resolved, err := resolveMember(*param.Schema, root, make(map[string]struct{}))
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := param.parse(values, *resolved, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, NewMultiError(param.Schema.Validate(value, root)...)
+}
+
+// defaultValue returns the value of an absent parameter: its default, or the default of its resolved schema.
+func (p Parameter) defaultValue(root any) (any, error) {
+	value, hasDefault := p.EffectiveDefault()
+	if !hasDefault && p.Schema != nil {
+		resolved, err := resolveMember(*p.Schema, root, make(map[string]struct{}))
+		if err != nil {
+			return nil, err
+		}
+		value, hasDefault = resolved.Default, resolved.Default != nil
+	}
+
+	if !hasDefault && p.Required {
+		return nil, NewMultiError(&ValidationError{Message: fmt.Sprintf("parameter %q in %s is required", p.Name, p.In)})
+	}
+
+	return normalizedInstance(value), nil
+}
+
+// parse converts the raw values of a parameter to the type of its resolved schema.
+func (p Parameter) parse(values []string, schema Schema, root any) (any, error) {
+	switch exampleType(schema) {
+	case "array":
+		items, err := p.splitArray(values)
+		if err != nil {
+			return nil, NewMultiError(&ValidationError{Message: err.Error()})
+		}
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return parsedStrings(items), nil
+		}
+
+		itemSchema, err := resolveMember(*schema.Items.Schema, root, make(map[string]struct{}))
+		if err != nil {
+			return nil, err
+		}
+
+		parsed := make([]any, 0, len(items))
+		var errs []error
+		for i, item := range items {
+			value, err := parseScalar(item, exampleType(*itemSchema))
+			if err != nil {
+				errs = append(errs, &ValidationError{Path: pointerTo(strconv.Itoa(i)), Message: err.Error()})
+			}
+			parsed = append(parsed, value)
+		}
+
+		return parsed, NewMultiError(errs...)
+	case "object":
+		if p.withDefaults().Style != "deepObject" {
+			return nil, fmt.Errorf("parameter %q in %s is an object: %w", p.Name, p.In, errors.ErrUnsupported)
+		}
+
+		return p.parseDeepObject(values, schema, root)
+	default:
+		raw, err := p.unprefixed(values[0])
+		if err == nil {
+			var value any
+			if value, err = parseScalar(raw, exampleType(schema)); err == nil {
+				return value, nil
+			}
+		}
+
+		return nil, NewMultiError(&ValidationError{Message: err.Error()})
+	}
+}
+
+// parseDeepObject converts the "name[property]=value" pairs of a deepObject parameter to an object.
+func (p Parameter) parseDeepObject(values []string, schema Schema, root any) (any, error) {
+	object := make(map[string]any, len(values))
+	var errs []error
+
+	for _, value := range values {
+		key, raw, _ := strings.Cut(value, "=")
+		property, isProperty := strings.CutPrefix(key, p.Name+"[")
+		property, isClosed := strings.CutSuffix(property, "]")
+		if !isProperty || !isClosed || property == "" {
+			errs = append(errs, &ValidationError{Message: fmt.Sprintf("value %q is not of the form %s[property]=value", value, p.Name)})
+
+			continue
+		}
+
+		tpe := "string"
+		if propertySchema, isDefined := schema.Properties[property]; isDefined {
+			resolved, err := resolveMember(propertySchema, root, make(map[string]struct{}))
+			if err != nil {
+				return nil, err
+			}
+			tpe = exampleType(*resolved)
+		}
+
+		parsed, err := parseScalar(raw, tpe)
+		if err != nil {
+			errs = append(errs, &ValidationError{Path: pointerTo(property), Message: err.Error()})
+		}
+		object[property] = parsed
+	}
+
+	return object, NewMultiError(errs...)
+}
+
+// parseScalar converts a raw value to a JSON type.
+func parseScalar(raw, tpe string) (any, error) {
+	switch tpe {
+	case "integer", "number":
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return raw, fmt.Errorf("value %q is not a number", raw)
+		}
+
+		return f, nil
+	case "boolean":
+		switch raw {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+
+		return raw, fmt.Errorf("value %q is not a boolean", raw)
+	default:
+		return raw, nil
+	}
+}
+
+func parsedStrings(values []string) []any {
+	parsed := make([]any, 0, len(values))
+	for _, value := range values {
+		parsed = append(parsed, value)
+	}
+
+	return parsed
+}
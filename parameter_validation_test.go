@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestParameter_ParseAndValidate(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1.0"},
+		"components": {
+			"schemas": {
+				"Id": {"type": "integer", "minimum": 1},
+				"Ids": {"type": "array", "items": {"$ref": "#/components/schemas/Id"}, "maxItems": 2},
+				"Filter": {
+					"type": "object",
+					"properties": {"color": {"type": "string"}, "size": {"$ref": "#/components/schemas/Id"}},
+					"default": {"color": "red"}
+				}
+			},
+			"parameters": {
+				"petId": {"name": "petId", "in": "path", "required": true, "schema": {"$ref": "#/components/schemas/Id"}}
+			}
+		},
+		"paths": {
+			"/pets/{petId}": {
+				"get": {
+					"parameters": [
+						{"$ref": "#/components/parameters/petId"},
+						{"name": "ids", "in": "query", "schema": {"$ref": "#/components/schemas/Ids"}},
+						{"name": "verbose", "in": "query", "schema": {"type": "boolean", "default": false}}
+					],
+					"responses": {"200": {"description": "a pet"}}
+				}
+			}
+		}
+	}`), &root))
+
+	params := root.Paths.Paths["/pets/{petId}"].Get.Parameters
+	petID, ids, verbose := params[0], params[1], params[2]
+
+	t.Run("should validate against a referenced schema", func(t *testing.T) {
+		value, err := petID.ParseAndValidate([]string{"42"}, root)
+		require.NoError(t, err)
+		assert.Equal(t, float64(42), value)
+
+		_, err = petID.ParseAndValidate([]string{"0"}, root)
+		var multi *MultiError
+		require.ErrorAs(t, err, &multi)
+		require.Len(t, multi.Errors, 1)
+		assert.Contains(t, multi.Errors[0].Error(), "greater than or equal to 1")
+
+		_, err = petID.ParseAndValidate([]string{"abc"}, root)
+		require.ErrorAs(t, err, &multi)
+		assert.Contains(t, multi.Error(), `value "abc" is not a number`)
+	})
+
+	t.Run("should respect the required field of the parameter", func(t *testing.T) {
+		_, err := petID.ParseAndValidate(nil, root)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `parameter "petId" in path is required`)
+
+		value, err := ids.ParseAndValidate(nil, root)
+		require.NoError(t, err)
+		assert.Nil(t, value)
+
+		value, err = verbose.ParseAndValidate(nil, root)
+		require.NoError(t, err)
+		assert.Equal(t, false, value)
+	})
+
+	t.Run("should parse arrays according to the style of the parameter", func(t *testing.T) {
+		value, err := ids.ParseAndValidate([]string{"1", "2"}, root)
+		require.NoError(t, err)
+		assert.Equal(t, []any{float64(1), float64(2)}, value)
+
+		_, err = ids.ParseAndValidate([]string{"1", "2", "3"}, root)
+		require.Error(t, err)
+
+		piped := ids
+		piped.Style = "pipeDelimited"
+		value, err = piped.ParseAndValidate([]string{"3|4"}, root)
+		require.NoError(t, err)
+		assert.Equal(t, []any{float64(3), float64(4)}, value)
+
+		_, err = piped.ParseAndValidate([]string{"3|x"}, root)
+		var multi *MultiError
+		require.ErrorAs(t, err, &multi)
+		assert.Equal(t, "/1", multi.Errors[0].Pointer())
+	})
+
+	t.Run("should parse query parameters with the default style, or deepObject", func(t *testing.T) {
+		explode := false
+		filter := Parameter{ParamProps: ParamProps{
+			Name: "filter", In: "query", Required: true, Style: "deepObject", Schema: RefSchema("#/components/schemas/Filter"),
+		}}
+
+		for _, tc := range []struct {
+			name     string
+			param    Parameter
+			values   []string
+			expected any
+		}{
+			{
+				name:     "exploded form array by default",
+				param:    ids,
+				values:   []string{"1", "2"},
+				expected: []any{float64(1), float64(2)},
+			},
+			{
+				name:     "form array without explode",
+				param:    Parameter{ParamProps: ParamProps{Name: "ids", In: "query", Explode: &explode, Schema: RefSchema("#/components/schemas/Ids")}},
+				values:   []string{"1,2"},
+				expected: []any{float64(1), float64(2)},
+			},
+			{
+				name:     "deepObject",
+				param:    filter,
+				values:   []string{"filter[color]=blue", "filter[size]=3"},
+				expected: map[string]any{"color": "blue", "size": float64(3)},
+			},
+			{
+				name:     "absent deepObject with the default of its schema",
+				param:    filter,
+				expected: map[string]any{"color": "red"},
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				value, err := tc.param.ParseAndValidate(tc.values, root)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, value)
+			})
+		}
+
+		_, err := filter.ParseAndValidate([]string{"filter[size]=0"}, root)
+		var multi *MultiError
+		require.ErrorAs(t, err, &multi)
+		assert.Equal(t, "/size", multi.Errors[0].Pointer())
+
+		_, err = filter.ParseAndValidate([]string{"color=blue"}, root)
+		require.ErrorAs(t, err, &multi)
+		assert.Contains(t, multi.Error(), `value "color=blue" is not of the form filter[property]=value`)
+	})
+
+	t.Run("should convert defaults like parsed values", func(t *testing.T) {
+		limit := QueryParam("limit")
+		limit.Schema = Int64Property()
+		limit.Schema.Default = json.Number("10")
+
+		value, err := limit.ParseAndValidate(nil, root)
+		require.NoError(t, err)
+		assert.Equal(t, float64(10), value)
+	})
+
+	t.Run("should reject query values outside of the enum", func(t *testing.T) {
+		status := QueryParam("status")
+		status.Schema = StringProperty().WithEnum("available", "sold")
+
+		value, err := status.ParseAndValidate([]string{"sold"}, root)
+		require.NoError(t, err)
+		assert.Equal(t, "sold", value)
+
+		_, err = status.ParseAndValidate([]string{"lost"}, root)
+		var multi *MultiError
+		require.ErrorAs(t, err, &multi)
+		require.Len(t, multi.Errors, 1)
+		assert.Empty(t, multi.Errors[0].Pointer())
+		assert.EqualError(t, multi.Errors[0], `value "lost" is not one of the allowed values ["available","sold"]`)
+
+		statuses := QueryParam("statuses")
+		statuses.Schema = ArrayProperty(StringProperty().WithEnum("available", "sold"))
+
+		_, err = statuses.ParseAndValidate([]string{"sold", "lost"}, root)
+		require.ErrorAs(t, err, &multi)
+		require.Len(t, multi.Errors, 1)
+		assert.Equal(t, "/1", multi.Errors[0].Pointer())
+		assert.Contains(t, multi.Errors[0].Error(), `value "lost" is not one of the allowed values ["available","sold"]`)
+	})
+
+	t.Run("should parse booleans", func(t *testing.T) {
+		value, err := verbose.ParseAndValidate([]string{"true"}, root)
+		require.NoError(t, err)
+		assert.Equal(t, true, value)
+
+		_, err = verbose.ParseAndValidate([]string{"yes"}, root)
+		require.Error(t, err)
+	})
+
+	t.Run("should reject parameters defined by content", func(t *testing.T) {
+		param := QueryParam("filter")
+		param.Content = map[string]MediaType{"application/json": {}}
+
+		_, err := param.ParseAndValidate([]string{"{}"}, root)
+		require.True(t, errors.Is(err, errors.ErrUnsupported))
+	})
+
+	t.Run("should keep the fields of the parameter when expanding its schema", func(t *testing.T) {
+		var doc Swagger
+		require.NoError(t, json.Unmarshal([]byte(asJSON(t, root)), &doc))
+		require.NoError(t, ExpandSpec(&doc, nil))
+
+		expanded := doc.Paths.Paths["/pets/{petId}"].Get.Parameters[0]
+		assert.Equal(t, "petId", expanded.Name)
+		assert.Equal(t, "path", expanded.In)
+		assert.True(t, expanded.Required)
+		require.NotNil(t, expanded.Schema)
+		assert.Empty(t, expanded.Schema.Ref.String())
+		assert.Equal(t, StringOrArray{"integer"}, expanded.Schema.Type)
+
+		_, err := expanded.ParseAndValidate(nil, nil)
+		require.Error(t, err)
+		_, err = expanded.ParseAndValidate([]string{"0"}, nil)
+		require.Error(t, err)
+	})
+}
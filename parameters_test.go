@@ -5,6 +5,7 @@ package spec
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/go-openapi/swag/conv"
@@ -155,3 +156,263 @@ func TestParametersWithValidation(t *testing.T) {
 	p := new(Parameter).WithValidations(CommonValidations{MaxLength: conv.Pointer(int64(15))})
 	assert.Equal(t, conv.Pointer(int64(15)), p.MaxLength)
 }
+
+func TestParameter_ExamplesOrdered(t *testing.T) {
+	param := QueryParam("kind")
+	param.Examples = map[string]Example{
+		"dog": {ExampleProps: ExampleProps{Summary: "dogs only", Value: "dog"}},
+		"all": {ExampleProps: ExampleProps{Summary: "any kind", ExternalValue: "https://example.com/kinds.txt"}},
+		"cat": {ExampleProps: ExampleProps{Summary: "cats only", Value: "cat"}},
+	}
+
+	for range 10 {
+		assert.Equal(t, []NamedExample{
+			{Name: "all", Example: param.Examples["all"]},
+			{Name: "cat", Example: param.Examples["cat"]},
+			{Name: "dog", Example: param.Examples["dog"]},
+		}, param.ExamplesOrdered())
+	}
+
+	assert.Equal(t, "https://example.com/kinds.txt", param.ExamplesOrdered()[0].ExternalValue)
+}
+
+func TestParameter_Resolve(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"parameters": {
+				"Limit": {
+					"name": "limit",
+					"in": "query",
+					"description": "maximum number of items",
+					"schema": {"type": "integer", "maximum": 100}
+				},
+				"PageSize": {"$ref": "#/components/parameters/Limit"},
+				"Loop": {"$ref": "#/components/parameters/Loop"}
+			}
+		}
+	}`), &root))
+
+	t.Run("should resolve a $ref to a parameter", func(t *testing.T) {
+		resolved, err := ParamRef("#/components/parameters/Limit").Resolve(root)
+		require.NoError(t, err)
+
+		assert.Empty(t, resolved.Ref.String())
+		assert.Equal(t, "limit", resolved.Name)
+		assert.Equal(t, "query", resolved.In)
+		assert.Equal(t, "maximum number of items", resolved.Description)
+		require.NotNil(t, resolved.Schema)
+		assert.Equal(t, float64Ptr(100), resolved.Schema.Maximum)
+	})
+
+	t.Run("should override the description with the one next to the $ref", func(t *testing.T) {
+		resolved, err := ParamRef("#/components/parameters/PageSize").WithDescription("page size").Resolve(root)
+		require.NoError(t, err)
+
+		assert.Equal(t, "limit", resolved.Name)
+		assert.Equal(t, "page size", resolved.Description)
+	})
+
+	t.Run("should return a parameter without $ref as is", func(t *testing.T) {
+		param := QueryParam("offset")
+		resolved, err := param.Resolve(root)
+		require.NoError(t, err)
+		assert.Equal(t, param, resolved)
+	})
+
+	t.Run("should fail on an unresolved $ref", func(t *testing.T) {
+		_, err := ParamRef("#/components/parameters/Missing").Resolve(root)
+		var notFound *RefNotFoundError
+		require.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("should fail on a circular $ref", func(t *testing.T) {
+		_, err := ParamRef("#/components/parameters/Loop").Resolve(root)
+		var circular *CircularReferenceError
+		require.ErrorAs(t, err, &circular)
+	})
+}
+
+func TestParameter_IsEffectivelyRequired(t *testing.T) {
+	t.Run("should be required when flagged as required without default", func(t *testing.T) {
+		assert.True(t, QueryParam("limit").AsRequired().IsEffectivelyRequired())
+		assert.True(t, PathParam("id").IsEffectivelyRequired())
+	})
+
+	t.Run("should be optional when not flagged as required", func(t *testing.T) {
+		assert.False(t, QueryParam("limit").IsEffectivelyRequired())
+		assert.False(t, PathParam("id").AsOptional().IsEffectivelyRequired())
+	})
+
+	t.Run("should be optional with a default", func(t *testing.T) {
+		assert.False(t, QueryParam("limit").WithDefault(10).AsRequired().IsEffectivelyRequired())
+
+		param := QueryParam("limit")
+		param.Required = true
+		param.Default = 10
+		assert.False(t, param.IsEffectivelyRequired())
+	})
+
+	t.Run("should be optional with a default in its schema", func(t *testing.T) {
+		var param Parameter
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"name": "limit",
+			"in": "query",
+			"required": true,
+			"schema": {"type": "integer", "default": 10}
+		}`), &param))
+		assert.False(t, param.IsEffectivelyRequired())
+	})
+}
+
+func TestParameter_WithFormat(t *testing.T) {
+	param := QueryParam("id").Typed("string", "").WithFormat("uuid")
+	assert.Equal(t, "string", param.Type)
+	assert.Equal(t, "uuid", param.Format)
+
+	assertSerializeJSON(t, param.WithFormat("ulid"), `{"type":"string","format":"ulid","name":"id","in":"query"}`)
+}
+
+func TestParameter_EqualSemantic(t *testing.T) {
+	explode := func(value bool) *bool { return &value }
+
+	t.Run("should equal a parameter relying on defaults", func(t *testing.T) {
+		explicit := QueryParam("tags")
+		explicit.Style = "form"
+		explicit.Explode = explode(true)
+
+		assert.True(t, QueryParam("tags").EqualSemantic(*explicit))
+		assert.True(t, explicit.EqualSemantic(*QueryParam("tags")))
+
+		simple := PathParam("id")
+		simple.Explode = explode(false)
+		assert.True(t, PathParam("id").EqualSemantic(*simple))
+	})
+
+	t.Run("should differ from a parameter overriding defaults", func(t *testing.T) {
+		exploded := QueryParam("tags")
+		exploded.Explode = explode(false)
+		assert.False(t, QueryParam("tags").EqualSemantic(*exploded))
+
+		delimited := QueryParam("tags")
+		delimited.Style = "pipeDelimited"
+		assert.False(t, QueryParam("tags").EqualSemantic(*delimited))
+
+		assert.False(t, QueryParam("tags").EqualSemantic(*QueryParam("tags").AsRequired()))
+	})
+}
+
+func TestParameter_EffectiveDefault(t *testing.T) {
+	t.Run("should prefer the default of the parameter", func(t *testing.T) {
+		param := QueryParam("limit").WithDefault(20)
+		param.Schema = new(Schema).Typed("integer", "").WithDefault(10)
+
+		value, ok := param.EffectiveDefault()
+		require.True(t, ok)
+		assert.Equal(t, 20, value)
+	})
+
+	t.Run("should fall back to the default of the schema", func(t *testing.T) {
+		param := QueryParam("limit")
+		param.Schema = new(Schema).Typed("integer", "").WithDefault(10)
+
+		value, ok := param.EffectiveDefault()
+		require.True(t, ok)
+		assert.Equal(t, 10, value)
+	})
+
+	t.Run("should tell when no default is set", func(t *testing.T) {
+		param := QueryParam("limit")
+		param.Schema = new(Schema).Typed("integer", "")
+
+		_, ok := param.EffectiveDefault()
+		assert.False(t, ok)
+	})
+}
+
+func TestParameter_EmbeddedKeys(t *testing.T) {
+	var param Parameter
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"name": "limit",
+		"in": "query",
+		"description": "page size",
+		"type": "integer",
+		"example": 20,
+		"maximum": 100
+	}`), &param))
+
+	t.Run("each key should land in a single field", func(t *testing.T) {
+		assert.Equal(t, "page size", param.ParamProps.Description)
+		assert.Equal(t, "integer", param.SimpleSchema.Type)
+		assert.InDelta(t, float64(20), param.SimpleSchema.Example, 0)
+
+		require.NotNil(t, param.CommonValidations.Maximum)
+		assert.InDelta(t, float64(100), *param.CommonValidations.Maximum, 0)
+	})
+
+	t.Run("each key should be marshaled once", func(t *testing.T) {
+		b, err := json.Marshal(param)
+		require.NoError(t, err)
+
+		for _, key := range []string{`"name"`, `"description"`, `"type"`, `"example"`, `"maximum"`} {
+			assert.Equal(t, 1, strings.Count(string(b), key), key)
+		}
+		assert.JSONEq(t, `{"name":"limit","in":"query","description":"page size","type":"integer","example":20,"maximum":100}`, string(b))
+	})
+}
+
+func FuzzParameterRoundTrip(f *testing.F) {
+	for _, seed := range []string{
+		`{"$ref": "#/components/parameters/limit", "description": "overridden"}`,
+		`{"name": "id", "in": "path", "required": true, "schema": {"type": "string", "format": "uuid"}}`,
+		`{"name": "filter", "in": "query", "content": {"application/json": {"schema": {"type": "object"}}}}`,
+		`{"name": "limit", "in": "query", "style": "form", "explode": false, "example": 10,` +
+			`"examples": {"small": {"value": 1}, "large": {"$ref": "#/components/examples/large"}}}`,
+		`{"name": "tags", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "csv",` +
+			`"maxItems": 3, "uniqueItems": true, "x-go-name": "Tags"}`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var param Parameter
+		if err := json.Unmarshal(data, &param); err != nil {
+			return
+		}
+
+		// the first round normalizes the input, e.g. drops empty values: the result must then be stable
+		b1, err := json.Marshal(param)
+		require.NoError(t, err)
+
+		var decoded Parameter
+		require.NoError(t, json.Unmarshal(b1, &decoded))
+
+		b2, err := json.Marshal(decoded)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(b1), string(b2))
+
+		var redecoded Parameter
+		require.NoError(t, json.Unmarshal(b2, &redecoded))
+		assert.Equal(t, decoded, redecoded)
+	})
+}
+
+func TestArrayQueryParam(t *testing.T) {
+	t.Run("should serialize an exploded array parameter", func(t *testing.T) {
+		param := ArrayQueryParam("id", "integer", "int64", true)
+
+		assertSerializeJSON(t, param,
+			`{"name":"id","in":"query","style":"form","explode":true,"schema":{"type":"array","items":{"type":"integer","format":"int64"}}}`)
+		assert.Nil(t, param.Items)
+		assert.True(t, param.EqualSemantic(Parameter{ParamProps: ParamProps{Name: "id", In: "query", Schema: param.Schema}}))
+	})
+
+	t.Run("should serialize a comma separated array parameter", func(t *testing.T) {
+		param := ArrayQueryParam("tags", "string", "", false)
+
+		assertSerializeJSON(t, param,
+			`{"name":"tags","in":"query","style":"form","explode":false,"schema":{"type":"array","items":{"type":"string"}}}`)
+		assert.False(t, param.EqualSemantic(Parameter{ParamProps: ParamProps{Name: "tags", In: "query", Schema: param.Schema}}))
+	})
+}
@@ -5,6 +5,7 @@ package spec
 
 import (
 	"encoding/json"
+	"iter"
 
 	"github.com/go-openapi/jsonpointer"
 	"github.com/go-openapi/swag/jsonutils"
@@ -12,14 +13,17 @@ import (
 
 // PathItemProps the path item specific properties
 type PathItemProps struct {
-	Get        *Operation  `json:"get,omitempty"`
-	Put        *Operation  `json:"put,omitempty"`
-	Post       *Operation  `json:"post,omitempty"`
-	Delete     *Operation  `json:"delete,omitempty"`
-	Options    *Operation  `json:"options,omitempty"`
-	Head       *Operation  `json:"head,omitempty"`
-	Patch      *Operation  `json:"patch,omitempty"`
-	Parameters []Parameter `json:"parameters,omitempty"`
+	Summary     string      `json:"summary,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Get         *Operation  `json:"get,omitempty"`
+	Put         *Operation  `json:"put,omitempty"`
+	Post        *Operation  `json:"post,omitempty"`
+	Delete      *Operation  `json:"delete,omitempty"`
+	Options     *Operation  `json:"options,omitempty"`
+	Head        *Operation  `json:"head,omitempty"`
+	Patch       *Operation  `json:"patch,omitempty"`
+	Servers     []Server    `json:"servers,omitempty"`
+	Parameters  []Parameter `json:"parameters,omitempty"`
 }
 
 // PathItem describes the operations available on a single path.
@@ -34,6 +38,38 @@ type PathItem struct {
 	PathItemProps
 }
 
+// operations iterates over the operations defined on this path item, keyed by their lower-case method name
+func (p PathItem) operations() iter.Seq2[string, *Operation] {
+	return func(yield func(string, *Operation) bool) {
+		for _, o := range []struct {
+			method string
+			op     *Operation
+		}{
+			{"get", p.Get},
+			{"put", p.Put},
+			{"post", p.Post},
+			{"delete", p.Delete},
+			{"options", p.Options},
+			{"head", p.Head},
+			{"patch", p.Patch},
+		} {
+			if o.op == nil {
+				continue
+			}
+			if !yield(o.method, o.op) {
+				return
+			}
+		}
+	}
+}
+
+// Parameter looks up a parameter defined at the level of the path, by name and location.
+//
+// Like with Operation.Parameter, parameters defined by a $ref are resolved against root.
+func (p PathItem) Parameter(name, in string, root any) (*Parameter, bool) {
+	return findParameter(p.Parameters, name, in, root)
+}
+
 // JSONLookup look up a value by the json property name
 func (p PathItem) JSONLookup(token string) (any, error) {
 	if ex, ok := p.Extensions[token]; ok {
@@ -74,3 +110,21 @@ func (p PathItem) MarshalJSON() ([]byte, error) {
 	concated := jsonutils.ConcatJSON(b3, b4, b5)
 	return concated, nil
 }
+
+// WithSummary a fluent builder method for the summary of the path item
+func (p *PathItem) WithSummary(summary string) *PathItem {
+	p.Summary = summary
+	return p
+}
+
+// WithDescription a fluent builder method for the description of the path item
+func (p *PathItem) WithDescription(description string) *PathItem {
+	p.Description = description
+	return p
+}
+
+// WithServers a fluent builder method to override the servers of the document for all operations of the path item
+func (p *PathItem) WithServers(servers ...Server) *PathItem {
+	p.Servers = append(p.Servers, servers...)
+	return p
+}
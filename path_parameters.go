@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ValidatePathParameters checks that path templates and path parameters agree.
+//
+// Every variable of a path template, such as "{petId}" in "/pets/{petId}", must be defined
+// by a path parameter, either on the path item or on each of its operations. Conversely, every
+// path parameter must appear in the template.
+//
+// Parameters defined by a local $ref are resolved against the document. Other $ref's are not resolved:
+// variables which they might define are not reported. Each mismatch is reported as a *ValidationError
+// located by a JSON pointer.
+func (s *Swagger) ValidatePathParameters() []error {
+	return s.ValidatePathParametersWithBase(nil)
+}
+
+// ValidatePathParametersWithBase is like ValidatePathParameters, but resolves the $ref's to other documents
+// as well when options configure a document loader, i.e. a Resolver, a PathLoaderContext or a PathLoader.
+// These $ref's are relative to the RelativeBase of options.
+func (s *Swagger) ValidatePathParametersWithBase(options *ExpandOptions) []error {
+	if s.Paths == nil {
+		return nil
+	}
+
+	var errs []error
+
+	for _, key := range slices.Sorted(maps.Keys(s.Paths.Paths)) {
+		item := s.Paths.Paths[key]
+		location := pointerTo("paths", key)
+		variables := pathTemplateVariables(key)
+
+		pathParams, pathResolved := s.pathParameters(item.Parameters, options)
+		errs = append(errs, orphanPathParameters(pathParams, variables, location)...)
+
+		hasOperations := false
+		for method, op := range item.operations() {
+			hasOperations = true
+
+			opParams, opResolved := s.pathParameters(op.Parameters, options)
+			errs = append(errs, orphanPathParameters(opParams, variables, location+pointerTo(method))...)
+			if !pathResolved || !opResolved {
+				continue
+			}
+
+			for _, variable := range variables {
+				if !slices.Contains(pathParams, variable) && !slices.Contains(opParams, variable) {
+					errs = append(errs, &ValidationError{
+						Path:    location + pointerTo(method),
+						Message: fmt.Sprintf("path parameter %q is not defined", variable),
+					})
+				}
+			}
+		}
+
+		if hasOperations || !pathResolved {
+			continue
+		}
+
+		for _, variable := range variables {
+			if !slices.Contains(pathParams, variable) {
+				errs = append(errs, &ValidationError{
+					Path:    location,
+					Message: fmt.Sprintf("path parameter %q is not defined", variable),
+				})
+			}
+		}
+	}
+
+	return errs
+}
+
+// pathParameters returns the names of the path parameters among params, in order.
+//
+// Names are left empty for parameters which are not in path, or cannot be resolved.
+// It returns false when some parameters cannot be resolved.
+func (s *Swagger) pathParameters(params []Parameter, options *ExpandOptions) ([]string, bool) {
+	names := make([]string, len(params))
+	resolvedAll := true
+
+	for i, param := range params {
+		if param.Ref.String() != "" {
+			resolved, err := s.resolvePathParameter(param, options)
+			if err != nil {
+				resolvedAll = false
+
+				continue
+			}
+			param = *resolved
+		}
+
+		if param.In == "path" {
+			names[i] = param.Name
+		}
+	}
+
+	return names, resolvedAll
+}
+
+// resolvePathParameter resolves the $ref of a parameter. $ref's to other documents require a document loader.
+func (s *Swagger) resolvePathParameter(param Parameter, options *ExpandOptions) (*Parameter, error) {
+	if param.Ref.HasFragmentOnly {
+		return param.Resolve(s)
+	}
+
+	if options == nil || options.Resolver == nil && options.PathLoaderContext == nil && options.PathLoader == nil {
+		return nil, fmt.Errorf("no document loader to resolve %q: %w", param.Ref.String(), ErrSpec)
+	}
+
+	return ResolveParameterWithBase(s, param.Ref, options)
+}
+
+func orphanPathParameters(names, variables []string, location string) []error {
+	var errs []error
+
+	for i, name := range names {
+		if name == "" || slices.Contains(variables, name) {
+			continue
+		}
+
+		errs = append(errs, &ValidationError{
+			Path:    location + pointerTo("parameters", strconv.Itoa(i)),
+			Message: fmt.Sprintf("path parameter %q does not appear in the path template", name),
+		})
+	}
+
+	return errs
+}
+
+// pathTemplateVariables returns the names of the variables in a path template.
+func pathTemplateVariables(path string) []string {
+	var variables []string
+
+	for {
+		start := strings.IndexByte(path, '{')
+		if start < 0 {
+			return variables
+		}
+
+		end := strings.IndexByte(path[start:], '}')
+		if end < 0 {
+			return variables
+		}
+
+		variables = append(variables, path[start+1:start+end])
+		path = path[start+end+1:]
+	}
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSwagger_ValidatePathParameters(t *testing.T) {
+	var doc Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"paths": {
+			"/pets/{petId}": {
+				"get": {"parameters": [{"name": "limit", "in": "query"}]},
+				"delete": {"parameters": [{"name": "petId", "in": "path", "required": true}]}
+			},
+			"/pets/{petId}/tags/{tagId}": {
+				"parameters": [{"$ref": "#/components/parameters/petId"}],
+				"get": {"parameters": [{"name": "tagId", "in": "path", "required": true}]}
+			},
+			"/owners": {
+				"parameters": [{"name": "x", "in": "path", "required": true}],
+				"get": {"parameters": [{"name": "ownerId", "in": "path", "required": true}]}
+			},
+			"/stores/{storeId}": {}
+		},
+		"components": {
+			"parameters": {
+				"petId": {"name": "petId", "in": "path", "required": true}
+			}
+		}
+	}`), &doc))
+
+	errs := doc.ValidatePathParameters()
+
+	expected := []string{
+		`/paths/~1owners/parameters/0: path parameter "x" does not appear in the path template`,
+		`/paths/~1owners/get/parameters/0: path parameter "ownerId" does not appear in the path template`,
+		`/paths/~1pets~1{petId}/get: path parameter "petId" is not defined`,
+		`/paths/~1stores~1{storeId}: path parameter "storeId" is not defined`,
+	}
+	require.Len(t, errs, len(expected))
+	for i, err := range errs {
+		require.ErrorIs(t, err, ErrSpec)
+		assert.EqualError(t, err, expected[i])
+	}
+}
+
+func TestSwagger_ValidatePathParametersWithBase(t *testing.T) {
+	var doc Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"paths": {
+			"/pets/{petId}": {
+				"parameters": [{"$ref": "parameters.json#/petId"}],
+				"get": {"parameters": [{"$ref": "parameters.json#/ownerId"}]}
+			}
+		}
+	}`), &doc))
+
+	t.Run("should not report variables which other documents might define", func(t *testing.T) {
+		assert.Empty(t, doc.ValidatePathParameters())
+	})
+
+	t.Run("should resolve $ref's to other documents with a loader", func(t *testing.T) {
+		options := &ExpandOptions{
+			RelativeBase: "/api/spec.json",
+			PathLoader: func(pth string) (json.RawMessage, error) {
+				assert.Contains(t, pth, "/api/parameters.json")
+
+				return json.RawMessage(`{
+					"petId": {"name": "petId", "in": "path", "required": true},
+					"ownerId": {"name": "ownerId", "in": "path", "required": true}
+				}`), nil
+			},
+		}
+
+		errs := doc.ValidatePathParametersWithBase(options)
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], `/paths/~1pets~1{petId}/get/parameters/0: path parameter "ownerId" does not appear in the path template`)
+	})
+}
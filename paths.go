@@ -6,6 +6,9 @@ package spec
 import (
 	"encoding/json"
 	"fmt"
+	"iter"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/go-openapi/swag/jsonutils"
@@ -85,3 +88,57 @@ func (p Paths) MarshalJSON() ([]byte, error) {
 	concated := jsonutils.ConcatJSON(b1, b2)
 	return concated, nil
 }
+
+// OperationKey identifies an operation in a document.
+type OperationKey struct {
+	// Path is the path template of the operation, or the name of the webhook
+	Path string
+	// Method is the lower case HTTP method of the operation
+	Method string
+	// Webhook is true for operations defined by webhooks
+	Webhook bool
+}
+
+// Pointer returns the JSON pointer to the operation in its document.
+func (k OperationKey) Pointer() string {
+	if k.Webhook {
+		return pointerTo("webhooks", k.Path, k.Method)
+	}
+
+	return pointerTo("paths", k.Path, k.Method)
+}
+
+// Operations iterates over the operations of all paths, sorted by path, then by method
+// in the order get, put, post, delete, options, head, patch.
+//
+// Operations of webhooks are not included: see WebhookOperations.
+func (s *Swagger) Operations() iter.Seq2[OperationKey, *Operation] {
+	return func(yield func(OperationKey, *Operation) bool) {
+		if s.Paths == nil {
+			return
+		}
+
+		for key, op := range operationsOf(s.Paths.Paths, false) {
+			if !yield(key, op) {
+				return
+			}
+		}
+	}
+}
+
+// WebhookOperations iterates over the operations of all webhooks, in the same order as Operations.
+func (s *Swagger) WebhookOperations() iter.Seq2[OperationKey, *Operation] {
+	return operationsOf(s.Webhooks, true)
+}
+
+func operationsOf(items map[string]PathItem, webhook bool) iter.Seq2[OperationKey, *Operation] {
+	return func(yield func(OperationKey, *Operation) bool) {
+		for _, pth := range slices.Sorted(maps.Keys(items)) {
+			for method, op := range items[pth].operations() {
+				if !yield(OperationKey{Path: pth, Method: method, Webhook: webhook}, op) {
+					return
+				}
+			}
+		}
+	}
+}
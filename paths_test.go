@@ -30,3 +30,66 @@ func TestIntegrationPaths(t *testing.T) {
 	assertParsesJSON(t, pathsJSON, paths)
 
 }
+
+func TestSwagger_Operations(t *testing.T) {
+	var doc Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"paths": {
+			"/pets/{petId}": {
+				"delete": {"operationId": "deletePet"},
+				"get": {"operationId": "getPet"}
+			},
+			"/pets": {
+				"post": {"operationId": "addPet"},
+				"get": {"operationId": "listPets"}
+			},
+			"/health": {
+				"head": {"operationId": "probe"}
+			}
+		},
+		"webhooks": {
+			"newPet": {
+				"post": {"operationId": "onNewPet"}
+			}
+		}
+	}`), &doc))
+
+	t.Run("should iterate over the operations of all paths", func(t *testing.T) {
+		var ids, pointers []string
+		for key, op := range doc.Operations() {
+			ids = append(ids, op.ID)
+			pointers = append(pointers, key.Pointer())
+		}
+
+		assert.Equal(t, []string{"probe", "listPets", "addPet", "getPet", "deletePet"}, ids)
+		assert.Equal(t, "/paths/~1pets~1{petId}/delete", pointers[4])
+	})
+
+	t.Run("should stop iterating on demand", func(t *testing.T) {
+		count := 0
+		for range doc.Operations() {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("should iterate over the operations of webhooks", func(t *testing.T) {
+		var keys []OperationKey
+		for key := range doc.WebhookOperations() {
+			keys = append(keys, key)
+		}
+
+		assert.Equal(t, []OperationKey{{Path: "newPet", Method: "post", Webhook: true}}, keys)
+		assert.Equal(t, "/webhooks/newPet/post", keys[0].Pointer())
+	})
+
+	t.Run("should not iterate over a document without paths", func(t *testing.T) {
+		for range new(Swagger).Operations() {
+			assert.Fail(t, "should not iterate")
+		}
+	})
+}
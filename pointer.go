@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// EscapeJSONPointerToken escapes a reference token of a JSON pointer,
+// replacing "~" by "~0" and "/" by "~1", as per RFC 6901.
+func EscapeJSONPointerToken(token string) string {
+	return jsonpointer.Escape(token)
+}
+
+// UnescapeJSONPointerToken reverts EscapeJSONPointerToken.
+func UnescapeJSONPointerToken(token string) string {
+	return jsonpointer.Unescape(token)
+}
+
+// SplitPointer returns the unescaped reference tokens of a JSON pointer.
+//
+// A leading "#", as found in URI fragments, is ignored. The root pointer "" yields no token.
+func SplitPointer(pointer string) []string {
+	pointer = strings.TrimPrefix(pointer, "#")
+	if pointer == "" {
+		return nil
+	}
+
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = UnescapeJSONPointerToken(token)
+	}
+
+	return tokens
+}
+
+// pointerTo builds the JSON pointer made of the escaped tokens.
+func pointerTo(tokens ...string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(EscapeJSONPointerToken(token))
+	}
+
+	return b.String()
+}
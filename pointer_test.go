@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+)
+
+func TestJSONPointerTokens(t *testing.T) {
+	for _, tc := range []struct {
+		token   string
+		escaped string
+	}{
+		{token: "pets", escaped: "pets"},
+		{token: "/pets/{id}", escaped: "~1pets~1{id}"},
+		{token: "a~b", escaped: "a~0b"},
+		{token: "~1", escaped: "~01"},
+		{token: "/~/", escaped: "~1~0~1"},
+		{token: "", escaped: ""},
+	} {
+		t.Run(tc.token, func(t *testing.T) {
+			assert.Equal(t, tc.escaped, EscapeJSONPointerToken(tc.token))
+			assert.Equal(t, tc.token, UnescapeJSONPointerToken(tc.escaped))
+		})
+	}
+}
+
+func TestSplitPointer(t *testing.T) {
+	t.Run("should round trip tokens with / and ~", func(t *testing.T) {
+		tokens := []string{"paths", "/pets/{id}", "get", "x~custom", "~1"}
+		assert.Equal(t, tokens, SplitPointer(pointerTo(tokens...)))
+	})
+
+	t.Run("should ignore a leading #", func(t *testing.T) {
+		assert.Equal(t, []string{"components", "schemas", "a/b"}, SplitPointer("#/components/schemas/a~1b"))
+	})
+
+	t.Run("should split the root pointer", func(t *testing.T) {
+		assert.Empty(t, SplitPointer(""))
+		assert.Empty(t, SplitPointer("#"))
+		assert.Equal(t, []string{""}, SplitPointer("/"))
+	})
+}
@@ -96,7 +96,7 @@ func (properties SchemaProperties) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON handles JSON Schema 2020-12 where property values can be either
 // a schema object or a boolean (true/false). Boolean values are converted to
-// empty schemas with appropriate semantics (true = allows any, false = allows none).
+// TrueSchema (allows any value) and FalseSchema (allows none).
 func (properties *SchemaProperties) UnmarshalJSON(data []byte) error {
 	var raw map[string]json.RawMessage
 	if err := json.Unmarshal(data, &raw); err != nil {
@@ -105,20 +105,6 @@ func (properties *SchemaProperties) UnmarshalJSON(data []byte) error {
 
 	result := make(SchemaProperties, len(raw))
 	for k, v := range raw {
-		// Check if it's a boolean value
-		trimmed := bytes.TrimSpace(v)
-		if bytes.Equal(trimmed, []byte("true")) || bytes.Equal(trimmed, []byte("false")) {
-			// For boolean values in properties (JSON Schema 2020-12):
-			// true = schema that allows anything (empty schema)
-			// false = schema that allows nothing (we can represent this with impossible constraints)
-			// For simplicity, we use an empty schema for true and skip false entries
-			if bytes.Equal(trimmed, []byte("true")) {
-				result[k] = Schema{} // empty schema allows anything
-			}
-			// false entries are not added - they disallow any value
-			continue
-		}
-
 		var schema Schema
 		if err := json.Unmarshal(v, &schema); err != nil {
 			return err
@@ -0,0 +1,605 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tagOpenAPI  = "openapi"
+	tagValidate = "validate"
+	tagJSON     = "json"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// tagOption is a key=value pair found in a struct tag
+type tagOption struct {
+	Key   string
+	Value string
+}
+
+// parseTagOptions splits a comma-separated list of key[=value] options
+func parseTagOptions(tag string) []tagOption {
+	if tag == "" {
+		return nil
+	}
+
+	parts := strings.Split(tag, ",")
+	opts := make([]tagOption, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, "=")
+		opts = append(opts, tagOption{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value)})
+	}
+
+	return opts
+}
+
+// jsonField parses the json tag of a struct field.
+//
+// The returned name is empty when the tag does not rename the field.
+// It returns false when the field is skipped by encoding/json.
+func jsonField(field reflect.StructField) (name string, omitEmpty bool, ok bool) {
+	tag := field.Tag.Get(tagJSON)
+	if tag == "-" {
+		return "", false, false
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	for opt := range strings.SplitSeq(opts, ",") {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitEmpty = true
+		}
+	}
+
+	return name, omitEmpty, true
+}
+
+// primitiveSchema infers a schema type and format from a go type which is not a struct, a map or a slice.
+func primitiveSchema(t reflect.Type) (*Schema, bool) {
+	if t == timeType {
+		return DateTimeProperty(), true
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return BooleanProperty(), true
+	case reflect.String:
+		return StringProperty(), true
+	case reflect.Int8:
+		return Int8Property(), true
+	case reflect.Int16:
+		return Int16Property(), true
+	case reflect.Int32:
+		return Int32Property(), true
+	case reflect.Int, reflect.Int64:
+		return Int64Property(), true
+	case reflect.Uint8:
+		return new(Schema).Typed("integer", "uint8"), true
+	case reflect.Uint16:
+		return new(Schema).Typed("integer", "uint16"), true
+	case reflect.Uint32:
+		return new(Schema).Typed("integer", "uint32"), true
+	case reflect.Uint, reflect.Uint64:
+		return new(Schema).Typed("integer", "uint64"), true
+	case reflect.Float32:
+		return Float32Property(), true
+	case reflect.Float64:
+		return Float64Property(), true
+	default:
+		return nil, false
+	}
+}
+
+// ParamFromStructTag builds a parameter from the tags of a struct field.
+//
+// The parameter is described by the "openapi" tag, as a comma-separated list of options, e.g.:
+//
+//	Limit int `openapi:"in=query,name=limit,required" validate:"min=1,max=100"`
+//
+// Supported options are: in, name, description, required, deprecated, allowEmptyValue, style, explode and format.
+//
+// When no name is specified, the name is taken from the json tag, then from the name of the field.
+// When no location is specified, the parameter is located in the query. Path parameters are always required.
+//
+// The schema of the parameter is inferred from the go type of the field.
+// Pointers are dereferenced and slices are described as arrays.
+//
+// Validations are read from the "validate" tag, following the conventions of the go-playground validator:
+//   - min, max: minimum and maximum for numbers, min and max length for strings, min and max items for slices
+//   - gt, lt: exclusive minimum and maximum for numbers
+//   - len: exact length for strings, exact number of items for slices
+//   - oneof: space-separated list of allowed values
+//   - required: the parameter is required
+//
+// Other validation rules are ignored.
+func ParamFromStructTag(field reflect.StructField) (*Parameter, error) {
+	name, _, _ := jsonField(field)
+	if name == "" {
+		name = field.Name
+	}
+
+	sch, err := paramSchemaFromType(field.Type)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+
+	param := &Parameter{ParamProps: ParamProps{Name: name, In: "query", Schema: sch}}
+
+	for _, opt := range parseTagOptions(field.Tag.Get(tagOpenAPI)) {
+		switch opt.Key {
+		case "in":
+			param.In = opt.Value
+		case "name":
+			param.Name = opt.Value
+		case "description":
+			param.Description = opt.Value
+		case "format":
+			sch.Format = opt.Value
+		case "style":
+			param.Style = opt.Value
+		case "required", "deprecated", "allowEmptyValue", "explode":
+			b, err := tagBool(opt)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			switch opt.Key {
+			case "required":
+				param.Required = b
+			case "deprecated":
+				param.Deprecated = b
+			case "allowEmptyValue":
+				param.AllowEmptyValue = b
+			default:
+				param.Explode = &b
+			}
+		default:
+			return nil, fmt.Errorf("field %s: unknown option %q in tag %q: %w", field.Name, opt.Key, tagOpenAPI, ErrInvalidStructTag)
+		}
+	}
+
+	required, err := applyValidateTag(sch, field.Tag.Get(tagValidate))
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	if required || param.In == "path" {
+		param.Required = true
+	}
+
+	return param, nil
+}
+
+func paramSchemaFromType(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		items, ok := primitiveSchema(t.Elem())
+		if !ok {
+			return nil, fmt.Errorf("array of %v: %w", t.Elem(), ErrUnsupportedGoType)
+		}
+		return ArrayProperty(items), nil
+	}
+
+	sch, ok := primitiveSchema(t)
+	if !ok {
+		return nil, fmt.Errorf("%v: %w", t, ErrUnsupportedGoType)
+	}
+
+	return sch, nil
+}
+
+func tagBool(opt tagOption) (bool, error) {
+	if opt.Value == "" {
+		return true, nil
+	}
+
+	b, err := strconv.ParseBool(opt.Value)
+	if err != nil {
+		return false, fmt.Errorf("option %q expects a boolean value, got %q: %w", opt.Key, opt.Value, ErrInvalidStructTag)
+	}
+
+	return b, nil
+}
+
+// applyValidateTag sets the validations expressed by a "validate" struct tag on a schema.
+//
+// It returns true when the tag requires a value.
+func applyValidateTag(sch *Schema, tag string) (bool, error) {
+	var required bool
+	for _, opt := range parseTagOptions(tag) {
+		switch opt.Key {
+		case "required":
+			required = true
+		case "min", "max", "gt", "lt", "len":
+			if err := applyBoundRule(sch, opt); err != nil {
+				return false, err
+			}
+		case "oneof":
+			target := sch
+			if sch.Items != nil && sch.Items.Schema != nil {
+				// for arrays, allowed values apply to items
+				target = sch.Items.Schema
+			}
+			values, err := enumValues(target, strings.Fields(opt.Value))
+			if err != nil {
+				return false, err
+			}
+			target.Enum = values
+		}
+	}
+
+	return required, nil
+}
+
+func applyBoundRule(sch *Schema, opt tagOption) error {
+	switch {
+	case sch.Type.Contains("integer") || sch.Type.Contains("number"):
+		f, err := strconv.ParseFloat(opt.Value, 64)
+		if err != nil {
+			return fmt.Errorf("rule %q expects a number, got %q: %w", opt.Key, opt.Value, ErrInvalidStructTag)
+		}
+		switch opt.Key {
+		case "min":
+			sch.WithMinimum(f, false)
+		case "gt":
+			sch.WithMinimum(f, true)
+		case "max":
+			sch.WithMaximum(f, false)
+		case "lt":
+			sch.WithMaximum(f, true)
+		case "len":
+			sch.WithMinimum(f, false).WithMaximum(f, false)
+		}
+	case sch.Type.Contains("string") || sch.Type.Contains(jsonArray):
+		n, err := strconv.ParseInt(opt.Value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("rule %q expects an integer, got %q: %w", opt.Key, opt.Value, ErrInvalidStructTag)
+		}
+		if n < 0 {
+			return fmt.Errorf("rule %q expects a positive integer, got %q: %w", opt.Key, opt.Value, ErrInvalidStructTag)
+		}
+		isString := sch.Type.Contains("string")
+		switch opt.Key {
+		case "min", "gt":
+			if opt.Key == "gt" {
+				n++
+			}
+			if isString {
+				sch.WithMinLength(n)
+			} else {
+				sch.WithMinItems(n)
+			}
+		case "max", "lt":
+			if opt.Key == "lt" {
+				if n == 0 {
+					return fmt.Errorf("rule %q expects an integer greater than 0, got %q: %w", opt.Key, opt.Value, ErrInvalidStructTag)
+				}
+				n--
+			}
+			if isString {
+				sch.WithMaxLength(n)
+			} else {
+				sch.WithMaxItems(n)
+			}
+		case "len":
+			if isString {
+				sch.WithMinLength(n).WithMaxLength(n)
+			} else {
+				sch.WithMinItems(n).WithMaxItems(n)
+			}
+		}
+	}
+
+	return nil
+}
+
+// enumValues converts the string representation of enum values to the type of the schema
+func enumValues(sch *Schema, values []string) ([]any, error) {
+	enum := make([]any, 0, len(values))
+	for _, value := range values {
+		switch {
+		case sch.Type.Contains("integer"):
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("enum value %q is not an integer: %w", value, ErrInvalidStructTag)
+			}
+			enum = append(enum, n)
+		case sch.Type.Contains("number"):
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("enum value %q is not a number: %w", value, ErrInvalidStructTag)
+			}
+			enum = append(enum, f)
+		case sch.Type.Contains("boolean"):
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("enum value %q is not a boolean: %w", value, ErrInvalidStructTag)
+			}
+			enum = append(enum, b)
+		default:
+			enum = append(enum, value)
+		}
+	}
+
+	return enum, nil
+}
+
+// SchemaFromType builds a schema describing the JSON representation of a go type.
+//
+// The following conventions apply:
+//   - structs are described as objects, with properties named after their json tag.
+//     Fields tagged with omitempty are optional, all others are required. Embedded structs are flattened.
+//   - slices and arrays are described as arrays. Byte slices are described as base64-encoded strings.
+//   - maps with string keys are described as objects with additionalProperties
+//   - pointers are described as nullable. Pointers to self-referencing types wrap their $ref in an allOf.
+//   - interfaces are described by the empty schema
+//
+// Validations are read from the "validate" struct tag of fields, like for ParamFromStructTag.
+//
+// Named struct types which refer to themselves, directly or not, are defined in the "$defs"
+// section of the returned schema and replaced by a $ref at the places they are used.
+func SchemaFromType(t reflect.Type) (*Schema, error) {
+	b := &schemaBuilder{
+		visiting:  make(map[reflect.Type]bool),
+		recursive: make(map[reflect.Type]bool),
+		names:     make(map[reflect.Type]string),
+		taken:     make(map[string]bool),
+		defs:      make(Definitions),
+	}
+
+	sch, err := b.build(t)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.defs) > 0 {
+		sch.Defs = b.defs
+	}
+
+	return sch, nil
+}
+
+// schemaBuilder accumulates the definitions of self-referencing types while building a schema
+type schemaBuilder struct {
+	visiting  map[reflect.Type]bool
+	recursive map[reflect.Type]bool
+	names     map[reflect.Type]string
+	taken     map[string]bool
+	defs      Definitions
+}
+
+func (b *schemaBuilder) build(t reflect.Type) (*Schema, error) {
+	if t.Kind() == reflect.Ptr {
+		sch, err := b.build(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		if sch.Ref.String() != "" {
+			// keywords next to a $ref are ignored before OpenAPI 3.1
+			return (&Schema{SchemaProps: SchemaProps{AllOf: []Schema{*sch}}}).AsNullable(), nil
+		}
+
+		return sch.AsNullable(), nil
+	}
+
+	if sch, ok := primitiveSchema(t); ok {
+		return sch, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return new(Schema), nil
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return StrFmtProperty("byte"), nil
+		}
+
+		items, err := b.build(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return ArrayProperty(items), nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map with keys of type %v: %w", t.Key(), ErrUnsupportedGoType)
+		}
+
+		values, err := b.build(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return MapProperty(values), nil
+	case reflect.Struct:
+		return b.buildStruct(t)
+	default:
+		return nil, fmt.Errorf("%v: %w", t, ErrUnsupportedGoType)
+	}
+}
+
+func (b *schemaBuilder) buildStruct(t reflect.Type) (*Schema, error) {
+	if t.Name() != "" {
+		if _, isDefined := b.defs[b.name(t)]; isDefined || b.visiting[t] {
+			// self-referencing type: refer to its definition
+			b.recursive[t] = true
+
+			return RefSchema("#/$defs/" + b.name(t)), nil
+		}
+
+		b.visiting[t] = true
+		defer delete(b.visiting, t)
+	}
+
+	sch := &Schema{SchemaProps: SchemaProps{Type: []string{"object"}}}
+	if err := b.addFields(sch, t); err != nil {
+		return nil, err
+	}
+
+	if b.recursive[t] {
+		b.defs[b.name(t)] = *sch
+
+		return RefSchema("#/$defs/" + b.name(t)), nil
+	}
+
+	return sch, nil
+}
+
+func (b *schemaBuilder) addFields(sch *Schema, t reflect.Type) error {
+	for _, field := range structFields(t) {
+		prop, err := b.build(field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s.%s: %w", field.owner.Name(), field.Name, err)
+		}
+
+		required, err := applyValidateTag(prop, field.Tag.Get(tagValidate))
+		if err != nil {
+			return fmt.Errorf("field %s.%s: %w", field.owner.Name(), field.Name, err)
+		}
+
+		sch.SetProperty(field.name, *prop)
+		if required || !field.omitEmpty {
+			sch.AddRequired(field.name)
+		}
+	}
+
+	return nil
+}
+
+// structField is a field of a struct, or of its embedded structs, serialized by encoding/json.
+type structField struct {
+	reflect.StructField
+
+	owner     reflect.Type // the struct declaring the field
+	name      string
+	tagged    bool
+	omitEmpty bool
+	index     []int // the path to the field through embedded structs
+}
+
+// structFields lists the fields of a struct serialized by encoding/json, in the order of their declaration.
+//
+// Like encoding/json, the fields of embedded structs are promoted, and embedded structs which are already
+// visited at a shallower depth, such as a pointer to the outer struct, are skipped. When several fields have the same name, the
+// shallowest one wins, then the one with a json tag. The others, and fields with an ambiguous name, are dropped.
+func structFields(t reflect.Type) []structField {
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	var fields []structField
+	taken := make(map[string]bool)
+	visited := make(map[reflect.Type]bool)
+
+	for level := []embedded{{typ: t}}; len(level) > 0; {
+		var next []embedded
+		byName := make(map[string][]structField)
+
+		// the same struct embedded twice at a level yields ambiguous fields, which are dropped
+		level = slices.DeleteFunc(level, func(parent embedded) bool { return visited[parent.typ] })
+		for _, parent := range level {
+			visited[parent.typ] = true
+		}
+
+		for _, parent := range level {
+			for i := range parent.typ.NumField() {
+				field := parent.typ.Field(i)
+				name, omitEmpty, ok := jsonField(field)
+				if !ok {
+					continue
+				}
+				index := append(slices.Clone(parent.index), i)
+
+				if field.Anonymous && name == "" {
+					typ := field.Type
+					if typ.Kind() == reflect.Ptr {
+						typ = typ.Elem()
+					}
+
+					if typ.Kind() == reflect.Struct {
+						// promoted fields: embedded pointers to unexported structs are ignored by encoding/json
+						if field.IsExported() || field.Type.Kind() != reflect.Ptr {
+							next = append(next, embedded{typ: typ, index: index})
+						}
+
+						continue
+					}
+				}
+
+				if !field.IsExported() {
+					continue
+				}
+
+				tagged := name != ""
+				if !tagged {
+					name = field.Name
+				}
+				byName[name] = append(byName[name], structField{
+					StructField: field, owner: parent.typ, name: name, tagged: tagged, omitEmpty: omitEmpty, index: index,
+				})
+			}
+		}
+
+		for name, candidates := range byName {
+			if taken[name] {
+				// shadowed by a shallower field
+				continue
+			}
+			taken[name] = true
+
+			if dominant, ok := dominantField(candidates); ok {
+				fields = append(fields, dominant)
+			}
+		}
+
+		level = next
+	}
+
+	slices.SortFunc(fields, func(a, b structField) int { return slices.Compare(a.index, b.index) })
+
+	return fields
+}
+
+// dominantField picks among fields with the same name at the same depth the only one, or the only tagged one.
+func dominantField(fields []structField) (structField, bool) {
+	if len(fields) == 1 {
+		return fields[0], true
+	}
+
+	tagged := slices.DeleteFunc(slices.Clone(fields), func(field structField) bool { return !field.tagged })
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+
+	return structField{}, false
+}
+
+// name yields a unique definition name for a type
+func (b *schemaBuilder) name(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	for i := 2; b.taken[name]; i++ {
+		name = t.Name() + strconv.Itoa(i)
+	}
+	b.names[t] = name
+	b.taken[name] = true
+
+	return name
+}
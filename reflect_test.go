@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+type listPetsParams struct {
+	Limit   int        `openapi:"in=query,name=limit,required" validate:"min=1,max=100"`
+	PetID   string     `openapi:"in=path" json:"petId" validate:"len=12"`
+	Tags    []string   `openapi:"explode=false" validate:"max=5,oneof=cat dog"`
+	Since   *time.Time `openapi:"in=header,name=X-Since,deprecated"`
+	Ratio   float32    `validate:"gt=0,lt=1"`
+	Invalid string     `openapi:"in=query,unknown"`
+	Empty   string     `validate:"lt=0"`
+	Bad     struct{}   `openapi:"in=query"`
+}
+
+func paramField(t testing.TB, name string) reflect.StructField {
+	field, ok := reflect.TypeOf(listPetsParams{}).FieldByName(name)
+	require.True(t, ok)
+
+	return field
+}
+
+func TestParamFromStructTag(t *testing.T) {
+	t.Run("should build an integer query param with bounds", func(t *testing.T) {
+		param, err := ParamFromStructTag(paramField(t, "Limit"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "limit", param.Name)
+		assert.Equal(t, "query", param.In)
+		assert.True(t, param.Required)
+		require.NotNil(t, param.Schema)
+		assert.Equal(t, StringOrArray{"integer"}, param.Schema.Type)
+		assert.Equal(t, "int64", param.Schema.Format)
+		require.NotNil(t, param.Schema.Minimum)
+		require.NotNil(t, param.Schema.Maximum)
+		assert.InDelta(t, 1, *param.Schema.Minimum, 1e-6)
+		assert.InDelta(t, 100, *param.Schema.Maximum, 1e-6)
+		assert.False(t, param.Schema.ExclusiveMinimum)
+
+		assertSerializeJSON(t, param,
+			`{"name":"limit","in":"query","required":true,"schema":{"type":"integer","format":"int64","maximum":100,"minimum":1}}`)
+	})
+
+	t.Run("should build a required path param named after the json tag", func(t *testing.T) {
+		param, err := ParamFromStructTag(paramField(t, "PetID"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "petId", param.Name)
+		assert.Equal(t, "path", param.In)
+		assert.True(t, param.Required)
+		require.NotNil(t, param.Schema.MinLength)
+		require.NotNil(t, param.Schema.MaxLength)
+		assert.Equal(t, int64(12), *param.Schema.MinLength)
+		assert.Equal(t, int64(12), *param.Schema.MaxLength)
+	})
+
+	t.Run("should build an array param with enum items", func(t *testing.T) {
+		param, err := ParamFromStructTag(paramField(t, "Tags"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "Tags", param.Name)
+		assert.False(t, param.Required)
+		require.NotNil(t, param.Explode)
+		assert.False(t, *param.Explode)
+		assert.Equal(t, StringOrArray{jsonArray}, param.Schema.Type)
+		require.NotNil(t, param.Schema.MaxItems)
+		assert.Equal(t, int64(5), *param.Schema.MaxItems)
+		require.NotNil(t, param.Schema.Items)
+		assert.Equal(t, []any{"cat", "dog"}, param.Schema.Items.Schema.Enum)
+	})
+
+	t.Run("should build a date-time header param from a pointer", func(t *testing.T) {
+		param, err := ParamFromStructTag(paramField(t, "Since"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "X-Since", param.Name)
+		assert.Equal(t, "header", param.In)
+		assert.True(t, param.Deprecated)
+		assert.Equal(t, "date-time", param.Schema.Format)
+	})
+
+	t.Run("should build exclusive bounds for numbers", func(t *testing.T) {
+		param, err := ParamFromStructTag(paramField(t, "Ratio"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "float", param.Schema.Format)
+		assert.True(t, param.Schema.ExclusiveMinimum)
+		assert.True(t, param.Schema.ExclusiveMaximum)
+	})
+
+	t.Run("should reject unknown options", func(t *testing.T) {
+		_, err := ParamFromStructTag(paramField(t, "Invalid"))
+		require.ErrorIs(t, err, ErrInvalidStructTag)
+	})
+
+	t.Run("should reject a length rule which allows no value", func(t *testing.T) {
+		_, err := ParamFromStructTag(paramField(t, "Empty"))
+		require.ErrorIs(t, err, ErrInvalidStructTag)
+	})
+
+	t.Run("should reject types which are not simple", func(t *testing.T) {
+		_, err := ParamFromStructTag(paramField(t, "Bad"))
+		require.ErrorIs(t, err, ErrUnsupportedGoType)
+	})
+}
+
+type petTag struct {
+	Name string `json:"name"`
+}
+
+type petNode struct {
+	petTag
+
+	ID       int64             `json:"id"`
+	Nickname *string           `json:"nickname,omitempty"`
+	Tags     []petTag          `json:"tags,omitempty" validate:"max=10"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Photo    []byte            `json:"photo,omitempty"`
+	Children []*petNode        `json:"children,omitempty"`
+	Any      any               `json:"any,omitempty"`
+	Skipped  string            `json:"-"`
+	internal string            //nolint:unused // unexported fields are ignored
+}
+
+// SelfEmbedded embeds a pointer to itself, which encoding/json skips.
+type SelfEmbedded struct {
+	*SelfEmbedded
+
+	Name string `json:"name"`
+}
+
+type shadowBase struct {
+	ID    int
+	Code  string
+	Label string
+}
+
+type shadowOther struct {
+	Code  int `json:"Code"`
+	Label string
+}
+
+type shadowing struct {
+	shadowBase
+	shadowOther
+
+	ID string
+}
+
+func TestSchemaFromType(t *testing.T) {
+	t.Run("should describe a struct with nested slices and pointers", func(t *testing.T) {
+		sch, err := SchemaFromType(reflect.TypeOf(petNode{}))
+		require.NoError(t, err)
+
+		// the root type refers to itself: it is defined in $defs
+		assert.Equal(t, "#/$defs/petNode", sch.Ref.String())
+		require.Contains(t, sch.Defs, "petNode")
+		node := sch.Defs["petNode"]
+
+		assert.Equal(t, StringOrArray{"object"}, node.Type)
+		assert.Equal(t, []string{"name", "id"}, node.Required)
+		assert.NotContains(t, node.Properties, "Skipped")
+		assert.NotContains(t, node.Properties, "internal")
+
+		require.Contains(t, node.Properties, "name")
+		assert.Equal(t, StringOrArray{"string"}, node.Properties["name"].Type)
+
+		require.Contains(t, node.Properties, "nickname")
+		nickname := node.Properties["nickname"]
+		require.NotNil(t, nickname.Nullable)
+		assert.True(t, *nickname.Nullable)
+
+		require.Contains(t, node.Properties, "tags")
+		tags := node.Properties["tags"]
+		assert.Equal(t, StringOrArray{jsonArray}, tags.Type)
+		require.NotNil(t, tags.MaxItems)
+		assert.Equal(t, int64(10), *tags.MaxItems)
+		require.NotNil(t, tags.Items)
+		assert.Equal(t, []string{"name"}, tags.Items.Schema.Required)
+
+		require.Contains(t, node.Properties, "labels")
+		labels := node.Properties["labels"]
+		require.NotNil(t, labels.AdditionalProperties)
+		assert.Equal(t, StringOrArray{"string"}, labels.AdditionalProperties.Schema.Type)
+
+		assert.Equal(t, "byte", node.Properties["photo"].Format)
+		assert.Equal(t, Schema{}, node.Properties["any"])
+
+		require.Contains(t, node.Properties, "children")
+		children := node.Properties["children"]
+		require.NotNil(t, children.Items)
+		child := children.Items.Schema
+		require.NotNil(t, child.Nullable)
+		assert.True(t, *child.Nullable)
+		assert.Empty(t, child.Ref.String())
+		require.Len(t, child.AllOf, 1)
+		assert.Equal(t, "#/$defs/petNode", child.AllOf[0].Ref.String())
+	})
+
+	t.Run("expanding the self-referencing schema should stop at the circular $ref", func(t *testing.T) {
+		sch, err := SchemaFromType(reflect.TypeOf(petNode{}))
+		require.NoError(t, err)
+
+		require.NoError(t, ExpandSchema(sch, nil, nil))
+		assert.Equal(t, []string{"name", "id"}, sch.Required)
+		assert.Equal(t, "#/$defs/petNode", sch.Properties["children"].Items.Schema.AllOf[0].Ref.String())
+	})
+
+	t.Run("should inline types which do not refer to themselves", func(t *testing.T) {
+		sch, err := SchemaFromType(reflect.TypeOf(&petTag{}))
+		require.NoError(t, err)
+
+		assert.Empty(t, sch.Defs)
+		assertSerializeJSON(t, sch,
+			`{"type":"object","nullable":true,"required":["name"],"properties":{"name":{"type":"string"}}}`)
+	})
+
+	t.Run("should skip embedded structs which are already visited", func(t *testing.T) {
+		sch, err := SchemaFromType(reflect.TypeOf(SelfEmbedded{}))
+		require.NoError(t, err)
+
+		assertSerializeJSON(t, sch, `{"type":"object","required":["name"],"properties":{"name":{"type":"string"}}}`)
+	})
+
+	t.Run("should apply the shadowing rules of encoding/json to embedded fields", func(t *testing.T) {
+		sch, err := SchemaFromType(reflect.TypeOf(shadowing{}))
+		require.NoError(t, err)
+
+		data, err := json.Marshal(shadowing{})
+		require.NoError(t, err)
+		var marshaled map[string]any
+		require.NoError(t, json.Unmarshal(data, &marshaled))
+
+		assert.ElementsMatch(t, slices.Collect(maps.Keys(marshaled)), slices.Collect(maps.Keys(sch.Properties)))
+		assert.Equal(t, []string{"Code", "ID"}, sch.Required)
+		assert.Equal(t, StringOrArray{"string"}, sch.Properties["ID"].Type, "the shallowest field should win")
+		assert.Equal(t, StringOrArray{"integer"}, sch.Properties["Code"].Type, "the tagged field should win")
+		assert.NotContains(t, sch.Properties, "Label", "ambiguous fields should be dropped")
+	})
+
+	t.Run("should reject unsupported types", func(t *testing.T) {
+		_, err := SchemaFromType(reflect.TypeOf(map[int]string{}))
+		require.ErrorIs(t, err, ErrUnsupportedGoType)
+
+		_, err = SchemaFromType(reflect.TypeOf(make(chan int)))
+		require.ErrorIs(t, err, ErrUnsupportedGoType)
+	})
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// componentKinds are the JSON names of the sections of components, e.g. "schemas" or "responses".
+var componentKinds = componentSections(reflect.TypeFor[ComponentsProps]())
+
+// legacySections locate the components of Swagger 2.0 documents, which have no components object.
+var legacySections = map[string]string{
+	"schemas":         "definitions",
+	"parameters":      "parameters",
+	"responses":       "responses",
+	"securitySchemes": "securityDefinitions",
+}
+
+func componentSections(typ reflect.Type) []string {
+	kinds := make([]string, 0, typ.NumField())
+	for i := range typ.NumField() {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		kinds = append(kinds, name)
+	}
+
+	return kinds
+}
+
+// RenameComponent renames a component, and rewrites all the local $ref's targeting it, or its nested values,
+// throughout the document.
+//
+// kind is the section of the components holding it, e.g. "schemas", "responses" or "securitySchemes".
+// With Swagger 2.0 documents, without components, schemas, parameters, responses and security schemes
+// are renamed in definitions, parameters, responses and securityDefinitions.
+// Security requirements refer to security schemes by name, and discriminator mappings may refer to schemas
+// by name: these are renamed as well.
+//
+// A missing component produces an error which matches ErrComponentNotFound, and a newName which is already
+// taken an error which matches ErrComponentExists. The document is left untouched on error.
+func (s *Swagger) RenameComponent(kind, oldName, newName string) error {
+	if !slices.Contains(componentKinds, kind) {
+		return fmt.Errorf("unknown kind of components %q: %w", kind, ErrComponentNotFound)
+	}
+	if oldName == newName {
+		return nil
+	}
+
+	section := pointerTo("components", kind)
+	if s.Components == nil {
+		if legacy, ok := legacySections[kind]; ok {
+			section = pointerTo(legacy)
+		}
+	}
+
+	return s.rewriteGeneric(func(doc any) error {
+		components, _ := lookupGeneric(doc, section).(map[string]any)
+		component, ok := components[oldName]
+		if !ok {
+			return fmt.Errorf("%s %q: %w", kind, oldName, ErrComponentNotFound)
+		}
+		if _, taken := components[newName]; taken {
+			return fmt.Errorf("%s %q: %w", kind, newName, ErrComponentExists)
+		}
+
+		delete(components, oldName)
+		components[newName] = component
+
+		renames := map[string]string{oldName: newName}
+		rewriteRefs(doc, componentRefRewriter(section, renames))
+
+		root, _ := doc.(map[string]any)
+		switch kind {
+		case "securitySchemes":
+			renameSecurityRequirements(root["security"], oldName, newName)
+			genericWalker{operation: func(op map[string]any, _ string) {
+				renameSecurityRequirements(op["security"], oldName, newName)
+			}}.document(root)
+		case "schemas":
+			rewrite := componentRefRewriter(section, renames)
+			genericWalker{schema: func(schema map[string]any, _ string) {
+				discriminator, _ := schema["discriminator"].(map[string]any)
+				mapping, _ := discriminator["mapping"].(map[string]any)
+				for value, target := range mapping {
+					switch target, _ := target.(string); target {
+					case "":
+					case oldName:
+						mapping[value] = newName
+					default:
+						mapping[value] = rewrite(target)
+					}
+				}
+			}}.document(root)
+		}
+
+		return nil
+	})
+}
+
+func renameSecurityRequirements(security any, oldName, newName string) {
+	requirements, _ := security.([]any)
+	for _, requirement := range requirements {
+		schemes, _ := requirement.(map[string]any)
+		if scopes, ok := schemes[oldName]; ok {
+			delete(schemes, oldName)
+			schemes[newName] = scopes
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSwagger_RenameComponent(t *testing.T) {
+	const doc = `{
+		"openapi": "3.1.0",
+		"security": [{"apiKey": []}],
+		"components": {
+			"schemas": {
+				"Pet": {
+					"type": "object",
+					"properties": {"name": {"type": "string"}},
+					"discriminator": {"propertyName": "kind", "mapping": {"pet": "Pet", "cat": "#/components/schemas/Cat"}}
+				},
+				"Cat": {"allOf": [{"$ref": "#/components/schemas/Pet"}]},
+				"Owner": {"type": "object"}
+			},
+			"securitySchemes": {"apiKey": {"type": "apiKey", "name": "X-API-Key", "in": "header"}}
+		},
+		"paths": {
+			"/pets": {
+				"get": {
+					"security": [{"apiKey": []}],
+					"responses": {"200": {"content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}}}
+				},
+				"post": {
+					"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+					"responses": {"201": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet/properties/name"}}}}}
+				}
+			}
+		}
+	}`
+
+	load := func(t *testing.T) *Swagger {
+		t.Helper()
+
+		var spec Swagger
+		require.NoError(t, json.Unmarshal([]byte(doc), &spec))
+
+		return &spec
+	}
+
+	t.Run("should rename a schema and rewrite its $ref's", func(t *testing.T) {
+		spec := load(t)
+		require.NoError(t, spec.RenameComponent("schemas", "Pet", "Animal"))
+
+		assert.NotContains(t, spec.Components.Schemas, "Pet")
+		require.Contains(t, spec.Components.Schemas, "Animal")
+
+		get := spec.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Content["application/json"].Schema
+		assert.Equal(t, "#/components/schemas/Animal", get.Items.Schema.Ref.String())
+
+		post := spec.Paths.Paths["/pets"].Post
+		assert.Equal(t, "#/components/schemas/Animal", post.RequestBody.Content["application/json"].Schema.Ref.String())
+		created := post.Responses.StatusCodeResponses[201].Content["application/json"].Schema
+		assert.Equal(t, "#/components/schemas/Animal/properties/name", created.Ref.String())
+
+		cat := spec.Components.Schemas["Cat"]
+		assert.Equal(t, "#/components/schemas/Animal", cat.AllOf[0].Ref.String())
+
+		animal := spec.Components.Schemas["Animal"]
+		assert.Equal(t, map[string]string{"pet": "Animal", "cat": "#/components/schemas/Cat"}, animal.Discriminator.Mapping)
+	})
+
+	t.Run("should rename a security scheme in security requirements", func(t *testing.T) {
+		spec := load(t)
+		require.NoError(t, spec.RenameComponent("securitySchemes", "apiKey", "key"))
+
+		assert.Contains(t, spec.Components.SecuritySchemes, "key")
+		assert.Equal(t, []map[string][]string{{"key": {}}}, spec.Security)
+		assert.Equal(t, []map[string][]string{{"key": {}}}, spec.Paths.Paths["/pets"].Get.Security)
+	})
+
+	t.Run("should leave examples holding a $ref key untouched", func(t *testing.T) {
+		var spec Swagger
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"openapi": "3.1.0",
+			"components": {
+				"schemas": {
+					"Pet": {"type": "object"},
+					"Link": {
+						"type": "object",
+						"properties": {
+							"default": {"$ref": "#/components/schemas/Pet"},
+							"target": {"type": "object", "example": {"$ref": "#/components/schemas/Pet"}}
+						},
+						"default": {"$ref": "#/components/schemas/Pet"},
+						"examples": [{"$ref": "#/components/schemas/Pet"}]
+					}
+				},
+				"examples": {
+					"link": {"value": {"$ref": "#/components/schemas/Pet"}}
+				}
+			}
+		}`), &spec))
+		require.NoError(t, spec.RenameComponent("schemas", "Pet", "Animal"))
+
+		link := spec.Components.Schemas["Link"]
+		payload := map[string]any{"$ref": "#/components/schemas/Pet"}
+		property := link.Properties["default"]
+		assert.Equal(t, "#/components/schemas/Animal", property.Ref.String())
+		assert.Equal(t, payload, link.Properties["target"].Example)
+		assert.Equal(t, payload, link.Default)
+		assert.Equal(t, []any{payload}, link.ExtraProps["examples"])
+		assert.Equal(t, payload, spec.Components.Examples["link"].Value)
+	})
+
+	t.Run("should fail when the new name is taken", func(t *testing.T) {
+		spec := load(t)
+		require.ErrorIs(t, spec.RenameComponent("schemas", "Pet", "Owner"), ErrComponentExists)
+
+		assert.Contains(t, spec.Components.Schemas, "Pet", "the document should be left untouched")
+	})
+
+	t.Run("should fail on a missing component", func(t *testing.T) {
+		require.ErrorIs(t, load(t).RenameComponent("schemas", "Dog", "Hound"), ErrComponentNotFound)
+		require.ErrorIs(t, load(t).RenameComponent("widgets", "Pet", "Animal"), ErrComponentNotFound)
+	})
+}
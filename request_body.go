@@ -5,6 +5,8 @@ package spec
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	"github.com/go-openapi/jsonpointer"
 	"github.com/go-openapi/swag/jsonutils"
@@ -62,3 +64,104 @@ func (r *RequestBody) UnmarshalJSON(data []byte) error {
 	}
 	return json.Unmarshal(data, &r.VendorExtensible)
 }
+
+// IsRequired tells if the request body must be sent by clients.
+//
+// As specified by OpenAPI 3.x, a request body is optional unless explicitly flagged as required.
+func (r RequestBody) IsRequired() bool {
+	return r.Required
+}
+
+// AsRequired flags this request body as required
+func (r *RequestBody) AsRequired() *RequestBody {
+	r.Required = true
+	return r
+}
+
+// AsOptional flags this request body as optional
+func (r *RequestBody) AsOptional() *RequestBody {
+	r.Required = false
+	return r
+}
+
+// ValidateRequestBodies reports GET and DELETE operations declaring a required request body.
+//
+// Such request bodies have no defined semantics in HTTP and are discouraged by OpenAPI 3.x,
+// since clients and proxies may drop them. They are reported as warnings, since they are not invalid.
+//
+// Request bodies defined by a local $ref are resolved against the document; other $ref's are ignored.
+// Each offending operation is located by a JSON pointer.
+func (s *Swagger) ValidateRequestBodies() []Warning {
+	var warnings []Warning
+
+	validate := func(key OperationKey, op *Operation) {
+		if op.RequestBody == nil || (key.Method != "get" && key.Method != "delete") {
+			return
+		}
+
+		body := *op.RequestBody
+		if body.Ref.String() != "" {
+			if !body.Ref.HasFragmentOnly {
+				return
+			}
+
+			resolved, err := ResolveRequestBody(s, body.Ref)
+			if err != nil {
+				return
+			}
+			body = *resolved
+		}
+
+		if !body.IsRequired() {
+			return
+		}
+
+		warnings = append(warnings, Warning{
+			Path:    key.Pointer() + pointerTo("requestBody"),
+			Message: fmt.Sprintf("a required request body is discouraged for %s operations", strings.ToUpper(key.Method)),
+		})
+	}
+
+	for key, op := range s.Operations() {
+		validate(key, op)
+	}
+
+	for key, op := range s.WebhookOperations() {
+		validate(key, op)
+	}
+
+	return warnings
+}
+
+// MultipartFileUpload builds a required multipart/form-data request body, to upload a file along with extra fields.
+//
+// The file field is a binary string, which OpenAPI 3.0 and 3.1 both understand, sent as application/octet-stream.
+// Extra fields are sent as text/plain, unless their schema is an object or an array, sent as application/json.
+// A nil schema for an extra field stands for a string.
+func MultipartFileUpload(fileField string, extraFields map[string]*Schema) *RequestBody {
+	form := new(Schema).Typed("object", "").
+		SetProperty(fileField, *StrFmtProperty("binary")).
+		WithRequired(fileField)
+	encoding := map[string]Encoding{
+		fileField: {EncodingProps: EncodingProps{ContentType: "application/octet-stream"}},
+	}
+
+	for name, schema := range extraFields {
+		if schema == nil {
+			schema = StringProperty()
+		}
+		form.SetProperty(name, *schema)
+
+		contentType := "text/plain"
+		if schema.Type.Contains("object") || schema.Type.Contains("array") {
+			contentType = "application/json"
+		}
+		encoding[name] = Encoding{EncodingProps: EncodingProps{ContentType: contentType}}
+	}
+
+	body := &RequestBody{RequestBodyProps: RequestBodyProps{Content: map[string]MediaType{
+		"multipart/form-data": {MediaTypeProps: MediaTypeProps{Schema: form, Encoding: encoding}},
+	}}}
+
+	return body.AsRequired()
+}
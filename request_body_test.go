@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestRequestBody_IsRequired(t *testing.T) {
+	t.Run("should be optional by default", func(t *testing.T) {
+		var body RequestBody
+		require.NoError(t, json.Unmarshal([]byte(`{"content":{"application/json":{}}}`), &body))
+
+		assert.False(t, body.IsRequired())
+	})
+
+	t.Run("should toggle required with builders", func(t *testing.T) {
+		body := new(RequestBody).AsRequired()
+		assert.True(t, body.IsRequired())
+		assertSerializeJSON(t, body, `{"content":null,"required":true}`)
+
+		body.AsOptional()
+		assert.False(t, body.IsRequired())
+		assertSerializeJSON(t, body, `{"content":null}`)
+	})
+}
+
+func TestSwagger_ValidateRequestBodies(t *testing.T) {
+	var doc Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"requestBodies": {
+				"Filter": {"required": true, "content": {"application/json": {}}}
+			}
+		},
+		"paths": {
+			"/pets": {
+				"get": {"requestBody": {"required": true, "content": {"application/json": {}}}},
+				"post": {"requestBody": {"required": true, "content": {"application/json": {}}}}
+			},
+			"/pets/{id}": {
+				"get": {"requestBody": {"content": {"application/json": {}}}},
+				"delete": {"requestBody": {"$ref": "#/components/requestBodies/Filter"}}
+			}
+		}
+	}`), &doc))
+
+	warnings := doc.ValidateRequestBodies()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, `/paths/~1pets/get/requestBody: a required request body is discouraged for GET operations`, warnings[0].String())
+	assert.Equal(t, `/paths/~1pets~1{id}/delete/requestBody: a required request body is discouraged for DELETE operations`, warnings[1].String())
+}
+
+func TestMultipartFileUpload(t *testing.T) {
+	body := MultipartFileUpload("file", map[string]*Schema{"comment": StringProperty()})
+
+	assert.True(t, body.IsRequired())
+	assertSerializeJSON(t, body, `{"content":{"multipart/form-data":{`+
+		`"schema":{"type":"object","required":["file"],"properties":{`+
+		`"comment":{"type":"string"},"file":{"type":"string","format":"binary"}}},`+
+		`"encoding":{"comment":{"contentType":"text/plain"},"file":{"contentType":"application/octet-stream"}}}},`+
+		`"required":true}`)
+
+	t.Run("should encode structured fields as JSON", func(t *testing.T) {
+		body := MultipartFileUpload("avatar", map[string]*Schema{
+			"tags":  ArrayProperty(StringProperty()),
+			"title": nil,
+		})
+
+		media := body.Content["multipart/form-data"]
+		require.NotNil(t, media.Schema)
+		assert.Equal(t, StringOrArray{"string"}, media.Schema.Properties["title"].Type)
+		assert.Equal(t, "application/json", media.Encoding["tags"].ContentType)
+		assert.Equal(t, "text/plain", media.Encoding["title"].ContentType)
+	})
+}
@@ -9,6 +9,44 @@ import (
 	"github.com/go-openapi/swag/jsonutils"
 )
 
+// followRefs follows a chain of $ref's against root, from an object to its target, then to the target
+// of this target, and so on, until an object without $ref.
+//
+// refOf yields the $ref of an object and resolve fetches its target, e.g. ResolveHeader. Each target is
+// passed to visit, when not nil, e.g. to retain the fields set next to a $ref. A $ref met twice
+// yields a *CircularReferenceError.
+func followRefs[T any](object T, root any, refOf func(*T) *Ref, resolve func(any, Ref) (*T, error), visit func(*T)) (*T, error) {
+	seen := make(map[string]struct{})
+	for ref := refOf(&object); ref.String() != ""; ref = refOf(&object) {
+		key := ref.String()
+		if _, isCircular := seen[key]; isCircular {
+			return nil, &CircularReferenceError{Ref: key}
+		}
+		seen[key] = struct{}{}
+
+		resolved, err := resolve(root, *ref)
+		if err != nil {
+			return nil, err
+		}
+
+		object = *resolved
+		if visit != nil {
+			visit(&object)
+		}
+	}
+
+	return &object, nil
+}
+
+func schemaRefOf(schema *Schema) *Ref {
+	return &schema.Ref
+}
+
+// resolveSchemaRef is ResolveRef, in the form expected by followRefs.
+func resolveSchemaRef(root any, ref Ref) (*Schema, error) {
+	return ResolveRef(root, &ref)
+}
+
 func resolveAnyWithBase(root any, ref *Ref, result any, options *ExpandOptions) error {
 	options = optionsOrDefault(options)
 	resolver := defaultSchemaLoader(root, options, nil, nil)
@@ -38,7 +76,7 @@ func ResolveRefWithBase(root any, ref *Ref, options *ExpandOptions) (*Schema, er
 func ResolveRef(root any, ref *Ref) (*Schema, error) {
 	res, _, err := ref.GetPointer().Get(root)
 	if err != nil {
-		return nil, err
+		return nil, &RefNotFoundError{Ref: ref.String(), Err: err}
 	}
 
 	switch sch := res.(type) {
@@ -90,6 +128,54 @@ func ResolveResponse(root any, ref Ref) (*Response, error) {
 	return ResolveResponseWithBase(root, ref, nil)
 }
 
+// ResolveRequestBodyWithBase resolves a request body reference against a context root and base path
+func ResolveRequestBodyWithBase(root any, ref Ref, options *ExpandOptions) (*RequestBody, error) {
+	result := new(RequestBody)
+
+	if err := resolveAnyWithBase(root, &ref, result, options); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ResolveRequestBody resolves a request body reference against a context root
+func ResolveRequestBody(root any, ref Ref) (*RequestBody, error) {
+	return ResolveRequestBodyWithBase(root, ref, nil)
+}
+
+// ResolveHeaderWithBase resolves a header reference against a context root and base path
+func ResolveHeaderWithBase(root any, ref Ref, options *ExpandOptions) (*Header, error) {
+	result := new(Header)
+
+	if err := resolveAnyWithBase(root, &ref, result, options); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ResolveHeader resolves a header reference against a context root
+func ResolveHeader(root any, ref Ref) (*Header, error) {
+	return ResolveHeaderWithBase(root, ref, nil)
+}
+
+// ResolveExampleWithBase resolves an example reference against a context root and base path
+func ResolveExampleWithBase(root any, ref Ref, options *ExpandOptions) (*Example, error) {
+	result := new(Example)
+
+	if err := resolveAnyWithBase(root, &ref, result, options); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ResolveExample resolves an example reference against a context root
+func ResolveExample(root any, ref Ref) (*Example, error) {
+	return ResolveExampleWithBase(root, ref, nil)
+}
+
 // ResolvePathItemWithBase resolves response a path item against a context root and base path
 func ResolvePathItemWithBase(root any, ref Ref, options *ExpandOptions) (*PathItem, error) {
 	result := new(PathItem)
@@ -324,6 +324,54 @@ func TestResolveLocalRef_FromInvalidFragment(t *testing.T) {
 	require.Error(t, resolver.Resolve(&ref, &tgt, ""))
 }
 
+func TestResolveLocalRef_EscapedTokens(t *testing.T) {
+	rootDoc := new(Swagger)
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"foo/bar": {"type": "string"},
+				"a~b": {"type": "integer"},
+				"Pet": {
+					"properties": {
+						"slash": {"$ref": "#/components/schemas/foo~1bar"},
+						"tilde": {"$ref": "#/components/schemas/a~0b"}
+					}
+				}
+			}
+		}
+	}`), rootDoc))
+
+	t.Run("should unescape ~1 and ~0 before lookup", func(t *testing.T) {
+		for ref, expected := range map[string]string{
+			"#/components/schemas/foo~1bar": "string",
+			"#/components/schemas/a~0b":     "integer",
+		} {
+			r := MustCreateRef(ref)
+			sch, err := ResolveRef(rootDoc, &r)
+			require.NoError(t, err)
+			assert.Equal(t, StringOrArray{expected}, sch.Type)
+		}
+	})
+
+	t.Run("should not take an unescaped slash for a key", func(t *testing.T) {
+		r := MustCreateRef("#/components/schemas/foo/bar")
+		_, err := ResolveRef(rootDoc, &r)
+		var notFound *RefNotFoundError
+		require.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("should expand $ref's to escaped keys", func(t *testing.T) {
+		var expanded Swagger
+		require.NoError(t, json.Unmarshal([]byte(asJSON(t, rootDoc)), &expanded))
+		require.NoError(t, ExpandSpec(&expanded, &ExpandOptions{PreserveNameAsTitle: true}))
+
+		pet := expanded.Components.Schemas["Pet"]
+		assert.Equal(t, "foo/bar", pet.Properties["slash"].Title)
+		assert.Equal(t, "a~b", pet.Properties["tilde"].Title)
+	})
+}
+
 func TestResolveLocalRef_Parameter(t *testing.T) {
 	rootDoc := new(Swagger)
 	b, err := os.ReadFile(filepath.Join(specs, "refed.json"))
@@ -423,3 +471,84 @@ func TestResolveExtraItem(t *testing.T) {
 	// In OpenAPI 3, parameters use schema instead of items
 	t.Skip("Test uses Swagger 2.0 specific features not available in OpenAPI 3")
 }
+
+func TestResolveRef_TypedErrors(t *testing.T) {
+	t.Run("a missing local $ref should yield a RefNotFoundError", func(t *testing.T) {
+		doc := new(Swagger)
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"openapi": "3.1.0",
+			"components": {
+				"schemas": {
+					"pet": {"properties": {"tag": {"$ref": "#/components/schemas/tag"}}}
+				}
+			}
+		}`), doc))
+
+		err := ExpandSpec(doc, nil)
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrSpec)
+
+		var notFound *RefNotFoundError
+		require.ErrorAs(t, err, &notFound)
+		assert.Equal(t, "#/components/schemas/tag", notFound.Ref)
+		assert.Equal(t, "/components/schemas/tag", notFound.Pointer())
+
+		var specErr SpecError
+		require.ErrorAs(t, err, &specErr)
+		assert.Equal(t, "/components/schemas/tag", specErr.Pointer())
+	})
+
+	t.Run("resolving a missing $ref against a root should yield a RefNotFoundError", func(t *testing.T) {
+		ref := MustCreateRef("#/definitions/NotThere")
+
+		_, err := ResolveRef(map[string]any{"definitions": map[string]any{}}, &ref)
+
+		var notFound *RefNotFoundError
+		require.ErrorAs(t, err, &notFound)
+		assert.Equal(t, "/definitions/NotThere", notFound.Pointer())
+	})
+
+	t.Run("a missing document should yield a RemoteFetchError", func(t *testing.T) {
+		sch := RefSchema("fixtures/specs/no-such-file.json#/definitions/pet")
+
+		err := ExpandSchemaWithBasePath(sch, nil, nil)
+
+		var fetchErr *RemoteFetchError
+		require.ErrorAs(t, err, &fetchErr)
+		assert.Contains(t, fetchErr.URL, "no-such-file.json")
+		assert.Empty(t, fetchErr.Pointer())
+		require.ErrorIs(t, err, os.ErrNotExist)
+		require.ErrorIs(t, err, ErrSpec)
+	})
+
+	t.Run("an invalid document should yield a ParseError", func(t *testing.T) {
+		sch := RefSchema("invalid.json#/definitions/pet")
+
+		err := ExpandSchemaWithBasePath(sch, nil, &ExpandOptions{
+			PathLoader: func(string) (json.RawMessage, error) {
+				return json.RawMessage(`{"definitions":`), nil
+			},
+		})
+
+		var parseErr *ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Contains(t, parseErr.Document, "invalid.json")
+		require.ErrorIs(t, err, ErrSpec)
+	})
+
+	t.Run("a self-referencing allOf should yield a CircularReferenceError", func(t *testing.T) {
+		root := map[string]any{
+			"definitions": map[string]any{
+				"loop": map[string]any{"allOf": []any{map[string]any{"$ref": "#/definitions/loop"}}},
+			},
+		}
+		schema := Schema{SchemaProps: SchemaProps{AllOf: []Schema{*RefSchema("#/definitions/loop")}}}
+
+		_, err := schema.MergeAllOf(root)
+
+		var circular *CircularReferenceError
+		require.ErrorAs(t, err, &circular)
+		assert.Equal(t, "/definitions/loop", circular.Pointer())
+		require.ErrorIs(t, err, ErrMergeConflict)
+	})
+}
@@ -104,6 +104,28 @@ func (r Response) MarshalJSON() ([]byte, error) {
 	return jsonutils.ConcatJSON(b1, b2, b3), nil
 }
 
+// Resolve returns the response targeted by the $ref of this response, resolved against root.
+//
+// Chains of $ref's are followed. As allowed by OpenAPI 3.1, a description set next to a $ref
+// overrides the description of its target. The headers and content of the target are returned
+// as defined, with their own $ref's left unresolved. A response without $ref is returned as is.
+func (r Response) Resolve(root any) (*Response, error) {
+	description := r.Description
+
+	resolved, err := followRefs(r, root, func(r *Response) *Ref { return &r.Ref }, ResolveResponse, func(target *Response) {
+		if description == "" {
+			description = target.Description
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolved.Description = description
+
+	return resolved, nil
+}
+
 // WithDescription sets the description on this response, allows for chaining
 func (r *Response) WithDescription(description string) *Response {
 	r.Description = description
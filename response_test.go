@@ -115,3 +115,90 @@ func TestResponseBuild(t *testing.T) {
          }
 			 }`, string(jazon))
 }
+
+func TestResponse_Resolve(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"paths": {
+			"/pets/{id}": {
+				"get": {
+					"responses": {
+						"200": {"description": "ok"},
+						"404": {"$ref": "#/components/responses/NotFound"}
+					}
+				}
+			}
+		},
+		"components": {
+			"responses": {
+				"NotFound": {"$ref": "#/components/responses/Problem"},
+				"Problem": {
+					"description": "problem",
+					"headers": {
+						"X-Request-Id": {"$ref": "#/components/headers/RequestId"},
+						"X-Retry-After": {"schema": {"type": "integer"}}
+					},
+					"content": {
+						"application/problem+json": {"schema": {"$ref": "#/components/schemas/Problem"}}
+					}
+				}
+			},
+			"headers": {
+				"RequestId": {"description": "request id", "schema": {"type": "string"}}
+			},
+			"schemas": {
+				"Problem": {"type": "object", "properties": {"title": {"type": "string"}}}
+			}
+		}
+	}`), &root))
+	responses := root.Paths.Paths["/pets/{id}"].Get.Responses
+
+	t.Run("should resolve a chain of response $ref's", func(t *testing.T) {
+		resolved, err := responses.StatusCodeResponses[404].Resolve(&root)
+		require.NoError(t, err)
+
+		assert.Equal(t, "problem", resolved.Description)
+		assert.Empty(t, resolved.Ref.String())
+		require.Contains(t, resolved.Headers, "X-Request-Id")
+		header := resolved.Headers["X-Request-Id"]
+		assert.Equal(t, "#/components/headers/RequestId", header.Ref.String())
+		require.Contains(t, resolved.Content, "application/problem+json")
+	})
+
+	t.Run("should override the description of the target", func(t *testing.T) {
+		response := *ResponseRef("#/components/responses/NotFound").WithDescription("pet not found")
+
+		resolved, err := response.Resolve(&root)
+		require.NoError(t, err)
+		assert.Equal(t, "pet not found", resolved.Description)
+	})
+
+	t.Run("should return a response without $ref as is", func(t *testing.T) {
+		resolved, err := responses.StatusCodeResponses[200].Resolve(&root)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", resolved.Description)
+	})
+
+	t.Run("should fail on a circular $ref", func(t *testing.T) {
+		circular := root
+		circular.Components = &Components{ComponentsProps: ComponentsProps{
+			Responses: map[string]Response{"Loop": *ResponseRef("#/components/responses/Loop")},
+		}}
+
+		_, err := ResponseRef("#/components/responses/Loop").Resolve(&circular)
+		var cycle *CircularReferenceError
+		require.ErrorAs(t, err, &cycle)
+	})
+
+	t.Run("should expand the nested header and content $ref's", func(t *testing.T) {
+		var expanded Swagger
+		require.NoError(t, json.Unmarshal([]byte(asJSON(t, &root)), &expanded))
+		require.NoError(t, ExpandSpec(&expanded, &ExpandOptions{Targets: TargetAll}))
+
+		notFound := expanded.Paths.Paths["/pets/{id}"].Get.Responses.StatusCodeResponses[404]
+		assert.Empty(t, notFound.Ref.String())
+		assert.Equal(t, "request id", notFound.Headers["X-Request-Id"].Description)
+		assert.Contains(t, notFound.Content["application/problem+json"].Schema.Properties, "title")
+	})
+}
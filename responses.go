@@ -44,6 +44,11 @@ func (r Responses) JSONLookup(token string) (any, error) {
 			return scr, nil
 		}
 	}
+	if class, ok := statusClass(token); ok {
+		if scr, ok := r.StatusRangeResponses[class]; ok {
+			return scr, nil
+		}
+	}
 	return nil, fmt.Errorf("object has no field %q: %w", token, ErrSpec)
 }
 
@@ -76,12 +81,31 @@ func (r Responses) MarshalJSON() ([]byte, error) {
 	return concated, nil
 }
 
+// Match returns the response expected for a HTTP status code.
+//
+// The response for the exact code is preferred, then the response for the range of the code, such as "4XX" for 418,
+// then the default response. It returns nil when no response matches.
+func (r Responses) Match(code int) *Response {
+	if response, ok := r.StatusCodeResponses[code]; ok {
+		return &response
+	}
+
+	if response, ok := r.StatusRangeResponses[code/100]; ok && code >= 100 && code < 600 {
+		return &response
+	}
+
+	return r.Default
+}
+
 // ResponsesProps describes all responses for an operation.
 // It tells what is the default response and maps all responses with a
 // HTTP status code.
+//
+// Responses for a range of status codes, such as "4XX", are keyed by the class of the range, e.g. 4.
 type ResponsesProps struct {
-	Default             *Response
-	StatusCodeResponses map[int]Response
+	Default              *Response
+	StatusCodeResponses  map[int]Response
+	StatusRangeResponses map[int]Response
 }
 
 // MarshalJSON marshals responses as JSON
@@ -93,6 +117,9 @@ func (r ResponsesProps) MarshalJSON() ([]byte, error) {
 	for k, v := range r.StatusCodeResponses {
 		toser[strconv.Itoa(k)] = v
 	}
+	for k, v := range r.StatusRangeResponses {
+		toser[statusRange(k)] = v
+	}
 	return json.Marshal(toser)
 }
 
@@ -122,8 +149,27 @@ func (r *ResponsesProps) UnmarshalJSON(data []byte) error {
 					r.StatusCodeResponses = map[int]Response{}
 				}
 				r.StatusCodeResponses[nk] = statusCodeResp
+			} else if class, ok := statusClass(k); ok {
+				if r.StatusRangeResponses == nil {
+					r.StatusRangeResponses = map[int]Response{}
+				}
+				r.StatusRangeResponses[class] = statusCodeResp
 			}
 		}
 	}
 	return nil
 }
+
+// statusClass parses a range of status codes, from "1XX" to "5XX".
+func statusClass(key string) (int, bool) {
+	if len(key) != 3 || key[0] < '1' || key[0] > '5' || !strings.EqualFold(key[1:], "XX") {
+		return 0, false
+	}
+
+	return int(key[0] - '0'), true
+}
+
+// statusRange is the key of the range of status codes of a class.
+func statusRange(class int) string {
+	return strconv.Itoa(class) + "XX"
+}
@@ -129,3 +129,72 @@ func TestResponsesBuild(t *testing.T) {
          }
 			 }`, string(jazon))
 }
+
+func TestResponses_StatusRanges(t *testing.T) {
+	var responses Responses
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"default": {"description": "unexpected"},
+		"200": {"description": "ok"},
+		"418": {"description": "teapot"},
+		"4XX": {"description": "client error"},
+		"5xx": {"description": "server error"},
+		"6XX": {"description": "not a range"}
+	}`), &responses))
+
+	t.Run("should store range keys", func(t *testing.T) {
+		assert.Len(t, responses.StatusCodeResponses, 2)
+		require.Len(t, responses.StatusRangeResponses, 2)
+		assert.Equal(t, "client error", responses.StatusRangeResponses[4].Description)
+		assert.Equal(t, "server error", responses.StatusRangeResponses[5].Description)
+
+		res, err := responses.JSONLookup("4XX")
+		require.NoError(t, err)
+		assert.Equal(t, responses.StatusRangeResponses[4], res)
+	})
+
+	t.Run("should round-trip range keys", func(t *testing.T) {
+		jazon, err := json.Marshal(responses)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"default": {"description": "unexpected"},
+			"200": {"description": "ok"},
+			"418": {"description": "teapot"},
+			"4XX": {"description": "client error"},
+			"5XX": {"description": "server error"}
+		}`, string(jazon))
+	})
+
+	t.Run("should match the exact code, then the range, then the default", func(t *testing.T) {
+		for code, expected := range map[int]string{
+			200: "ok",
+			418: "teapot",
+			404: "client error",
+			503: "server error",
+			302: "unexpected",
+		} {
+			response := responses.Match(code)
+			require.NotNil(t, response)
+			assert.Equal(t, expected, response.Description, code)
+		}
+
+		ranges := Responses{ResponsesProps: ResponsesProps{StatusRangeResponses: map[int]Response{4: *NewResponse().WithDescription("client error")}}}
+		response := ranges.Match(418)
+		require.NotNil(t, response)
+		assert.Equal(t, "client error", response.Description)
+
+		assert.Nil(t, ranges.Match(200))
+		assert.Nil(t, ranges.Match(40))
+	})
+
+	t.Run("should prefer the 2XX range for success", func(t *testing.T) {
+		op := Operation{OperationProps: OperationProps{Responses: &Responses{ResponsesProps: ResponsesProps{
+			StatusRangeResponses: map[int]Response{2: *NewResponse().WithDescription("success")},
+			Default:              NewResponse().WithDescription("unexpected"),
+		}}}}
+
+		response, code, ok := op.SuccessResponse()
+		require.True(t, ok)
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "success", response.Description)
+	})
+}
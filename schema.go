@@ -4,8 +4,12 @@
 package spec
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/go-openapi/jsonpointer"
@@ -100,6 +104,45 @@ func ArrayProperty(items *Schema) *Schema {
 	return &Schema{SchemaProps: SchemaProps{Items: &SchemaOrArray{Schema: items}, Type: []string{"array"}}}
 }
 
+// NullProperty creates a property which only allows null (JSON Schema 2020-12)
+func NullProperty() *Schema {
+	return &Schema{SchemaProps: SchemaProps{Type: []string{"null"}}}
+}
+
+// TrueSchema creates a schema which matches any value, i.e. the empty schema.
+//
+// It is the equivalent of the "true" boolean schema of JSON Schema 2020-12, and is marshaled as true
+// unless it is modified.
+func TrueSchema() *Schema {
+	allows := true
+
+	return &Schema{boolean: &allows}
+}
+
+// FalseSchema creates a schema which matches no value, i.e. a schema which does not match the empty schema.
+//
+// It is the equivalent of the "false" boolean schema of JSON Schema 2020-12, and is marshaled as false
+// unless it is modified.
+func FalseSchema() *Schema {
+	allows := false
+
+	return &Schema{SchemaProps: SchemaProps{Not: &Schema{}}, boolean: &allows}
+}
+
+// booleanForm tells whether a schema is still one of the boolean schemas built by TrueSchema and FalseSchema.
+func (s Schema) booleanForm() (allows, ok bool) {
+	if s.boolean == nil {
+		return false, false
+	}
+
+	canonical := FalseSchema()
+	if *s.boolean {
+		canonical = TrueSchema()
+	}
+
+	return *s.boolean, reflect.DeepEqual(s, *canonical)
+}
+
 // ComposedSchema creates a schema with allOf
 func ComposedSchema(schemas ...Schema) *Schema {
 	s := new(Schema)
@@ -160,6 +203,8 @@ type SchemaProps struct {
 	ExclusiveMaximum     bool                    `json:"exclusiveMaximum,omitempty"`
 	Minimum              *float64                `json:"minimum,omitempty"`
 	ExclusiveMinimum     bool                    `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaxValue    *float64                `json:"-"` // JSON Schema 2020-12: numeric exclusiveMaximum
+	ExclusiveMinValue    *float64                `json:"-"` // JSON Schema 2020-12: numeric exclusiveMinimum
 	MaxLength            *int64                  `json:"maxLength,omitempty"`
 	MinLength            *int64                  `json:"minLength,omitempty"`
 	Pattern              string                  `json:"pattern,omitempty"`
@@ -168,10 +213,15 @@ type SchemaProps struct {
 	UniqueItems          bool                    `json:"uniqueItems,omitempty"`
 	MultipleOf           *float64                `json:"multipleOf,omitempty"`
 	Enum                 []any                   `json:"enum,omitempty"`
+	Const                any                     `json:"const,omitempty"` // JSON Schema 2020-12
+	HasConst             bool                    `json:"-"`               // JSON Schema 2020-12: const is set, even to null
 	MaxProperties        *int64                  `json:"maxProperties,omitempty"`
 	MinProperties        *int64                  `json:"minProperties,omitempty"`
 	Required             []string                `json:"required,omitempty"`
 	Items                *SchemaOrArray          `json:"items,omitempty"`
+	Contains             *Schema                 `json:"contains,omitempty"`    // JSON Schema 2020-12
+	MaxContains          *int64                  `json:"maxContains,omitempty"` // JSON Schema 2020-12
+	MinContains          *int64                  `json:"minContains,omitempty"` // JSON Schema 2020-12
 	AllOf                []Schema                `json:"allOf,omitempty"`
 	OneOf                []Schema                `json:"oneOf,omitempty"`
 	AnyOf                []Schema                `json:"anyOf,omitempty"`
@@ -179,6 +229,7 @@ type SchemaProps struct {
 	Properties           SchemaProperties        `json:"properties,omitempty"`
 	AdditionalProperties *SchemaOrBool           `json:"additionalProperties,omitempty"`
 	PatternProperties    PatternSchemaProperties `json:"patternProperties,omitempty"`
+	PropertyNames        *Schema                 `json:"propertyNames,omitempty"` // JSON Schema 2020-12
 	Dependencies         Dependencies            `json:"dependencies,omitempty"`
 	AdditionalItems      *SchemaOrBool           `json:"additionalItems,omitempty"`
 	Definitions          Definitions             `json:"definitions,omitempty"`
@@ -187,11 +238,13 @@ type SchemaProps struct {
 
 // SwaggerSchemaProps are additional properties supported by swagger schemas, but not JSON-schema (draft 4)
 type SwaggerSchemaProps struct {
-	Discriminator string                 `json:"discriminator,omitempty"`
+	Discriminator *Discriminator         `json:"discriminator,omitempty"`
 	ReadOnly      bool                   `json:"readOnly,omitempty"`
+	WriteOnly     bool                   `json:"writeOnly,omitempty"` // OpenAPI 3.x
 	XML           *XMLObject             `json:"xml,omitempty"`
 	ExternalDocs  *ExternalDocumentation `json:"externalDocs,omitempty"`
 	Example       any                    `json:"example,omitempty"`
+	Deprecated    bool                   `json:"deprecated,omitempty"` // OpenAPI 3.x
 }
 
 // Schema the schema object allows the definition of input and output data types.
@@ -207,6 +260,9 @@ type Schema struct {
 	SwaggerSchemaProps
 
 	ExtraProps map[string]any `json:"-"`
+
+	// boolean is set for the boolean schemas true and false, which are marshaled back as booleans
+	boolean *bool
 }
 
 // JSONLookup implements an interface to customize json pointer lookup
@@ -266,6 +322,12 @@ func (s *Schema) WithAllOf(schemas ...Schema) *Schema {
 	return s
 }
 
+// WithNot sets the schema which a value must not match, allows for chaining
+func (s *Schema) WithNot(schema *Schema) *Schema {
+	s.Not = schema
+	return s
+}
+
 // WithMaxProperties sets the max number of properties an object can have
 func (s *Schema) WithMaxProperties(maximum int64) *Schema {
 	s.MaxProperties = &maximum
@@ -278,6 +340,12 @@ func (s *Schema) WithMinProperties(minimum int64) *Schema {
 	return s
 }
 
+// WithPropertyNames sets the schema which the names of the properties of an object must match
+func (s *Schema) WithPropertyNames(schema *Schema) *Schema {
+	s.PropertyNames = schema
+	return s
+}
+
 // Typed sets the type of this schema for a single value item
 func (s *Schema) Typed(tpe, format string) *Schema {
 	s.Type = []string{tpe}
@@ -285,6 +353,12 @@ func (s *Schema) Typed(tpe, format string) *Schema {
 	return s
 }
 
+// WithFormat sets the format for this schema, leaving its type unchanged
+func (s *Schema) WithFormat(format string) *Schema {
+	s.Format = format
+	return s
+}
+
 // AddType adds a type with potential format to the types for this schema
 func (s *Schema) AddType(tpe, format string) *Schema {
 	s.Type = append(s.Type, tpe)
@@ -370,6 +444,46 @@ func (s *Schema) WithEnum(values ...any) *Schema {
 	return s
 }
 
+// BuilderOptions tune the output of schema builders to the version of the target spec.
+type BuilderOptions struct {
+	// OpenAPIVersion is the version of the OpenAPI spec to produce, e.g. "3.0.3".
+	// It defaults to OpenAPI 3.1.
+	OpenAPIVersion string
+}
+
+// supportsConst tells if the target version supports keywords from JSON Schema 2020-12, such as const.
+func (o BuilderOptions) supportsConst() bool {
+	return !strings.HasPrefix(o.OpenAPIVersion, "3.0") && !strings.HasPrefix(o.OpenAPIVersion, "2.")
+}
+
+// WithSingleValue restricts the schema to a single value (replace).
+//
+// This sets const, unless the options target OpenAPI 3.0 or Swagger 2.0,
+// which only support an enum with a single value.
+func (s *Schema) WithSingleValue(value any, opts ...BuilderOptions) *Schema {
+	var o BuilderOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	if o.supportsConst() {
+		s.Enum = nil
+		s.Const = value
+		s.HasConst = true
+	} else {
+		s.Const = nil
+		s.HasConst = false
+		s.Enum = []any{value}
+	}
+
+	return s
+}
+
+// hasConst tells if the schema restricts its instances to a constant, which may be null.
+func (s Schema) hasConst() bool {
+	return s.HasConst || s.Const != nil
+}
+
 // WithMaxItems sets the max items
 func (s *Schema) WithMaxItems(size int64) *Schema {
 	s.MaxItems = &size
@@ -382,6 +496,24 @@ func (s *Schema) WithMinItems(size int64) *Schema {
 	return s
 }
 
+// WithContains sets the schema which some items of an array must match
+func (s *Schema) WithContains(schema *Schema) *Schema {
+	s.Contains = schema
+	return s
+}
+
+// WithMaxContains sets the max number of items matching the schema of contains
+func (s *Schema) WithMaxContains(count int64) *Schema {
+	s.MaxContains = &count
+	return s
+}
+
+// WithMinContains sets the min number of items matching the schema of contains
+func (s *Schema) WithMinContains(count int64) *Schema {
+	s.MinContains = &count
+	return s
+}
+
 // UniqueValues dictates that this array can only have unique items
 func (s *Schema) UniqueValues() *Schema {
 	s.UniqueItems = true
@@ -402,10 +534,21 @@ func (s *Schema) AddToAllOf(schemas ...Schema) *Schema {
 
 // WithDiscriminator sets the name of the discriminator field
 func (s *Schema) WithDiscriminator(discriminator string) *Schema {
-	s.Discriminator = discriminator
+	s.Discriminator = &Discriminator{PropertyName: discriminator}
 	return s
 }
 
+// DiscriminatorProperty returns the name of the discriminator property, or an empty string without discriminator.
+//
+// This is the value of the discriminator of Swagger 2.0 schemas, before it became a Discriminator object.
+func (s Schema) DiscriminatorProperty() string {
+	if s.Discriminator == nil {
+		return ""
+	}
+
+	return s.Discriminator.PropertyName
+}
+
 // AsReadOnly flags this schema as readonly
 func (s *Schema) AsReadOnly() *Schema {
 	s.ReadOnly = true
@@ -418,12 +561,76 @@ func (s *Schema) AsWritable() *Schema {
 	return s
 }
 
+// AsWriteOnly flags this schema as write-only
+func (s *Schema) AsWriteOnly() *Schema {
+	s.WriteOnly = true
+	return s
+}
+
 // WithExample sets the example for this schema
 func (s *Schema) WithExample(example any) *Schema {
 	s.Example = example
 	return s
 }
 
+// WithExampleValue adds a value to the JSON Schema "examples" of this schema.
+//
+// Unlike the single example set by WithExample, examples are a list of values (JSON Schema 2020-12).
+func (s *Schema) WithExampleValue(example any) *Schema {
+	examples, _ := s.ExtraProps["examples"].([]any)
+	if s.ExtraProps == nil {
+		s.ExtraProps = make(map[string]any)
+	}
+	s.ExtraProps["examples"] = append(slices.Clone(examples), example)
+	return s
+}
+
+// WithDeprecated flags this schema as deprecated, or not
+func (s *Schema) WithDeprecated(deprecated bool) *Schema {
+	s.Deprecated = deprecated
+	return s
+}
+
+// MarkPropertyDeprecated flags a property of this schema as deprecated.
+//
+// Properties which are not declared by the schema are left undeclared.
+func (s *Schema) MarkPropertyDeprecated(name string) *Schema {
+	property, ok := s.Properties[name]
+	if !ok {
+		return s
+	}
+
+	property.Deprecated = true
+	s.Properties[name] = property
+	return s
+}
+
+// DeprecatedProperties returns the sorted names of the deprecated properties of this schema,
+// e.g. to annotate the fields of generated code.
+//
+// When root is not nil, the $ref's of properties are resolved against root, so that a property
+// referring to a deprecated schema is deprecated too. Unresolved and circular $ref's are ignored.
+func (s Schema) DeprecatedProperties(root any) []string {
+	var deprecated []string
+
+	for _, name := range slices.Sorted(maps.Keys(s.Properties)) {
+		property := s.Properties[name]
+
+		isDeprecated := property.Deprecated
+		if !isDeprecated && root != nil {
+			_, _ = followRefs(property, root, schemaRefOf, resolveSchemaRef, func(target *Schema) {
+				isDeprecated = isDeprecated || target.Deprecated
+			})
+		}
+
+		if isDeprecated {
+			deprecated = append(deprecated, name)
+		}
+	}
+
+	return deprecated
+}
+
 // WithExternalDocs sets/removes the external docs for/from this schema.
 // When you pass empty strings as params the external documents will be removed.
 // When you pass non-empty string as one value then those values will be used on the external docs object.
@@ -555,9 +762,42 @@ func (s Schema) Validations() SchemaValidations {
 	}
 }
 
+// EffectiveExclusiveMaximum returns the exclusive upper bound of a number, whether it's set as a number (JSON Schema 2020-12)
+// or as a boolean modifier of maximum (draft 4).
+func (s Schema) EffectiveExclusiveMaximum() (value float64, set bool) {
+	if s.ExclusiveMaxValue != nil {
+		return *s.ExclusiveMaxValue, true
+	}
+	if s.ExclusiveMaximum && s.Maximum != nil {
+		return *s.Maximum, true
+	}
+
+	return 0, false
+}
+
+// EffectiveExclusiveMinimum returns the exclusive lower bound of a number, whether it's set as a number (JSON Schema 2020-12)
+// or as a boolean modifier of minimum (draft 4).
+func (s Schema) EffectiveExclusiveMinimum() (value float64, set bool) {
+	if s.ExclusiveMinValue != nil {
+		return *s.ExclusiveMinValue, true
+	}
+	if s.ExclusiveMinimum && s.Minimum != nil {
+		return *s.Minimum, true
+	}
+
+	return 0, false
+}
+
 // MarshalJSON marshal this to JSON
+//
+// Numeric exclusive bounds take precedence over the boolean ones. Unmodified boolean schemas are marshaled
+// as true or false. With HasConst, a nil Const is marshaled as null.
 func (s Schema) MarshalJSON() ([]byte, error) {
-	b1, err := json.Marshal(s.SchemaProps)
+	if allows, ok := s.booleanForm(); ok {
+		return json.Marshal(allows)
+	}
+
+	b1, err := marshalSchemaProps(s.SchemaProps)
 	if err != nil {
 		return nil, fmt.Errorf("schema props %v: %w", err, ErrSpec)
 	}
@@ -585,16 +825,54 @@ func (s Schema) MarshalJSON() ([]byte, error) {
 		}
 		b6 = jj
 	}
-	return jsonutils.ConcatJSON(b1, b2, b3, b4, b5, b6), nil
+	var b7 []byte
+	if s.HasConst && s.Const == nil {
+		b7 = []byte(`{"const":null}`)
+	}
+	return jsonutils.ConcatJSON(b1, b2, b3, b4, b5, b6, b7), nil
+}
+
+func marshalSchemaProps(props SchemaProps) ([]byte, error) {
+	if props.ExclusiveMaxValue == nil && props.ExclusiveMinValue == nil {
+		return json.Marshal(props)
+	}
+
+	type schemaProps SchemaProps
+	return json.Marshal(struct {
+		schemaProps
+		ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+		ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	}{
+		schemaProps:      schemaProps(props),
+		ExclusiveMaximum: props.ExclusiveMaxValue,
+		ExclusiveMinimum: props.ExclusiveMinValue,
+	})
 }
 
 // UnmarshalJSON marshal this from JSON
+//
+// The boolean schemas true and false are unmarshaled as TrueSchema and FalseSchema.
+// exclusiveMaximum and exclusiveMinimum are accepted either as booleans or as numbers.
 func (s *Schema) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "true":
+		*s = *TrueSchema()
+		return nil
+	case "false":
+		*s = *FalseSchema()
+		return nil
+	}
+
 	props := struct {
 		SchemaProps
 		SwaggerSchemaProps
+		ExclusiveMaximum json.RawMessage `json:"exclusiveMaximum,omitempty"`
+		ExclusiveMinimum json.RawMessage `json:"exclusiveMinimum,omitempty"`
 	}{}
-	if err := json.Unmarshal(data, &props); err != nil {
+	// numbers in values such as enum, default, const or example are kept as found, e.g. so integers stay integers
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&props); err != nil {
 		return err
 	}
 
@@ -602,14 +880,24 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 		SchemaProps:        props.SchemaProps,
 		SwaggerSchemaProps: props.SwaggerSchemaProps,
 	}
+	if err := unmarshalExclusiveBound(props.ExclusiveMaximum, &sch.ExclusiveMaximum, &sch.ExclusiveMaxValue); err != nil {
+		return fmt.Errorf("exclusiveMaximum: %w", err)
+	}
+	if err := unmarshalExclusiveBound(props.ExclusiveMinimum, &sch.ExclusiveMinimum, &sch.ExclusiveMinValue); err != nil {
+		return fmt.Errorf("exclusiveMinimum: %w", err)
+	}
 
+	// extensions and extra properties keep their numbers as found too
 	var d map[string]any
-	if err := json.Unmarshal(data, &d); err != nil {
+	dec = json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&d); err != nil {
 		return err
 	}
 
 	_ = sch.Ref.fromMap(d)
 	_ = sch.Schema.fromMap(d)
+	_, sch.HasConst = d["const"]
 
 	delete(d, "$ref")
 	delete(d, "$schema")
@@ -636,3 +924,16 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// unmarshalExclusiveBound decodes an exclusive bound, either as a boolean modifier (draft 4) or as a number (JSON Schema 2020-12).
+func unmarshalExclusiveBound(data json.RawMessage, exclusive *bool, value **float64) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, exclusive); err == nil {
+		return nil
+	}
+
+	return json.Unmarshal(data, value)
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ApplyDefaults returns a copy of an object instance, where the missing properties are set to their default.
+//
+// Defaults are applied recursively to the nested objects of the instance, including the objects set from a default.
+// Properties declared by allOf members count as well, and $ref's are resolved against root.
+// Present values are never overwritten, and missing properties without a default are left missing.
+//
+// The instance itself is left untouched. Schemas with allOf members which cannot be merged, or with $ref's
+// which cannot be resolved, produce an error.
+func (s Schema) ApplyDefaults(instance map[string]any, root any) (map[string]any, error) {
+	schema, err := resolveMember(s, root, make(map[string]struct{}))
+	if err != nil {
+		return nil, err
+	}
+
+	defaulted := maps.Clone(instance)
+	if defaulted == nil {
+		defaulted = make(map[string]any, len(schema.Properties))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		property := schema.Properties[name]
+
+		value, isPresent := defaulted[name]
+		if !isPresent {
+			resolved, err := resolveMember(property, root, make(map[string]struct{}))
+			if err != nil {
+				return nil, fmt.Errorf("properties/%s: %w", name, err)
+			}
+			if resolved.Default == nil {
+				continue
+			}
+
+			value = normalizedInstance(resolved.Default)
+			defaulted[name] = value
+		}
+
+		object, isObject := value.(map[string]any)
+		if !isObject {
+			continue
+		}
+
+		nested, err := property.ApplyDefaults(object, root)
+		if err != nil {
+			return nil, fmt.Errorf("properties/%s: %w", name, err)
+		}
+		defaulted[name] = nested
+	}
+
+	return defaulted, nil
+}
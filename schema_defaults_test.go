@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSchema_ApplyDefaults(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"limits": {
+					"type": "object",
+					"properties": {
+						"rate": {"type": "integer", "default": 100},
+						"burst": {"type": "integer", "default": 10}
+					}
+				},
+				"named": {
+					"properties": {"name": {"type": "string", "default": "unnamed"}}
+				}
+			}
+		}
+	}`), &root))
+
+	var schema Schema
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"allOf": [{"$ref": "#/components/schemas/named"}],
+		"properties": {
+			"enabled": {"type": "boolean", "default": false},
+			"settings": {
+				"type": "object",
+				"default": {"mode": "auto"},
+				"properties": {
+					"mode": {"type": "string", "default": "manual"},
+					"retries": {"type": "integer", "default": 3},
+					"limits": {"$ref": "#/components/schemas/limits"}
+				}
+			},
+			"owner": {
+				"type": "object",
+				"properties": {"email": {"type": "string", "format": "email"}}
+			}
+		}
+	}`), &schema))
+
+	t.Run("should fill in nested defaults", func(t *testing.T) {
+		defaulted, err := schema.ApplyDefaults(map[string]any{}, root)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]any{
+			"name":     "unnamed",
+			"enabled":  false,
+			"settings": map[string]any{"mode": "auto", "retries": float64(3)},
+		}, defaulted)
+	})
+
+	t.Run("should not overwrite present values", func(t *testing.T) {
+		instance := map[string]any{
+			"enabled":  true,
+			"settings": map[string]any{"limits": map[string]any{"rate": 5}},
+		}
+
+		defaulted, err := schema.ApplyDefaults(instance, root)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]any{
+			"name":    "unnamed",
+			"enabled": true,
+			"settings": map[string]any{
+				"mode":    "manual",
+				"retries": float64(3),
+				"limits":  map[string]any{"rate": 5, "burst": float64(10)},
+			},
+		}, defaulted)
+
+		// the instance is left untouched
+		assert.Equal(t, map[string]any{"limits": map[string]any{"rate": 5}}, instance["settings"])
+	})
+
+	t.Run("should fail on unresolved $ref", func(t *testing.T) {
+		_, err := RefSchema("#/components/schemas/missing").ApplyDefaults(nil, root)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// FilterForRead returns the schema of the data read by clients, i.e. without its writeOnly properties.
+//
+// See FilterForWrite for the handling of $ref's.
+func (s Schema) FilterForRead(root any) (*Schema, error) {
+	f := newSchemaFilter(root, func(sch Schema) bool { return sch.WriteOnly })
+
+	return f.nested(s)
+}
+
+// FilterForWrite returns the schema of the data written by clients, i.e. without its readOnly properties.
+//
+// Properties are filtered in nested schemas, including the members of compositions.
+// Names of filtered properties are removed from "required", as well as the names of the filtered properties
+// of allOf members: a required readOnly id is not required from clients.
+//
+// When root is not nil, $ref's are resolved against root to find out whether their target is readOnly.
+// Targets with filtered nested properties are inlined. Other $ref's are kept,
+// as well as circular $ref's, and all $ref's when root is nil.
+func (s Schema) FilterForWrite(root any) (*Schema, error) {
+	f := newSchemaFilter(root, func(sch Schema) bool { return sch.ReadOnly })
+
+	return f.nested(s)
+}
+
+type schemaFilter struct {
+	root       any
+	isFiltered func(Schema) bool
+	visiting   map[string]struct{} // $ref's being resolved, to detect cycles
+}
+
+func newSchemaFilter(root any, isFiltered func(Schema) bool) *schemaFilter {
+	return &schemaFilter{
+		root:       root,
+		isFiltered: isFiltered,
+		visiting:   make(map[string]struct{}),
+	}
+}
+
+// apply filters a schema after resolving its $ref.
+//
+// When droppable, it tells whether the schema itself is filtered out.
+func (f *schemaFilter) apply(schema Schema, droppable bool) (*Schema, bool, error) {
+	ref := schema.Ref.String()
+	if ref == "" {
+		if droppable && f.isFiltered(schema) {
+			return nil, false, nil
+		}
+
+		filtered, err := f.nested(schema)
+
+		return filtered, err == nil, err
+	}
+
+	if f.root == nil {
+		return &schema, true, nil
+	}
+
+	if _, isCircular := f.visiting[ref]; isCircular {
+		return &schema, true, nil
+	}
+	f.visiting[ref] = struct{}{}
+	defer delete(f.visiting, ref)
+
+	resolved, err := ResolveRef(f.root, &schema.Ref)
+	if err != nil {
+		return nil, false, err
+	}
+
+	filtered, keep, err := f.apply(*resolved, droppable)
+	if err != nil || !keep {
+		return nil, false, err
+	}
+
+	if enumKey(filtered) == enumKey(resolved) {
+		// nothing is filtered in the target: keep the $ref
+		return &schema, true, nil
+	}
+
+	return filtered, true, nil
+}
+
+// nested filters the schemas nested in a schema.
+func (f *schemaFilter) nested(schema Schema) (*Schema, error) {
+	filtered := schema
+
+	if len(schema.Properties) > 0 {
+		filtered.Properties = make(SchemaProperties, len(schema.Properties))
+
+		var dropped []string
+		for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+			property, keep, err := f.apply(schema.Properties[name], true)
+			if err != nil {
+				return nil, fmt.Errorf("properties/%s: %w", name, err)
+			}
+
+			if !keep {
+				dropped = append(dropped, name)
+
+				continue
+			}
+			filtered.Properties[name] = *property
+		}
+
+		if len(dropped) > 0 {
+			filtered.Required = slices.DeleteFunc(slices.Clone(schema.Required), func(name string) bool {
+				return slices.Contains(dropped, name)
+			})
+		}
+	}
+
+	// properties required here may be declared by allOf members, e.g. a readOnly id in a base schema
+	if slices.ContainsFunc(filtered.Required, func(name string) bool { return f.isFilteredInMembers(schema, name) }) {
+		filtered.Required = slices.DeleteFunc(slices.Clone(filtered.Required), func(name string) bool {
+			return f.isFilteredInMembers(schema, name)
+		})
+	}
+
+	for _, composition := range []struct {
+		keyword string
+		members *[]Schema
+	}{
+		{"allOf", &filtered.AllOf},
+		{"anyOf", &filtered.AnyOf},
+		{"oneOf", &filtered.OneOf},
+	} {
+		members, err := f.all(*composition.members, composition.keyword)
+		if err != nil {
+			return nil, err
+		}
+		*composition.members = members
+	}
+
+	if schema.Items != nil {
+		items := new(SchemaOrArray)
+		if schema.Items.Schema != nil {
+			sch, _, err := f.apply(*schema.Items.Schema, false)
+			if err != nil {
+				return nil, fmt.Errorf("items: %w", err)
+			}
+			items.Schema = sch
+		}
+
+		schemas, err := f.all(schema.Items.Schemas, "items")
+		if err != nil {
+			return nil, err
+		}
+		items.Schemas = schemas
+		filtered.Items = items
+	}
+
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		sch, _, err := f.apply(*schema.AdditionalProperties.Schema, false)
+		if err != nil {
+			return nil, fmt.Errorf("additionalProperties: %w", err)
+		}
+		filtered.AdditionalProperties = &SchemaOrBool{Allows: true, Schema: sch}
+	}
+
+	return &filtered, nil
+}
+
+// isFilteredInMembers tells whether a property, which is not declared by a schema, is filtered out
+// by the allOf members declaring it.
+func (f *schemaFilter) isFilteredInMembers(schema Schema, name string) bool {
+	if _, isDeclared := schema.Properties[name]; isDeclared {
+		return false
+	}
+
+	return slices.ContainsFunc(schema.AllOf, func(member Schema) bool {
+		return f.followRef(member, func(member Schema) bool {
+			if property, isDeclared := member.Properties[name]; isDeclared {
+				return f.followRef(property, f.isFiltered)
+			}
+
+			return f.isFilteredInMembers(member, name)
+		})
+	})
+}
+
+// followRef checks the target of the $ref's of a schema.
+//
+// Circular and unresolved $ref's, and all $ref's when root is nil, are not followed and fail the check.
+func (f *schemaFilter) followRef(schema Schema, check func(Schema) bool) bool {
+	ref := schema.Ref.String()
+	if ref == "" {
+		return check(schema)
+	}
+
+	if f.root == nil {
+		return false
+	}
+	if _, isCircular := f.visiting[ref]; isCircular {
+		return false
+	}
+	f.visiting[ref] = struct{}{}
+	defer delete(f.visiting, ref)
+
+	resolved, err := ResolveRef(f.root, &schema.Ref)
+	if err != nil {
+		return false
+	}
+
+	return f.followRef(*resolved, check)
+}
+
+// all filters the nested properties of a list of schemas, which are never dropped.
+func (f *schemaFilter) all(schemas []Schema, keyword string) ([]Schema, error) {
+	if len(schemas) == 0 {
+		return schemas, nil
+	}
+
+	filtered := make([]Schema, len(schemas))
+	for i, schema := range schemas {
+		sch, _, err := f.apply(schema, false)
+		if err != nil {
+			return nil, fmt.Errorf("%s/%d: %w", keyword, i, err)
+		}
+		filtered[i] = *sch
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSchema_Filter(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"Id": {"type": "integer", "readOnly": true},
+				"AuditId": {"$ref": "#/components/schemas/Id"},
+				"Password": {"type": "string", "writeOnly": true},
+				"Owner": {
+					"type": "object",
+					"required": ["id", "name"],
+					"properties": {
+						"id": {"$ref": "#/components/schemas/Id"},
+						"name": {"type": "string"}
+					}
+				},
+				"Tag": {"type": "string"},
+				"Node": {
+					"type": "object",
+					"properties": {"next": {"$ref": "#/components/schemas/Node"}}
+				}
+			}
+		}
+	}`), &root))
+
+	var schema Schema
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"required": ["id", "name", "password"],
+		"properties": {
+			"id": {"$ref": "#/components/schemas/AuditId"},
+			"name": {"type": "string"},
+			"password": {"$ref": "#/components/schemas/Password"},
+			"createdAt": {"type": "string", "readOnly": true},
+			"owner": {"$ref": "#/components/schemas/Owner"},
+			"tags": {"type": "array", "items": {"$ref": "#/components/schemas/Tag"}},
+			"node": {"$ref": "#/components/schemas/Node"}
+		}
+	}`), &schema))
+
+	t.Run("should drop readOnly properties behind $ref's for writes", func(t *testing.T) {
+		filtered, err := schema.FilterForWrite(root)
+		require.NoError(t, err)
+
+		assert.NotContains(t, filtered.Properties, "id")
+		assert.NotContains(t, filtered.Properties, "createdAt")
+		assert.Contains(t, filtered.Properties, "password")
+		assert.Equal(t, []string{"name", "password"}, filtered.Required)
+
+		owner := filtered.Properties["owner"]
+		assert.Empty(t, owner.Ref.String(), "a target with filtered properties should be inlined")
+		assert.Equal(t, []string{"name"}, owner.Required)
+		assert.NotContains(t, owner.Properties, "id")
+
+		tags := filtered.Properties["tags"]
+		assert.Equal(t, "#/components/schemas/Tag", tags.Items.Schema.Ref.String(), "an unfiltered target should remain a $ref")
+
+		node := filtered.Properties["node"]
+		assert.Equal(t, "#/components/schemas/Node", node.Ref.String())
+
+		assert.Contains(t, schema.Properties, "id", "the original schema should be left unchanged")
+		assert.Len(t, schema.Required, 3)
+	})
+
+	t.Run("should drop writeOnly properties behind $ref's for reads", func(t *testing.T) {
+		filtered, err := schema.FilterForRead(root)
+		require.NoError(t, err)
+
+		assert.NotContains(t, filtered.Properties, "password")
+		assert.Contains(t, filtered.Properties, "id")
+		assert.Contains(t, filtered.Properties, "createdAt")
+		assert.Equal(t, []string{"id", "name"}, filtered.Required)
+		owner := filtered.Properties["owner"]
+		assert.Equal(t, "#/components/schemas/Owner", owner.Ref.String())
+	})
+
+	t.Run("should not require readOnly properties of allOf members for writes", func(t *testing.T) {
+		var derived Schema
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"allOf": [{"$ref": "#/components/schemas/Owner"}, {"properties": {"email": {"type": "string"}}}],
+			"required": ["id", "email", "name"]
+		}`), &derived))
+
+		filtered, err := derived.FilterForWrite(root)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"email", "name"}, filtered.Required)
+
+		properties, required, err := filtered.EffectiveProperties(root)
+		require.NoError(t, err)
+		assert.NotContains(t, properties, "id")
+		assert.ElementsMatch(t, []string{"email", "name"}, required)
+
+		read, err := derived.FilterForRead(root)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id", "email", "name"}, read.Required)
+	})
+
+	t.Run("should keep $ref's without root", func(t *testing.T) {
+		filtered, err := schema.FilterForWrite(nil)
+		require.NoError(t, err)
+
+		assert.Contains(t, filtered.Properties, "id")
+		assert.NotContains(t, filtered.Properties, "createdAt")
+	})
+
+	t.Run("should fail on an unresolved $ref", func(t *testing.T) {
+		broken := new(Schema).SetProperty("id", *RefSchema("#/components/schemas/Missing"))
+		_, err := broken.FilterForWrite(root)
+		require.ErrorIs(t, err, ErrSpec)
+	})
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+// SchemaKind is the normalized shape of a schema, as returned by Schema.Kind.
+type SchemaKind int
+
+// Kinds of schemas
+const (
+	SchemaKindEmpty SchemaKind = iota // any value is accepted
+	SchemaKindRef
+	SchemaKindObject
+	SchemaKindArray
+	SchemaKindString
+	SchemaKindNumber
+	SchemaKindInteger
+	SchemaKindBoolean
+	SchemaKindNull
+	SchemaKindAllOf
+	SchemaKindOneOf
+	SchemaKindAnyOf
+)
+
+var schemaKindNames = map[SchemaKind]string{
+	SchemaKindEmpty:   "empty",
+	SchemaKindRef:     "ref",
+	SchemaKindObject:  "object",
+	SchemaKindArray:   jsonArray,
+	SchemaKindString:  "string",
+	SchemaKindNumber:  "number",
+	SchemaKindInteger: "integer",
+	SchemaKindBoolean: "boolean",
+	SchemaKindNull:    "null",
+	SchemaKindAllOf:   "allOf",
+	SchemaKindOneOf:   "oneOf",
+	SchemaKindAnyOf:   "anyOf",
+}
+
+func (k SchemaKind) String() string {
+	if name, ok := schemaKindNames[k]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+var kindsOfTypes = map[string]SchemaKind{
+	"object":  SchemaKindObject,
+	jsonArray: SchemaKindArray,
+	"string":  SchemaKindString,
+	"number":  SchemaKindNumber,
+	"integer": SchemaKindInteger,
+	"boolean": SchemaKindBoolean,
+}
+
+// Kind classifies the schema by its shape.
+//
+// A $ref takes precedence over any other keyword. Otherwise, the kind is given by a single type,
+// either as a string or as a JSON Schema 2020-12 type array, where "null" is ignored next to another type:
+// ["string", "null"] is a string. A schema with only the "null" type is of kind SchemaKindNull.
+//
+// Schemas without a type are classified by their composition, checking allOf, then oneOf, then anyOf.
+// Untyped schemas without composition, as well as schemas allowing several types, accept any value
+// and are of kind SchemaKindEmpty.
+func (s Schema) Kind() SchemaKind {
+	if s.Ref.String() != "" {
+		return SchemaKindRef
+	}
+
+	kind, typed := SchemaKindEmpty, false
+	for _, tpe := range s.Type {
+		if tpe == "null" {
+			continue
+		}
+
+		if typed {
+			// several types, e.g. ["string", "integer"]
+			return SchemaKindEmpty
+		}
+		kind, typed = kindsOfTypes[tpe], true
+	}
+
+	switch {
+	case typed:
+		return kind
+	case len(s.Type) > 0:
+		return SchemaKindNull
+	case len(s.AllOf) > 0:
+		return SchemaKindAllOf
+	case len(s.OneOf) > 0:
+		return SchemaKindOneOf
+	case len(s.AnyOf) > 0:
+		return SchemaKindAnyOf
+	default:
+		return SchemaKindEmpty
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSchema_Kind(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schema   string
+		expected SchemaKind
+	}{
+		{name: "untyped schema", schema: `{}`, expected: SchemaKindEmpty},
+		{name: "untyped schema with properties", schema: `{"properties": {"id": {}}}`, expected: SchemaKindEmpty},
+		{name: "$ref", schema: `{"$ref": "#/components/schemas/Pet", "type": "object"}`, expected: SchemaKindRef},
+		{name: "object", schema: `{"type": "object"}`, expected: SchemaKindObject},
+		{name: "array", schema: `{"type": "array", "items": {"type": "string"}}`, expected: SchemaKindArray},
+		{name: "string", schema: `{"type": "string", "format": "date"}`, expected: SchemaKindString},
+		{name: "number", schema: `{"type": "number"}`, expected: SchemaKindNumber},
+		{name: "integer", schema: `{"type": "integer"}`, expected: SchemaKindInteger},
+		{name: "boolean", schema: `{"type": "boolean"}`, expected: SchemaKindBoolean},
+		{name: "nullable type array", schema: `{"type": ["integer", "null"]}`, expected: SchemaKindInteger},
+		{name: "null", schema: `{"type": "null"}`, expected: SchemaKindNull},
+		{name: "several types", schema: `{"type": ["string", "integer"]}`, expected: SchemaKindEmpty},
+		{name: "allOf", schema: `{"allOf": [{"type": "object"}], "oneOf": [{}, {}]}`, expected: SchemaKindAllOf},
+		{name: "oneOf", schema: `{"oneOf": [{"type": "string"}, {"type": "integer"}]}`, expected: SchemaKindOneOf},
+		{name: "anyOf", schema: `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, expected: SchemaKindAnyOf},
+		{name: "typed composition", schema: `{"type": "object", "allOf": [{}]}`, expected: SchemaKindObject},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var schema Schema
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+
+			assert.Equal(t, tc.expected, schema.Kind())
+		})
+	}
+
+	t.Run("should name kinds", func(t *testing.T) {
+		assert.Equal(t, "array", SchemaKindArray.String())
+		assert.Equal(t, "oneOf", SchemaKindOneOf.String())
+		assert.Equal(t, "unknown", SchemaKind(-1).String())
+	})
+}
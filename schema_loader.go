@@ -4,12 +4,18 @@
 package spec
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"net/url"
+	"os"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/swag/jsonutils"
 	"github.com/go-openapi/swag/loading"
@@ -21,15 +27,67 @@ import (
 // This is a package level default. It may be overridden or bypassed by
 // specifying the loader in ExpandOptions.
 //
+// YAML documents are converted to JSON, so a JSON document may refer to a YAML one
+// and vice versa, see documentJSON.
+//
 // NOTE: if you are using the go-openapi/loads package, it will override
-// this value with its own default (a loader to retrieve YAML documents as
-// well as JSON ones).
-var PathLoader = func(pth string) (json.RawMessage, error) {
-	data, err := loading.LoadFromFileOrHTTP(pth)
+// this value with its own default.
+var PathLoader = defaultPathLoader
+
+func defaultPathLoader(pth string) (json.RawMessage, error) {
+	return loadPathContext(context.Background(), pth)
+}
+
+// loadPathContext is the default document loading method, which cancels HTTP requests when ctx is done.
+func loadPathContext(ctx context.Context, pth string) (json.RawMessage, error) {
+	remote := func(pth string) ([]byte, error) {
+		return loadHTTPContext(ctx, pth)
+	}
+
+	data, err := loading.LoadStrategy(pth, os.ReadFile, remote)(pth)
 	if err != nil {
 		return nil, err
 	}
-	return json.RawMessage(data), nil
+	return documentJSON(pth, data)
+}
+
+// defaultHTTPTimeout is the timeout of HTTP requests, as with loading.LoadFromFileOrHTTP.
+const defaultHTTPTimeout = 30 * time.Second
+
+func loadHTTPContext(ctx context.Context, pth string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultHTTPTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pth, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not access document at %q [%s]: %w", pth, resp.Status, loading.ErrLoader)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// isDefaultPathLoader tells whether the package level PathLoader has not been overridden.
+func isDefaultPathLoader() bool {
+	return reflect.ValueOf(PathLoader).Pointer() == reflect.ValueOf(defaultPathLoader).Pointer()
+}
+
+// ReferenceResolver fetches the documents targeted by $ref's, e.g. from a database, an OCI registry or a git repository.
+//
+// Resolve is called with the URL of the document to fetch, without fragment, once resolved against the location
+// of the referring document. base is the location of the root document, i.e. the RelativeBase of ExpandOptions.
+// Fetched documents are cached: each document is fetched once per expansion.
+type ReferenceResolver interface {
+	Resolve(ctx context.Context, ref Ref, base string) (json.RawMessage, error)
 }
 
 // resolverContext allows to share a context during spec processing.
@@ -41,25 +99,41 @@ type resolverContext struct {
 	// concurrent access, unless we chose to implement a parallel spec walking.
 	circulars map[string]bool
 	basePath  string
-	loadDoc   func(string) (json.RawMessage, error)
+	loadDoc   func(context.Context, string) (json.RawMessage, error)
 	rootID    string
+
+	// ctx cancels the resolution of $ref's, see ExpandSpecContext
+	ctx context.Context
+
+	// expandedSize is the approximate size of the expanded document, tracked when a budget is set
+	expandedSize int
+
+	// refSiblings tells whether the keywords next to a $ref apply, as allowed since OpenAPI 3.1
+	refSiblings bool
 }
 
 func newResolverContext(options *ExpandOptions) *resolverContext {
 	expandOptions := optionsOrDefault(options)
 
 	// path loader may be overridden by options
-	var loader func(string) (json.RawMessage, error)
-	if expandOptions.PathLoader == nil {
-		loader = PathLoader
-	} else {
-		loader = expandOptions.PathLoader
+	loader := expandOptions.PathLoaderContext
+	switch {
+	case expandOptions.Resolver != nil:
+		loader = expandOptions.resolverLoader()
+	case loader == nil && expandOptions.PathLoader == nil && isDefaultPathLoader():
+		loader = loadPathContext
+	case loader == nil:
+		pathLoader := expandOptions.pathLoader()
+		loader = func(_ context.Context, pth string) (json.RawMessage, error) {
+			return pathLoader(pth)
+		}
 	}
 
 	return &resolverContext{
 		circulars: make(map[string]bool),
 		basePath:  expandOptions.RelativeBase, // keep the root base path in context
 		loadDoc:   loader,
+		ctx:       context.Background(),
 	}
 }
 
@@ -121,6 +195,10 @@ func (r *schemaLoader) resolveRef(ref *Ref, target any, basePath string) error {
 		return ErrResolveRefNeedsAPointer
 	}
 
+	if err := r.context.ctx.Err(); err != nil {
+		return err
+	}
+
 	if ref.GetURL() == nil {
 		return nil
 	}
@@ -154,7 +232,7 @@ func (r *schemaLoader) resolveRef(ref *Ref, target any, basePath string) error {
 	if ref.String() != "" {
 		res, _, err = ref.GetPointer().Get(data)
 		if err != nil {
-			return err
+			return &RefNotFoundError{Ref: ref.String(), Err: err}
 		}
 	}
 	return jsonutils.FromDynamicJSON(res, target)
@@ -175,14 +253,14 @@ func (r *schemaLoader) load(refURL *url.URL) (any, url.URL, bool, error) {
 		return data, toFetch, fromCache, nil
 	}
 
-	b, err := r.context.loadDoc(normalized)
+	b, err := r.context.loadDoc(r.context.ctx, normalized)
 	if err != nil {
-		return nil, url.URL{}, false, err
+		return nil, url.URL{}, false, &RemoteFetchError{URL: normalized, Err: err}
 	}
 
 	var doc any
 	if err := json.Unmarshal(b, &doc); err != nil {
-		return nil, url.URL{}, false, err
+		return nil, url.URL{}, false, &ParseError{Document: normalized, Err: err}
 	}
 	r.cache.Set(normalized, doc)
 
@@ -217,6 +295,14 @@ func (r *schemaLoader) deref(input any, parentRefs []string, basePath string) er
 		ref = &refable.Ref
 	case *PathItem:
 		ref = &refable.Ref
+	case *Callback:
+		ref = &refable.Ref
+	case *RequestBody:
+		ref = &refable.Ref
+	case *Header:
+		ref = &refable.Ref
+	case *Example:
+		ref = &refable.Ref
 	default:
 		return fmt.Errorf("unsupported type: %T: %w", input, ErrDerefUnsupportedType)
 	}
@@ -233,7 +319,11 @@ func (r *schemaLoader) deref(input any, parentRefs []string, basePath string) er
 		return nil
 	}
 
-	if err := r.resolveRef(ref, input, basePath); r.shouldStopOnError(err) {
+	if err := r.resolveRef(ref, input, basePath); err != nil {
+		return err
+	}
+
+	if err := r.growExpandedSize(input); err != nil {
 		return err
 	}
 
@@ -247,7 +337,7 @@ func (r *schemaLoader) deref(input any, parentRefs []string, basePath string) er
 }
 
 func (r *schemaLoader) shouldStopOnError(err error) bool {
-	if err != nil && !r.options.ContinueOnError {
+	if err != nil && (!r.options.ContinueOnError || errors.Is(err, ErrMaxExpandedSize) || r.context.ctx.Err() != nil) {
 		return true
 	}
 
@@ -258,6 +348,27 @@ func (r *schemaLoader) shouldStopOnError(err error) bool {
 	return false
 }
 
+// growExpandedSize accounts for the serialized size of some content added to the expanded document.
+//
+// This is a no-op unless a MaxExpandedSize budget is set in options.
+func (r *schemaLoader) growExpandedSize(content any) error {
+	if r.options.MaxExpandedSize <= 0 {
+		return nil
+	}
+
+	b, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+
+	r.context.expandedSize += len(b)
+	if r.context.expandedSize > r.options.MaxExpandedSize {
+		return &ExpandedSizeError{Limit: r.options.MaxExpandedSize, Size: r.context.expandedSize}
+	}
+
+	return nil
+}
+
 func (r *schemaLoader) setSchemaID(target any, id, basePath string) (string, string) {
 	debugLog("schema has ID: %s", id)
 
@@ -289,6 +400,19 @@ func (r *schemaLoader) setSchemaID(target any, id, basePath string) (string, str
 	return newBasePath, refPath
 }
 
+// allowsRefSiblings tells whether the root document is an OpenAPI 3.1 document or later,
+// where the keywords next to a $ref are no longer ignored.
+func allowsRefSiblings(root any) bool {
+	doc, ok := root.(*Swagger)
+	if !ok {
+		return false
+	}
+
+	_, minor, err := doc.SpecVersion()
+
+	return err == nil && minor >= 1
+}
+
 func defaultSchemaLoader(
 	root any,
 	expandOptions *ExpandOptions,
@@ -311,6 +435,7 @@ func defaultSchemaLoader(
 
 	if context == nil {
 		context = newResolverContext(expandOptions)
+		context.refSiblings = allowsRefSiblings(root)
 	}
 
 	return &schemaLoader{
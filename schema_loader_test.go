@@ -8,14 +8,36 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/go-openapi/testify/v2/assert"
 	"github.com/go-openapi/testify/v2/require"
 )
 
 func TestLoader_Issue145(t *testing.T) {
 	t.Run("with ExpandSpec", func(t *testing.T) {
-		// TODO: This test fails with OpenAPI 3 refs and paths with spaces
-		// The expansion of cross-file refs with #/components/... paths needs investigation
-		t.Skip("Test needs investigation for cross-file ref expansion with OpenAPI 3")
+		basePath := filepath.Join("fixtures", "bugs", "145", "Program Files (x86)", "AppName", "todos.json")
+		doc, opts := docAndOpts(t, basePath)
+		opts.Targets = TargetAll // including request bodies
+
+		spec := new(Swagger)
+		require.NoError(t, json.Unmarshal(doc, spec))
+		require.NoError(t, ExpandSpec(spec, opts))
+		assertNoRef(t, asJSON(t, spec))
+	})
+
+	t.Run("with ExpandSpec and refs to a sibling directory", func(t *testing.T) {
+		// specs/v1/api.json refers to "../shared types/types.json", which refers to "../../todos.common.json"
+		basePath := filepath.Join("fixtures", "bugs", "145", "Program Files (x86)", "AppName", "specs", "v1", "api.json")
+		jazon, spec := expandThisOrDieTrying(t, basePath)
+		assertNoRef(t, jazon)
+
+		todo := spec.Components.Schemas["todo"]
+		assert.Equal(t, StringOrArray{"object"}, todo.Type)
+		assert.Equal(t, "uuid", todo.Properties["id"].Format)
+		assert.Equal(t, "User", todo.Properties["user"].Title)
+
+		param := spec.Paths.Paths["/todos/{todoId}"].Get.Parameters[0]
+		require.NotNil(t, param.Schema)
+		assert.Equal(t, "uuid", param.Schema.Format)
 	})
 
 	t.Run("with ExpandSchema", func(t *testing.T) {
@@ -0,0 +1,480 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// MergeAllOf flattens the allOf composition of a schema into a single schema.
+//
+// Members of allOf are merged into the schema, after their $ref's are resolved against root.
+// Nested allOf compositions are flattened as well.
+//
+// The merged schema holds the union of properties and required properties, and the
+// intersection of constraints: e.g. the lowest maximum, the highest minimum or the common enum values.
+// Overlapping properties are merged the same way.
+//
+// Constraints which cannot be combined, such as different types or formats, produce an error
+// which matches ErrMergeConflict. So do bounds which no value satisfies once combined, e.g. a minimum
+// above a maximum: this error matches ErrUnsatisfiable as well.
+func (s Schema) MergeAllOf(root any) (*Schema, error) {
+	return s.mergeAllOf(root, make(map[string]struct{}))
+}
+
+func (s Schema) mergeAllOf(root any, visiting map[string]struct{}) (*Schema, error) {
+	members := s.AllOf
+
+	merged := s
+	merged.AllOf = nil
+
+	for i, member := range members {
+		resolved, err := resolveMember(member, root, visiting)
+		if err != nil {
+			return nil, fmt.Errorf("allOf/%d: %w", i, err)
+		}
+
+		if err := mergeSchema(&merged, *resolved); err != nil {
+			return nil, fmt.Errorf("allOf/%d: %w", i, err)
+		}
+	}
+
+	return &merged, nil
+}
+
+// resolveMember follows the $ref's of an allOf member, then flattens its own composition.
+func resolveMember(member Schema, root any, visiting map[string]struct{}) (*Schema, error) {
+	var seen []string
+	defer func() {
+		for _, ref := range seen {
+			delete(visiting, ref)
+		}
+	}()
+
+	for member.Ref.String() != "" {
+		ref := member.Ref.String()
+		if _, isCircular := visiting[ref]; isCircular {
+			return nil, fmt.Errorf("cannot be merged: %w: %w", &CircularReferenceError{Ref: ref}, ErrMergeConflict)
+		}
+		visiting[ref] = struct{}{}
+		seen = append(seen, ref)
+
+		resolved, err := ResolveRef(root, &member.Ref)
+		if err != nil {
+			return nil, err
+		}
+		member = *resolved
+	}
+
+	return member.mergeAllOf(root, visiting)
+}
+
+// mergeSchema merges the constraints of src into dst.
+func mergeSchema(dst *Schema, src Schema) error {
+	if err := mergeTypes(dst, src); err != nil {
+		return err
+	}
+
+	if src.Format != "" {
+		if dst.Format != "" && dst.Format != src.Format {
+			return conflict("format", dst.Format, src.Format)
+		}
+		dst.Format = src.Format
+	}
+
+	if src.Pattern != "" {
+		if dst.Pattern != "" && dst.Pattern != src.Pattern {
+			return conflict("pattern", dst.Pattern, src.Pattern)
+		}
+		dst.Pattern = src.Pattern
+	}
+
+	if src.MultipleOf != nil {
+		if dst.MultipleOf != nil && *dst.MultipleOf != *src.MultipleOf {
+			return conflict("multipleOf", *dst.MultipleOf, *src.MultipleOf)
+		}
+		dst.MultipleOf = src.MultipleOf
+	}
+
+	mergeBounds(dst, src)
+	if err := satisfiable(*dst); err != nil {
+		return fmt.Errorf("%w: %w", err, ErrMergeConflict)
+	}
+
+	if err := mergeEnum(dst, src); err != nil {
+		return err
+	}
+
+	if src.hasConst() {
+		if dst.hasConst() && enumKey(dst.Const) != enumKey(src.Const) {
+			return conflict("const", enumKey(dst.Const), enumKey(src.Const))
+		}
+		dst.Const = src.Const
+		dst.HasConst = true
+	}
+
+	dst.Nullable = mergeNullable(dst.Nullable, src.Nullable)
+
+	dst.UniqueItems = dst.UniqueItems || src.UniqueItems
+	dst.ReadOnly = dst.ReadOnly || src.ReadOnly
+	dst.WriteOnly = dst.WriteOnly || src.WriteOnly
+
+	dst.Required = slices.Clone(dst.Required) // do not alter the original schema
+	for _, name := range src.Required {
+		if !slices.Contains(dst.Required, name) {
+			dst.Required = append(dst.Required, name)
+		}
+	}
+
+	if err := mergeProperties(dst, src); err != nil {
+		return err
+	}
+
+	if err := mergeAdditionalProperties(dst, src); err != nil {
+		return err
+	}
+
+	if err := mergeItems(dst, src); err != nil {
+		return err
+	}
+
+	if err := mergeCompositions(dst, src); err != nil {
+		return err
+	}
+
+	// annotations from the merged schema are kept, unless already set
+	if dst.Title == "" {
+		dst.Title = src.Title
+	}
+	if dst.Description == "" {
+		dst.Description = src.Description
+	}
+	if dst.Default == nil {
+		dst.Default = src.Default
+	}
+	if dst.Example == nil {
+		dst.Example = src.Example
+	}
+	if dst.Discriminator == nil {
+		dst.Discriminator = src.Discriminator
+	}
+	if len(src.Extensions) > 0 {
+		extensions := maps.Clone(src.Extensions)
+		maps.Copy(extensions, dst.Extensions)
+		dst.Extensions = extensions
+	}
+
+	return nil
+}
+
+func mergeTypes(dst *Schema, src Schema) error {
+	if len(src.Type) == 0 {
+		return nil
+	}
+
+	if len(dst.Type) == 0 {
+		dst.Type = slices.Clone(src.Type)
+
+		return nil
+	}
+
+	var common StringOrArray
+	for _, tpe := range dst.Type {
+		if src.Type.Contains(tpe) || (tpe == "integer" && src.Type.Contains("number")) {
+			common = append(common, tpe)
+		} else if tpe == "number" && src.Type.Contains("integer") {
+			common = append(common, "integer")
+		}
+	}
+
+	if len(common) == 0 {
+		return conflict("type", []string(dst.Type), []string(src.Type))
+	}
+	dst.Type = common
+
+	return nil
+}
+
+func mergeBounds(dst *Schema, src Schema) {
+	if src.Maximum != nil {
+		switch {
+		case dst.Maximum == nil || *src.Maximum < *dst.Maximum:
+			dst.Maximum = src.Maximum
+			dst.ExclusiveMaximum = src.ExclusiveMaximum
+		case *src.Maximum == *dst.Maximum:
+			dst.ExclusiveMaximum = dst.ExclusiveMaximum || src.ExclusiveMaximum
+		}
+	}
+
+	if src.Minimum != nil {
+		switch {
+		case dst.Minimum == nil || *src.Minimum > *dst.Minimum:
+			dst.Minimum = src.Minimum
+			dst.ExclusiveMinimum = src.ExclusiveMinimum
+		case *src.Minimum == *dst.Minimum:
+			dst.ExclusiveMinimum = dst.ExclusiveMinimum || src.ExclusiveMinimum
+		}
+	}
+
+	dst.ExclusiveMaxValue = lowest(dst.ExclusiveMaxValue, src.ExclusiveMaxValue)
+	dst.ExclusiveMinValue = highest(dst.ExclusiveMinValue, src.ExclusiveMinValue)
+
+	dst.MaxLength = lowest(dst.MaxLength, src.MaxLength)
+	dst.MinLength = highest(dst.MinLength, src.MinLength)
+	dst.MaxItems = lowest(dst.MaxItems, src.MaxItems)
+	dst.MinItems = highest(dst.MinItems, src.MinItems)
+	dst.MaxProperties = lowest(dst.MaxProperties, src.MaxProperties)
+	dst.MinProperties = highest(dst.MinProperties, src.MinProperties)
+}
+
+// mergeNullable intersects the nullable flags of two schemas: the merged schema is nullable
+// only if every schema which sets the flag allows null.
+func mergeNullable(dst, src *bool) *bool {
+	switch {
+	case src == nil:
+		return dst
+	case dst == nil:
+		nullable := *src
+
+		return &nullable
+	default:
+		nullable := *dst && *src
+
+		return &nullable
+	}
+}
+
+func mergeEnum(dst *Schema, src Schema) error {
+	if len(src.Enum) == 0 {
+		return nil
+	}
+
+	if len(dst.Enum) == 0 {
+		dst.Enum = slices.Clone(src.Enum)
+
+		return nil
+	}
+
+	var common []any
+	for _, value := range dst.Enum {
+		key := enumKey(value)
+		if slices.ContainsFunc(src.Enum, func(other any) bool { return enumKey(other) == key }) {
+			common = append(common, value)
+		}
+	}
+
+	if len(common) == 0 {
+		return conflict("enum", dst.Enum, src.Enum)
+	}
+	dst.Enum = common
+
+	return nil
+}
+
+func mergeProperties(dst *Schema, src Schema) error {
+	if len(src.Properties) == 0 {
+		return nil
+	}
+
+	properties := make(SchemaProperties, len(dst.Properties)+len(src.Properties))
+	maps.Copy(properties, dst.Properties)
+	dst.Properties = properties // do not alter the original schema
+
+	for _, name := range slices.Sorted(maps.Keys(src.Properties)) {
+		property := src.Properties[name]
+
+		existing, isDefined := dst.Properties[name]
+		if !isDefined {
+			dst.Properties[name] = property
+
+			continue
+		}
+
+		if err := mergeSchema(&existing, property); err != nil {
+			return fmt.Errorf("properties/%s: %w", name, err)
+		}
+		dst.Properties[name] = existing
+	}
+
+	return nil
+}
+
+func mergeAdditionalProperties(dst *Schema, src Schema) error {
+	if src.AdditionalProperties == nil {
+		return nil
+	}
+
+	switch {
+	case dst.AdditionalProperties == nil:
+		dst.AdditionalProperties = src.AdditionalProperties
+	case !dst.AdditionalProperties.Allows || !src.AdditionalProperties.Allows:
+		// no additional property is allowed by either schema
+		dst.AdditionalProperties = &SchemaOrBool{Allows: false}
+	case src.AdditionalProperties.Schema == nil:
+		// any additional property allowed by src: keep dst
+	case dst.AdditionalProperties.Schema == nil:
+		dst.AdditionalProperties = src.AdditionalProperties
+	default:
+		merged := *dst.AdditionalProperties.Schema
+		if err := mergeSchema(&merged, *src.AdditionalProperties.Schema); err != nil {
+			return fmt.Errorf("additionalProperties: %w", err)
+		}
+		dst.AdditionalProperties = &SchemaOrBool{Allows: true, Schema: &merged}
+	}
+
+	return nil
+}
+
+func mergeItems(dst *Schema, src Schema) error {
+	if src.Items == nil || src.Items.Len() == 0 {
+		return nil
+	}
+
+	if dst.Items == nil || dst.Items.Len() == 0 {
+		dst.Items = src.Items
+
+		return nil
+	}
+
+	if dst.Items.Schema == nil || src.Items.Schema == nil {
+		return conflict("items", "tuple", "tuple")
+	}
+
+	merged := *dst.Items.Schema
+	if err := mergeSchema(&merged, *src.Items.Schema); err != nil {
+		return fmt.Errorf("items: %w", err)
+	}
+	dst.Items = &SchemaOrArray{Schema: &merged}
+
+	return nil
+}
+
+// mergeCompositions keeps the anyOf, oneOf and not constraints which are only found in one schema.
+func mergeCompositions(dst *Schema, src Schema) error {
+	if len(src.AnyOf) > 0 {
+		if len(dst.AnyOf) > 0 {
+			return conflict("anyOf", len(dst.AnyOf), len(src.AnyOf))
+		}
+		dst.AnyOf = src.AnyOf
+	}
+
+	if len(src.OneOf) > 0 {
+		if len(dst.OneOf) > 0 {
+			return conflict("oneOf", len(dst.OneOf), len(src.OneOf))
+		}
+		dst.OneOf = src.OneOf
+	}
+
+	if src.Not != nil {
+		if dst.Not != nil {
+			return conflict("not", "schema", "schema")
+		}
+		dst.Not = src.Not
+	}
+
+	return nil
+}
+
+func lowest[T int64 | float64](a, b *T) *T {
+	if a == nil || (b != nil && *b < *a) {
+		return b
+	}
+
+	return a
+}
+
+func highest[T int64 | float64](a, b *T) *T {
+	if a == nil || (b != nil && *b > *a) {
+		return b
+	}
+
+	return a
+}
+
+func conflict(keyword string, a, b any) error {
+	return fmt.Errorf("%s: cannot merge %v with %v: %w", keyword, a, b, ErrMergeConflict)
+}
+
+// EffectiveProperties returns the properties and the required properties of an object schema,
+// once its $ref and allOf composition are resolved against root, e.g. to generate a single struct.
+//
+// Properties declared by several members of allOf are merged like with MergeAllOf.
+//
+// Schemas with a oneOf or anyOf composition, including in their allOf members, describe a union
+// rather than a flat object: they produce an error which matches ErrUnionSchema.
+func (s Schema) EffectiveProperties(root any) (map[string]Schema, []string, error) {
+	merged, err := resolveMember(s, root, make(map[string]struct{}))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(merged.OneOf) > 0 {
+		return nil, nil, fmt.Errorf("oneOf with %d alternatives: %w", len(merged.OneOf), ErrUnionSchema)
+	}
+	if len(merged.AnyOf) > 0 {
+		return nil, nil, fmt.Errorf("anyOf with %d alternatives: %w", len(merged.AnyOf), ErrUnionSchema)
+	}
+
+	return merged.Properties, merged.Required, nil
+}
+
+// IntersectConstraints computes the tightest numeric, string and array constraints satisfied by
+// all the schemas, so that an allOf composition can be validated in a single pass.
+//
+// The result holds the highest minimums and the lowest maximums, as well as unique items when any
+// schema requires them. Since several patterns or multiples cannot be expressed by a single keyword,
+// the first pattern (resp. multipleOf) is kept and the others are added as allOf members of the result.
+// Other keywords, such as types or properties, are ignored: see MergeAllOf to merge full schemas.
+//
+// Constraints which no value can satisfy, e.g. a minimum above the maximum, produce an error which
+// matches ErrUnsatisfiable.
+func IntersectConstraints(schemas ...Schema) (Schema, error) {
+	var result Schema
+
+	for _, schema := range schemas {
+		mergeBounds(&result, schema)
+		result.UniqueItems = result.UniqueItems || schema.UniqueItems
+
+		switch {
+		case schema.Pattern == "" || schema.Pattern == result.Pattern:
+		case result.Pattern == "":
+			result.Pattern = schema.Pattern
+		case !slices.ContainsFunc(result.AllOf, func(member Schema) bool { return member.Pattern == schema.Pattern }):
+			result.AllOf = append(result.AllOf, Schema{SchemaProps: SchemaProps{Pattern: schema.Pattern}})
+		}
+
+		switch {
+		case schema.MultipleOf == nil || (result.MultipleOf != nil && *schema.MultipleOf == *result.MultipleOf):
+		case result.MultipleOf == nil:
+			result.MultipleOf = schema.MultipleOf
+		case !slices.ContainsFunc(result.AllOf, func(member Schema) bool {
+			return member.MultipleOf != nil && *member.MultipleOf == *schema.MultipleOf
+		}):
+			result.AllOf = append(result.AllOf, Schema{SchemaProps: SchemaProps{MultipleOf: schema.MultipleOf}})
+		}
+	}
+
+	if err := satisfiable(result); err != nil {
+		return Schema{}, err
+	}
+
+	return result, nil
+}
+
+// satisfiable checks that the lower bounds of a schema do not exceed its upper bounds, see conflictingBounds.
+func satisfiable(schema Schema) error {
+	if conflicts := conflictingBounds(schema); len(conflicts) > 0 {
+		conflict := conflicts[0]
+
+		return unsatisfiable(conflict.lower.keyword, enumKey(conflict.lower.value), conflict.upper.keyword, enumKey(conflict.upper.value))
+	}
+
+	return nil
+}
+
+func unsatisfiable(lower string, minimum any, upper string, maximum any) error {
+	return fmt.Errorf("%s %v is incompatible with %s %v: %w", lower, minimum, upper, maximum, ErrUnsatisfiable)
+}
@@ -0,0 +1,304 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSchema_MergeAllOf(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"named": {
+					"type": "object",
+					"required": ["id", "name"],
+					"properties": {
+						"id": {"type": "number", "minimum": 0, "maximum": 1000},
+						"name": {"type": "string", "maxLength": 80}
+					}
+				},
+				"alias": {"$ref": "#/components/schemas/named"},
+				"loop": {"allOf": [{"$ref": "#/components/schemas/loop"}]}
+			}
+		}
+	}`), &root))
+
+	t.Run("should merge object schemas with overlapping and distinct properties", func(t *testing.T) {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"description": "a pet",
+			"allOf": [
+				{"$ref": "#/components/schemas/alias"},
+				{
+					"type": "object",
+					"required": ["id", "tag"],
+					"properties": {
+						"id": {"type": "integer", "minimum": 1, "maximum": 2000},
+						"name": {"minLength": 2},
+						"tag": {"type": "string", "enum": ["cat", "dog"]}
+					}
+				},
+				{"allOf": [{"properties": {"tag": {"enum": ["dog", "bird"]}}}]}
+			]
+		}`), &schema))
+
+		merged, err := schema.MergeAllOf(root)
+		require.NoError(t, err)
+
+		assert.Empty(t, merged.AllOf)
+		assert.Equal(t, "a pet", merged.Description)
+		assert.Equal(t, StringOrArray{"object"}, merged.Type)
+		assert.Equal(t, []string{"id", "name", "tag"}, merged.Required)
+		require.Len(t, merged.Properties, 3)
+
+		id := merged.Properties["id"]
+		assert.Equal(t, StringOrArray{"integer"}, id.Type)
+		require.NotNil(t, id.Minimum)
+		require.NotNil(t, id.Maximum)
+		assert.InDelta(t, 1, *id.Minimum, 1e-6)
+		assert.InDelta(t, 1000, *id.Maximum, 1e-6)
+
+		name := merged.Properties["name"]
+		assert.Equal(t, StringOrArray{"string"}, name.Type)
+		assert.Equal(t, int64Ptr(2), name.MinLength)
+		assert.Equal(t, int64Ptr(80), name.MaxLength)
+
+		assert.Equal(t, []any{"dog"}, merged.Properties["tag"].Enum)
+
+		// the original schemas are left unchanged
+		assert.Len(t, root.Components.Schemas["named"].Properties["name"].Type, 1)
+		assert.Nil(t, root.Components.Schemas["named"].Properties["name"].MinLength)
+		assert.Len(t, schema.AllOf, 3)
+	})
+
+	t.Run("should merge nullable flags", func(t *testing.T) {
+		nullable, notNullable := true, false
+
+		for _, tc := range []struct {
+			name          string
+			schema, other *bool
+			expected      *bool
+		}{
+			{name: "nullable schema and unset member", schema: &nullable, expected: &nullable},
+			{name: "unset schema and nullable member", other: &nullable, expected: &nullable},
+			{name: "nullable schema and non nullable member", schema: &nullable, other: &notNullable, expected: &notNullable},
+			{name: "nullable schema and nullable member", schema: &nullable, other: &nullable, expected: &nullable},
+			{name: "unset flags"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				schema := Schema{SchemaProps: SchemaProps{
+					Nullable: tc.schema,
+					AllOf:    []Schema{{SchemaProps: SchemaProps{Type: StringOrArray{"string"}, Nullable: tc.other}}},
+				}}
+
+				merged, err := schema.MergeAllOf(nil)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, merged.Nullable)
+			})
+		}
+
+		t.Run("should not alter the flags of the merged schemas", func(t *testing.T) {
+			flag := true
+			schema := Schema{SchemaProps: SchemaProps{
+				Nullable: &flag,
+				AllOf:    []Schema{{SchemaProps: SchemaProps{Nullable: &notNullable}}},
+			}}
+
+			_, err := schema.MergeAllOf(nil)
+			require.NoError(t, err)
+			assert.True(t, flag)
+		})
+	})
+
+	t.Run("should leave a schema without allOf unchanged", func(t *testing.T) {
+		schema := *StringProperty()
+
+		merged, err := schema.MergeAllOf(nil)
+		require.NoError(t, err)
+		assert.Equal(t, schema, *merged)
+	})
+
+	for _, tc := range []struct {
+		name   string
+		schema string
+	}{
+		{
+			name:   "conflicting types",
+			schema: `{"allOf": [{"type": "object"}, {"type": "string"}]}`,
+		},
+		{
+			name:   "conflicting property formats",
+			schema: `{"allOf": [{"properties": {"at": {"format": "date"}}}, {"properties": {"at": {"format": "date-time"}}}]}`,
+		},
+		{
+			name:   "disjoint enums",
+			schema: `{"allOf": [{"enum": ["a"]}, {"enum": ["b"]}]}`,
+		},
+		{
+			name:   "bounds which no value satisfies",
+			schema: `{"allOf": [{"minimum": 10}, {"maximum": 5}]}`,
+		},
+		{
+			name:   "property lengths which no value satisfies",
+			schema: `{"allOf": [{"properties": {"code": {"minLength": 3}}}, {"properties": {"code": {"maxLength": 2}}}]}`,
+		},
+		{
+			name:   "circular $ref",
+			schema: `{"allOf": [{"$ref": "#/components/schemas/loop"}]}`,
+		},
+	} {
+		t.Run("should fail on "+tc.name, func(t *testing.T) {
+			var schema Schema
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+
+			_, err := schema.MergeAllOf(root)
+			require.ErrorIs(t, err, ErrMergeConflict)
+		})
+	}
+
+	t.Run("should report the bounds which no value satisfies", func(t *testing.T) {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(`{"allOf": [{"minimum": 10}, {"maximum": 5}]}`), &schema))
+
+		_, err := schema.MergeAllOf(root)
+		require.ErrorIs(t, err, ErrUnsatisfiable)
+		assert.ErrorContains(t, err, "allOf/1: minimum 10 is incompatible with maximum 5")
+	})
+
+	t.Run("should fail on unresolved $ref", func(t *testing.T) {
+		schema := Schema{SchemaProps: SchemaProps{AllOf: []Schema{*RefSchema("#/components/schemas/missing")}}}
+
+		_, err := schema.MergeAllOf(root)
+		require.Error(t, err)
+	})
+}
+
+func TestSchema_EffectiveProperties(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"Base": {
+					"type": "object",
+					"required": ["id"],
+					"properties": {"id": {"type": "integer"}}
+				},
+				"Pet": {
+					"allOf": [
+						{"$ref": "#/components/schemas/Base"},
+						{"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}}
+					]
+				},
+				"Either": {"oneOf": [{"$ref": "#/components/schemas/Base"}, {"type": "string"}]}
+			}
+		}
+	}`), &root))
+
+	t.Run("should merge an allOf with a $ref base and a local extension", func(t *testing.T) {
+		properties, required, err := RefSchema("#/components/schemas/Pet").EffectiveProperties(root)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"id", "name"}, required)
+		require.Len(t, properties, 2)
+		assert.Equal(t, StringOrArray{"integer"}, properties["id"].Type)
+		assert.Equal(t, StringOrArray{"string"}, properties["name"].Type)
+	})
+
+	t.Run("should report a union", func(t *testing.T) {
+		schema := Schema{SchemaProps: SchemaProps{AllOf: []Schema{*RefSchema("#/components/schemas/Either")}}}
+
+		_, _, err := schema.EffectiveProperties(root)
+		require.ErrorIs(t, err, ErrUnionSchema)
+		assert.EqualError(t, err, "oneOf with 2 alternatives: schema is a union of alternatives")
+	})
+}
+
+func TestIntersectConstraints(t *testing.T) {
+	t.Run("should intersect numeric ranges", func(t *testing.T) {
+		intersection, err := IntersectConstraints(
+			*new(Schema).WithMinimum(0, false).WithMaximum(100, false),
+			*new(Schema).WithMinimum(10, true).WithMaximum(200, false),
+			*new(Schema).WithMaximum(100, true),
+		)
+		require.NoError(t, err)
+
+		require.NotNil(t, intersection.Minimum)
+		require.NotNil(t, intersection.Maximum)
+		assert.InDelta(t, 10, *intersection.Minimum, 1e-6)
+		assert.True(t, intersection.ExclusiveMinimum)
+		assert.InDelta(t, 100, *intersection.Maximum, 1e-6)
+		assert.True(t, intersection.ExclusiveMaximum)
+	})
+
+	t.Run("should intersect string and array constraints", func(t *testing.T) {
+		intersection, err := IntersectConstraints(
+			*new(Schema).WithMinLength(2).WithMaxLength(80).WithPattern("^[a-z]+$"),
+			*new(Schema).WithMaxLength(20).WithPattern("^a").WithMinItems(1),
+			*new(Schema).WithPattern("^[a-z]+$").WithMaxItems(3).UniqueValues(),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64Ptr(2), intersection.MinLength)
+		assert.Equal(t, int64Ptr(20), intersection.MaxLength)
+		assert.Equal(t, int64Ptr(1), intersection.MinItems)
+		assert.Equal(t, int64Ptr(3), intersection.MaxItems)
+		assert.True(t, intersection.UniqueItems)
+
+		assert.Equal(t, "^[a-z]+$", intersection.Pattern)
+		require.Len(t, intersection.AllOf, 1)
+		assert.Equal(t, "^a", intersection.AllOf[0].Pattern)
+	})
+
+	for _, tc := range []struct {
+		name    string
+		schemas []Schema
+	}{
+		{
+			name:    "disjoint numeric ranges",
+			schemas: []Schema{*new(Schema).WithMinimum(10, false), *new(Schema).WithMaximum(5, false)},
+		},
+		{
+			name:    "an exclusive bound on an empty range",
+			schemas: []Schema{*new(Schema).WithMinimum(5, false), *new(Schema).WithMaximum(5, true)},
+		},
+		{
+			name:    "a numeric exclusive bound on an empty range",
+			schemas: []Schema{{SchemaProps: SchemaProps{ExclusiveMinValue: float64Ptr(5)}}, *new(Schema).WithMaximum(5, false)},
+		},
+		{
+			name: "disjoint numeric exclusive bounds",
+			schemas: []Schema{
+				{SchemaProps: SchemaProps{ExclusiveMinValue: float64Ptr(10)}},
+				{SchemaProps: SchemaProps{ExclusiveMaxValue: float64Ptr(3)}},
+			},
+		},
+		{
+			name:    "disjoint lengths",
+			schemas: []Schema{*new(Schema).WithMinLength(10), *new(Schema).WithMaxLength(5)},
+		},
+	} {
+		t.Run("should fail on "+tc.name, func(t *testing.T) {
+			_, err := IntersectConstraints(tc.schemas...)
+			require.ErrorIs(t, err, ErrUnsatisfiable)
+		})
+	}
+
+	t.Run("should accept a range bounded by numeric exclusive bounds", func(t *testing.T) {
+		intersection, err := IntersectConstraints(
+			*new(Schema).WithMinimum(1, false),
+			Schema{SchemaProps: SchemaProps{ExclusiveMinValue: float64Ptr(1), ExclusiveMaxValue: float64Ptr(2)}},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, float64Ptr(1), intersection.ExclusiveMinValue)
+		assert.Equal(t, float64Ptr(2), intersection.ExclusiveMaxValue)
+	})
+}
@@ -48,7 +48,7 @@ var schema = Schema{
 		}}},
 	},
 	SwaggerSchemaProps: SwaggerSchemaProps{
-		Discriminator: "not this",
+		Discriminator: &Discriminator{PropertyName: "not this"},
 		ReadOnly:      true,
 		XML:           &XMLObject{Name: "sch", Namespace: "io", Prefix: "sw", Attribute: true, Wrapped: true},
 		ExternalDocs: &ExternalDocumentation{
@@ -108,7 +108,9 @@ var schemaJSON = `{
       "type": "string"
     }
   },
-  "discriminator": "not this",
+  "discriminator": {
+    "propertyName": "not this"
+  },
   "readOnly": true,
   "xml": {
     "name": "sch",
@@ -189,9 +191,10 @@ func TestSchema(t *testing.T) {
 	exp1 := expEx[0].(map[string]any)
 	exp2 := expEx[1].(map[string]any)
 
-	assert.EqualValues(t, exp1["id"], ex1["id"])
+	// numbers are kept as found in JSON
+	assert.Equal(t, json.Number("1"), ex1["id"])
 	assert.Equal(t, exp1["name"], ex1["name"])
-	assert.EqualValues(t, exp2["id"], ex2["id"])
+	assert.Equal(t, json.Number("2"), ex2["id"])
 	assert.Equal(t, exp2["name"], ex2["name"])
 }
 
@@ -211,3 +214,279 @@ func TestSchemaWithValidation(t *testing.T) {
 
 	assert.Equal(t, val, s.Validations())
 }
+
+func TestSchemaWithSingleValue(t *testing.T) {
+	t.Run("should use const by default", func(t *testing.T) {
+		s := StringProperty().WithEnum("a", "b").WithSingleValue("cat")
+		assertSerializeJSON(t, s, `{"type":"string","const":"cat"}`)
+	})
+
+	t.Run("should use const for OpenAPI 3.1", func(t *testing.T) {
+		s := StringProperty().WithSingleValue("cat", BuilderOptions{OpenAPIVersion: "3.1.0"})
+		assertSerializeJSON(t, s, `{"type":"string","const":"cat"}`)
+	})
+
+	t.Run("should use a single value enum for OpenAPI 3.0", func(t *testing.T) {
+		s := StringProperty().WithSingleValue("cat", BuilderOptions{OpenAPIVersion: "3.0.3"})
+		assertSerializeJSON(t, s, `{"type":"string","enum":["cat"]}`)
+	})
+
+	t.Run("should parse const", func(t *testing.T) {
+		var s Schema
+		require.NoError(t, json.Unmarshal([]byte(`{"const":"cat"}`), &s))
+		assert.Equal(t, "cat", s.Const)
+		assert.Empty(t, s.ExtraProps)
+	})
+
+	t.Run("should express a null const", func(t *testing.T) {
+		assertSerializeJSON(t, new(Schema).WithSingleValue(nil), `{"const":null}`)
+
+		var s Schema
+		require.NoError(t, json.Unmarshal([]byte(`{"const":null}`), &s))
+		assert.True(t, s.HasConst)
+		assert.Nil(t, s.Const)
+		assertSerializeJSON(t, s, `{"const":null}`)
+
+		assert.Empty(t, s.Validate(nil, nil))
+		assert.Len(t, s.Validate("cat", nil), 1)
+	})
+}
+
+func TestSchemaWithFormat(t *testing.T) {
+	s := StringProperty().WithFormat("uuid")
+	assert.Equal(t, StringOrArray{"string"}, s.Type)
+	assert.Equal(t, "uuid", s.Format)
+
+	s = new(Schema).Typed("integer", "int32").WithFormat("int64")
+	assert.Equal(t, StringOrArray{"integer"}, s.Type)
+	assert.Equal(t, "int64", s.Format)
+}
+
+func TestSchemaMetadataBuilders(t *testing.T) {
+	t.Run("should chain metadata builders", func(t *testing.T) {
+		s := StringProperty().
+			WithTitle("Name").
+			WithDescription("the name of the pet").
+			WithDefault("rex").
+			WithExample("fido").
+			WithExampleValue("rex").
+			WithExampleValue("felix").
+			WithDeprecated(true)
+
+		assertSerializeJSON(t, s,
+			`{"description":"the name of the pet","type":"string","title":"Name","default":"rex","example":"fido","deprecated":true,"examples":["rex","felix"]}`)
+	})
+
+	t.Run("should parse deprecated", func(t *testing.T) {
+		var s Schema
+		require.NoError(t, json.Unmarshal([]byte(`{"deprecated":true}`), &s))
+		assert.True(t, s.Deprecated)
+		assert.Empty(t, s.ExtraProps)
+
+		assertSerializeJSON(t, s.WithDeprecated(false), `{}`)
+	})
+}
+
+func TestSchema_DeprecatedProperties(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"LegacyId": {"type": "integer", "deprecated": true},
+				"OldId": {"$ref": "#/components/schemas/LegacyId"},
+				"Loop": {"$ref": "#/components/schemas/Loop"}
+			}
+		}
+	}`), &root))
+
+	var schema Schema
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"id": {"type": "string"},
+			"nickname": {"type": "string", "deprecated": true},
+			"legacyId": {"$ref": "#/components/schemas/OldId"},
+			"loop": {"$ref": "#/components/schemas/Loop"},
+			"missing": {"$ref": "#/components/schemas/Missing"}
+		}
+	}`), &schema))
+
+	t.Run("should report deprecated properties", func(t *testing.T) {
+		assert.Equal(t, []string{"legacyId", "nickname"}, schema.DeprecatedProperties(root))
+	})
+
+	t.Run("should not follow $ref's without root", func(t *testing.T) {
+		assert.Equal(t, []string{"nickname"}, schema.DeprecatedProperties(nil))
+	})
+
+	t.Run("should mark a property deprecated", func(t *testing.T) {
+		s := new(Schema).
+			SetProperty("id", *StringProperty()).
+			SetProperty("name", *StringProperty()).
+			MarkPropertyDeprecated("name").
+			MarkPropertyDeprecated("undeclared")
+
+		assert.Equal(t, []string{"name"}, s.DeprecatedProperties(nil))
+		assert.NotContains(t, s.Properties, "undeclared")
+	})
+}
+
+func TestBooleanSchemas(t *testing.T) {
+	t.Run("should build the boolean schema forms", func(t *testing.T) {
+		assertSerializeJSON(t, TrueSchema(), `true`)
+		assertSerializeJSON(t, FalseSchema(), `false`)
+		assertSerializeJSON(t, TrueSchema().Typed("string", ""), `{"type":"string"}`)
+		assertSerializeJSON(t, NullProperty(), `{"type":"null"}`)
+
+		assert.Empty(t, TrueSchema().Validate(map[string]any{"any": "value"}, nil))
+		assert.Len(t, FalseSchema().Validate("value", nil), 1)
+		assert.Empty(t, NullProperty().Validate(nil, nil))
+		assert.Len(t, NullProperty().Validate("value", nil), 1)
+	})
+
+	t.Run("should unmarshal boolean schemas", func(t *testing.T) {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"type": "object",
+			"properties": {"any": true, "none": false},
+			"items": false
+		}`), &schema))
+
+		assert.Equal(t, *TrueSchema(), schema.Properties["any"])
+		assert.Equal(t, *FalseSchema(), schema.Properties["none"])
+		require.NotNil(t, schema.Items)
+		assert.Equal(t, FalseSchema(), schema.Items.Schema)
+	})
+
+	t.Run("should round-trip boolean schemas", func(t *testing.T) {
+		const doc = `{"type":"object","items":false,"not":{},"properties":{"any":true,"none":false}}`
+
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(doc), &schema))
+
+		assertSerializeJSON(t, schema, doc)
+	})
+
+	for _, allows := range []string{"true", "false"} {
+		t.Run("should round-trip additionalProperties "+allows, func(t *testing.T) {
+			doc := `{"type":"object","additionalProperties":` + allows + `}`
+
+			var schema Schema
+			require.NoError(t, json.Unmarshal([]byte(doc), &schema))
+			require.NotNil(t, schema.AdditionalProperties)
+			assert.Equal(t, allows == "true", schema.AdditionalProperties.Allows)
+			assert.Nil(t, schema.AdditionalProperties.Schema)
+
+			assertSerializeJSON(t, schema, doc)
+		})
+	}
+}
+
+func TestSchema_NumbersRoundTrip(t *testing.T) {
+	var schema Schema
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "integer",
+		"format": "int64",
+		"enum": [1, 2, 3, 9007199254740993],
+		"default": 2,
+		"const": 3,
+		"example": 9007199254740993,
+		"examples": [9007199254740993],
+		"x-max-id": 9007199254740993,
+		"properties": {
+			"ratio": {"type": "number", "enum": [1.0, 2.50], "default": 1.0}
+		}
+	}`), &schema))
+
+	t.Run("should re-marshal integers as integers", func(t *testing.T) {
+		b, err := json.Marshal(schema)
+		require.NoError(t, err)
+		marshaled := string(b)
+
+		assert.Contains(t, marshaled, `"enum":[1,2,3,9007199254740993]`)
+		assert.Contains(t, marshaled, `"default":2`)
+		assert.Contains(t, marshaled, `"const":3`)
+		assert.Contains(t, marshaled, `"example":9007199254740993`)
+	})
+
+	t.Run("should re-marshal integers of extensions and extra properties as integers", func(t *testing.T) {
+		assert.Equal(t, json.Number("9007199254740993"), schema.Extensions["x-max-id"])
+
+		b, err := json.Marshal(schema)
+		require.NoError(t, err)
+		marshaled := string(b)
+
+		assert.Contains(t, marshaled, `"examples":[9007199254740993]`)
+		assert.Contains(t, marshaled, `"x-max-id":9007199254740993`)
+	})
+
+	t.Run("should re-marshal numbers as found", func(t *testing.T) {
+		b, err := json.Marshal(schema.Properties["ratio"])
+		require.NoError(t, err)
+
+		assert.Contains(t, string(b), `"enum":[1.0,2.50]`)
+		assert.Contains(t, string(b), `"default":1.0`)
+	})
+
+	t.Run("should validate with numbers", func(t *testing.T) {
+		assert.Empty(t, schema.ValidateEnum())
+		assert.Empty(t, schema.ValidateFormat())
+		assert.Empty(t, schema.Properties["ratio"].ValidateEnum())
+		assert.Empty(t, schema.Validate(3, nil))
+		assert.NotEmpty(t, schema.Validate(4, nil))
+	})
+}
+
+func TestSchema_ExclusiveBounds(t *testing.T) {
+	t.Run("should unmarshal boolean exclusive bounds", func(t *testing.T) {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(`{"type": "number", "minimum": 5, "exclusiveMinimum": true, "maximum": 10}`), &schema))
+
+		assert.True(t, schema.ExclusiveMinimum)
+		assert.Nil(t, schema.ExclusiveMinValue)
+		assert.Empty(t, schema.ExtraProps)
+
+		value, set := schema.EffectiveExclusiveMinimum()
+		assert.True(t, set)
+		assert.InDelta(t, 5.0, value, 0)
+
+		_, set = schema.EffectiveExclusiveMaximum()
+		assert.False(t, set)
+	})
+
+	t.Run("should unmarshal numeric exclusive bounds", func(t *testing.T) {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(`{"type": "number", "exclusiveMinimum": 5, "exclusiveMaximum": 0}`), &schema))
+
+		assert.False(t, schema.ExclusiveMinimum)
+		assert.Nil(t, schema.Minimum)
+		assert.Equal(t, float64Ptr(5), schema.ExclusiveMinValue)
+		assert.Equal(t, float64Ptr(0), schema.ExclusiveMaxValue)
+		assert.Empty(t, schema.ExtraProps)
+
+		value, set := schema.EffectiveExclusiveMinimum()
+		assert.True(t, set)
+		assert.InDelta(t, 5.0, value, 0)
+
+		value, set = schema.EffectiveExclusiveMaximum()
+		assert.True(t, set)
+		assert.InDelta(t, 0.0, value, 0)
+
+		b, err := json.Marshal(schema)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"type": "number", "exclusiveMinimum": 5, "exclusiveMaximum": 0}`, string(b))
+	})
+
+	t.Run("should ignore a boolean modifier without bound", func(t *testing.T) {
+		schema := Schema{SchemaProps: SchemaProps{ExclusiveMinimum: true}}
+
+		_, set := schema.EffectiveExclusiveMinimum()
+		assert.False(t, set)
+	})
+
+	t.Run("should reject invalid exclusive bounds", func(t *testing.T) {
+		var schema Schema
+		require.Error(t, json.Unmarshal([]byte(`{"exclusiveMaximum": "10"}`), &schema))
+	})
+}
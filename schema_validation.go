@@ -0,0 +1,561 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// ValidateObjectSchema checks that closed objects may actually be satisfied.
+//
+// Whenever a schema declares "additionalProperties: false", every name in "required"
+// must be declared in "properties", either directly or through an allOf composition,
+// or be matched by some "patternProperties".
+//
+// All schemas nested in s are checked. Each offending name is reported as a *ValidationError
+// located by a JSON pointer relative to s.
+func (s Schema) ValidateObjectSchema() []error {
+	var errs []error
+
+	walkSchema(s, "", func(schema Schema, location string) {
+		if schema.AdditionalProperties == nil || schema.AdditionalProperties.Allows || schema.AdditionalProperties.Schema != nil {
+			return
+		}
+
+		declared, complete := declaredProperties(schema)
+		if !complete {
+			// some properties come from a $ref: this cannot be decided without resolving it
+			return
+		}
+
+		for i, name := range schema.Required {
+			if declared(name) {
+				continue
+			}
+
+			errs = append(errs, &ValidationError{
+				Path:    location + pointerTo("required", strconv.Itoa(i)),
+				Message: fmt.Sprintf("required property %q is not declared and additional properties are not allowed", name),
+			})
+		}
+	})
+
+	return errs
+}
+
+// ValidateEnum checks that enumerated values are consistent.
+//
+// Every member of "enum" must be of one of the declared types and appear only once.
+// When "enum" is not empty, the "default" value must be one of its members.
+//
+// All schemas nested in s are checked. Each problem is reported as a *ValidationError
+// located by a JSON pointer relative to s.
+func (s Schema) ValidateEnum() []error {
+	var errs []error
+
+	walkSchema(s, "", func(schema Schema, location string) {
+		if len(schema.Enum) == 0 {
+			return
+		}
+
+		seen := make(map[string]int, len(schema.Enum))
+		for i, value := range schema.Enum {
+			pointer := location + pointerTo("enum", strconv.Itoa(i))
+			key := enumKey(value)
+
+			if !schema.allowsType(value) {
+				errs = append(errs, &ValidationError{
+					Path:    pointer,
+					Message: fmt.Sprintf("enum value %s does not match type %v", key, []string(schema.Type)),
+				})
+			}
+
+			if first, duplicate := seen[key]; duplicate {
+				errs = append(errs, &ValidationError{
+					Path:    pointer,
+					Message: fmt.Sprintf("enum value %s duplicates the value at index %d", key, first),
+				})
+
+				continue
+			}
+			seen[key] = i
+		}
+
+		if schema.Default == nil {
+			return
+		}
+
+		key := enumKey(schema.Default)
+		if _, isMember := seen[key]; !isMember {
+			errs = append(errs, &ValidationError{
+				Path:    location + pointerTo("default"),
+				Message: fmt.Sprintf("default value %s is not a member of enum", key),
+			})
+		}
+	})
+
+	return errs
+}
+
+// ValidateFormat checks that the numeric values of a schema fit its type and format.
+//
+// Values of integer schemas must be whole numbers. Values of schemas with format "int32", "int64"
+// or "float" must fit the range of this format, and values of schemas with format "byte" must be
+// base64 encoded strings. This applies to "default", "example", "const",
+// the members of "enum", as well as "minimum" and "maximum".
+//
+// All schemas nested in s are checked. Each problem is reported as a *ValidationError
+// located by a JSON pointer relative to s.
+func (s Schema) ValidateFormat() []error {
+	var errs []error
+
+	walkSchema(s, "", func(schema Schema, location string) {
+		check := func(value any, tokens ...string) {
+			for _, msg := range []string{schema.checkNumericFormat(value), schema.checkByteFormat(value)} {
+				if msg != "" {
+					errs = append(errs, &ValidationError{
+						Path:    location + pointerTo(tokens...),
+						Message: msg,
+					})
+				}
+			}
+		}
+
+		check(schema.Default, "default")
+		check(schema.Example, "example")
+		check(schema.Const, "const")
+		for i, value := range schema.Enum {
+			check(value, "enum", strconv.Itoa(i))
+		}
+		if schema.Minimum != nil {
+			check(*schema.Minimum, "minimum")
+		}
+		if schema.Maximum != nil {
+			check(*schema.Maximum, "maximum")
+		}
+	})
+
+	return errs
+}
+
+// ValidateBounds checks that the lower bounds of a schema do not exceed its upper bounds.
+//
+// This applies to "minimum" and "maximum", including their exclusive forms, "minLength" and "maxLength",
+// "minItems" and "maxItems", "minContains" and "maxContains", as well as "minProperties" and "maxProperties":
+// no value could satisfy such a schema otherwise.
+//
+// All schemas nested in s are checked. Each problem is reported as a *ValidationError
+// located by a JSON pointer relative to s.
+func (s Schema) ValidateBounds() []error {
+	var errs []error
+
+	walkSchema(s, "", func(schema Schema, location string) {
+		for _, conflict := range conflictingBounds(schema) {
+			errs = append(errs, &ValidationError{
+				Path:    location + pointerTo(conflict.lower.keyword),
+				Message: conflict.String(),
+			})
+		}
+	})
+
+	return errs
+}
+
+// bound is a lower or an upper bound of a schema.
+type bound struct {
+	keyword   string
+	value     float64
+	exclusive bool
+}
+
+// boundConflict is a pair of bounds which no value can satisfy.
+type boundConflict struct {
+	lower, upper bound
+}
+
+func (c boundConflict) String() string {
+	relation := "is greater than"
+	if c.lower.value == c.upper.value {
+		relation = "is not less than"
+	}
+
+	return fmt.Sprintf("%s %s %s %s %s", c.lower.keyword, enumKey(c.lower.value), relation, c.upper.keyword, enumKey(c.upper.value))
+}
+
+// conflictingBounds returns the pairs of bounds of a schema which no value can satisfy, e.g. a minimum above the maximum.
+//
+// Numeric bounds are compared by their tightest form, either inclusive or exclusive: a value cannot be both
+// greater than an exclusive minimum of 1 and lower than, or equal to, a maximum of 1.
+func conflictingBounds(schema Schema) []boundConflict {
+	var conflicts []boundConflict
+
+	lower, hasLower := lowerBound(schema)
+	upper, hasUpper := upperBound(schema)
+	if hasLower && hasUpper && (lower.value > upper.value || lower.value == upper.value && (lower.exclusive || upper.exclusive)) {
+		conflicts = append(conflicts, boundConflict{lower: lower, upper: upper})
+	}
+
+	for _, counts := range []struct {
+		lower, upper     string
+		minimum, maximum *int64
+	}{
+		{"minLength", "maxLength", schema.MinLength, schema.MaxLength},
+		{"minItems", "maxItems", schema.MinItems, schema.MaxItems},
+		{"minContains", "maxContains", schema.MinContains, schema.MaxContains},
+		{"minProperties", "maxProperties", schema.MinProperties, schema.MaxProperties},
+	} {
+		if counts.minimum != nil && counts.maximum != nil && *counts.minimum > *counts.maximum {
+			conflicts = append(conflicts, boundConflict{
+				lower: bound{keyword: counts.lower, value: float64(*counts.minimum)},
+				upper: bound{keyword: counts.upper, value: float64(*counts.maximum)},
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// lowerBound returns the tightest lower bound of a number, among "minimum" and a numeric "exclusiveMinimum".
+func lowerBound(schema Schema) (bound, bool) {
+	if schema.ExclusiveMinValue != nil && (schema.Minimum == nil || *schema.ExclusiveMinValue >= *schema.Minimum) {
+		return bound{keyword: "exclusiveMinimum", value: *schema.ExclusiveMinValue, exclusive: true}, true
+	}
+	if schema.Minimum != nil {
+		return bound{keyword: "minimum", value: *schema.Minimum, exclusive: schema.ExclusiveMinimum}, true
+	}
+
+	return bound{}, false
+}
+
+// upperBound returns the tightest upper bound of a number, among "maximum" and a numeric "exclusiveMaximum".
+func upperBound(schema Schema) (bound, bool) {
+	if schema.ExclusiveMaxValue != nil && (schema.Maximum == nil || *schema.ExclusiveMaxValue <= *schema.Maximum) {
+		return bound{keyword: "exclusiveMaximum", value: *schema.ExclusiveMaxValue, exclusive: true}, true
+	}
+	if schema.Maximum != nil {
+		return bound{keyword: "maximum", value: *schema.Maximum, exclusive: schema.ExclusiveMaximum}, true
+	}
+
+	return bound{}, false
+}
+
+// ValidateCompositionRequired reports the properties required by a member of allOf, but declared
+// neither by the schema nor by any member of its allOf composition.
+//
+// Such properties may only be declared by a oneOf or anyOf alternative, or not at all: this is likely
+// a mistake in the composition. $ref's are resolved against root. Compositions which cannot be
+// merged, e.g. because some $ref cannot be resolved, are not checked.
+//
+// All schemas nested in s are checked. Each problem is located by a JSON pointer to the allOf member,
+// relative to s.
+func (s Schema) ValidateCompositionRequired(root any) []Warning {
+	var warnings []Warning
+
+	walkSchema(s, "", func(schema Schema, location string) {
+		if len(schema.AllOf) == 0 {
+			return
+		}
+
+		merged, err := schema.mergeAllOf(root, make(map[string]struct{}))
+		if err != nil {
+			return
+		}
+
+		for i, member := range schema.AllOf {
+			resolved, err := resolveMember(member, root, make(map[string]struct{}))
+			if err != nil {
+				return
+			}
+
+			for _, name := range resolved.Required {
+				if _, isDeclared := merged.Properties[name]; isDeclared {
+					continue
+				}
+
+				warnings = append(warnings, Warning{
+					Path:    location + pointerTo("allOf", strconv.Itoa(i)),
+					Message: fmt.Sprintf("required property %q is not declared by any member of allOf", name),
+				})
+			}
+		}
+	})
+
+	return warnings
+}
+
+// checkByteFormat explains why a string value of a schema with format "byte" is not base64 encoded.
+//
+// It returns an empty string when the value is base64 encoded, is not a string, or the format is not "byte".
+func (s Schema) checkByteFormat(value any) string {
+	str, isString := value.(string)
+	if !isString || s.Format != "byte" || isBase64(str) {
+		return ""
+	}
+
+	return fmt.Sprintf("string %q is not base64 encoded, as required by format byte", str)
+}
+
+// isBase64 tells if a string is base64 encoded, with or without padding.
+func isBase64(str string) bool {
+	if _, err := base64.StdEncoding.DecodeString(str); err == nil {
+		return true
+	}
+	_, err := base64.RawStdEncoding.DecodeString(str)
+
+	return err == nil
+}
+
+// checkNumericFormat explains why a numeric value does not fit the type and format of the schema.
+//
+// It returns an empty string when the value fits, or is not a number.
+func (s Schema) checkNumericFormat(value any) string {
+	f, isNumber := numericValue(value)
+	if !isNumber {
+		return ""
+	}
+
+	if s.Type.Contains("integer") && !s.Type.Contains("number") && f != math.Trunc(f) {
+		return fmt.Sprintf("value %s is not an integer", enumKey(value))
+	}
+
+	var fits bool
+	switch s.Format {
+	case "int32":
+		fits = f >= math.MinInt32 && f <= math.MaxInt32
+	case "int64":
+		// MaxInt64 is rounded up to 2^63 as a float64
+		fits = isInt64(value) || (f >= math.MinInt64 && f < math.MaxInt64)
+	case "float":
+		fits = math.Abs(f) <= math.MaxFloat32
+	default:
+		return ""
+	}
+
+	if !fits {
+		return fmt.Sprintf("value %s overflows format %s", enumKey(value), s.Format)
+	}
+
+	return ""
+}
+
+// numericValue converts a go number to a float64.
+func numericValue(value any) (float64, bool) {
+	if number, isNumber := value.(json.Number); isNumber {
+		f, err := number.Float64()
+
+		return f, err == nil
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch {
+	case v.CanFloat():
+		return v.Float(), true
+	case v.CanInt():
+		return float64(v.Int()), true
+	case v.CanUint():
+		return float64(v.Uint()), true
+	}
+
+	return 0, false
+}
+
+// allowsType tells if a value is of one of the types declared by the schema.
+//
+// Any value is allowed when no type is declared.
+func (s Schema) allowsType(value any) bool {
+	if len(s.Type) == 0 {
+		return true
+	}
+
+	if value == nil {
+		return s.Type.Contains("null") || (s.Nullable != nil && *s.Nullable)
+	}
+
+	return slices.ContainsFunc(s.Type, func(tpe string) bool { return isOfType(value, tpe) })
+}
+
+func isOfType(value any, tpe string) bool {
+	if number, isNumber := value.(json.Number); isNumber {
+		// numbers unmarshaled from a schema, as found in the JSON document
+		f, err := number.Float64()
+
+		switch tpe {
+		case "integer":
+			return isInt64(number) || (err == nil && f == math.Trunc(f))
+		case "number":
+			return err == nil
+		}
+
+		return false
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch tpe {
+	case "integer":
+		if v.CanFloat() {
+			// JSON numbers are unmarshaled as float64
+			f := v.Float()
+			return f == math.Trunc(f)
+		}
+
+		return v.CanInt() || v.CanUint()
+	case "number":
+		return v.CanInt() || v.CanUint() || v.CanFloat()
+	case "string":
+		return v.Kind() == reflect.String
+	case "boolean":
+		return v.Kind() == reflect.Bool
+	case jsonArray:
+		return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+	case "object":
+		return v.Kind() == reflect.Map || v.Kind() == reflect.Struct
+	}
+
+	return false
+}
+
+// isInt64 tells if a value is a go integer or a JSON number, which fits into an int64.
+func isInt64(value any) bool {
+	if number, isNumber := value.(json.Number); isNumber {
+		_, err := number.Int64()
+
+		return err == nil
+	}
+
+	return reflect.ValueOf(value).CanInt()
+}
+
+// enumKey is the JSON representation of a value, so values may be compared regardless of their go type.
+//
+// JSON numbers are normalized, so that e.g. 1 and 1.0 have the same key.
+func enumKey(value any) string {
+	b, err := json.Marshal(normalizedInstance(value))
+	if err != nil {
+		return fmt.Sprintf("%#v", value)
+	}
+
+	return string(b)
+}
+
+// declaredProperties tells which property names are declared by a schema and its allOf members.
+//
+// It returns false when some allOf member is a $ref, i.e. when the declared properties are not all known.
+func declaredProperties(schema Schema) (func(string) bool, bool) {
+	names := make(map[string]struct{})
+	var patterns []*regexp.Regexp
+
+	var collect func(Schema) bool
+	collect = func(sch Schema) bool {
+		if sch.Ref.String() != "" {
+			return false
+		}
+
+		for name := range sch.Properties {
+			names[name] = struct{}{}
+		}
+
+		for pattern := range sch.PatternProperties {
+			rex, err := regexp.Compile(pattern)
+			if err != nil {
+				continue
+			}
+			patterns = append(patterns, rex)
+		}
+
+		for _, member := range sch.AllOf {
+			if !collect(member) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	complete := collect(schema)
+
+	return func(name string) bool {
+		if _, ok := names[name]; ok {
+			return true
+		}
+
+		return slices.ContainsFunc(patterns, func(rex *regexp.Regexp) bool { return rex.MatchString(name) })
+	}, complete
+}
+
+// walkSchema calls fn on schema, then on every schema nested in it, in a deterministic order.
+//
+// The location of each schema is a JSON pointer relative to the root schema, prefixed by location.
+func walkSchema(schema Schema, location string, fn func(Schema, string)) {
+	fn(schema, location)
+
+	walkSchemaMap(schema.Definitions, location+pointerTo("definitions"), fn)
+	walkSchemaMap(schema.Defs, location+pointerTo("$defs"), fn)
+
+	if schema.Items != nil {
+		if schema.Items.Schema != nil {
+			walkSchema(*schema.Items.Schema, location+pointerTo("items"), fn)
+		}
+		for i, item := range schema.Items.Schemas {
+			walkSchema(item, location+pointerTo("items", strconv.Itoa(i)), fn)
+		}
+	}
+	if schema.Contains != nil {
+		walkSchema(*schema.Contains, location+pointerTo("contains"), fn)
+	}
+
+	for i, member := range schema.AllOf {
+		walkSchema(member, location+pointerTo("allOf", strconv.Itoa(i)), fn)
+	}
+	for i, member := range schema.AnyOf {
+		walkSchema(member, location+pointerTo("anyOf", strconv.Itoa(i)), fn)
+	}
+	for i, member := range schema.OneOf {
+		walkSchema(member, location+pointerTo("oneOf", strconv.Itoa(i)), fn)
+	}
+	if schema.Not != nil {
+		walkSchema(*schema.Not, location+pointerTo("not"), fn)
+	}
+
+	walkSchemaMap(schema.Properties, location+pointerTo("properties"), fn)
+
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		walkSchema(*schema.AdditionalProperties.Schema, location+pointerTo("additionalProperties"), fn)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(schema.PatternProperties)) {
+		if sch := schema.PatternProperties[key].Schema; sch != nil {
+			walkSchema(*sch, location+pointerTo("patternProperties", key), fn)
+		}
+	}
+
+	if schema.PropertyNames != nil {
+		walkSchema(*schema.PropertyNames, location+pointerTo("propertyNames"), fn)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(schema.Dependencies)) {
+		if sch := schema.Dependencies[key].Schema; sch != nil {
+			walkSchema(*sch, location+pointerTo("dependencies", key), fn)
+		}
+	}
+
+	if schema.AdditionalItems != nil && schema.AdditionalItems.Schema != nil {
+		walkSchema(*schema.AdditionalItems.Schema, location+pointerTo("additionalItems"), fn)
+	}
+}
+
+func walkSchemaMap[M ~map[string]Schema](schemas M, location string, fn func(Schema, string)) {
+	for _, key := range slices.Sorted(maps.Keys(schemas)) {
+		walkSchema(schemas[key], location+pointerTo(key), fn)
+	}
+}
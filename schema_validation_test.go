@@ -0,0 +1,403 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSchema_ValidateObjectSchema(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schema   string
+		expected []string
+	}{
+		{
+			name: "closed object requiring an undeclared property",
+			schema: `{
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["id", "name"],
+				"properties": {"id": {"type": "integer"}}
+			}`,
+			expected: []string{`/required/1: required property "name" is not declared and additional properties are not allowed`},
+		},
+		{
+			name: "closed object with all required properties declared",
+			schema: `{
+				"additionalProperties": false,
+				"required": ["id"],
+				"properties": {"id": {"type": "integer"}}
+			}`,
+		},
+		{
+			name: "open object requiring an undeclared property",
+			schema: `{
+				"required": ["id"],
+				"properties": {"name": {"type": "string"}}
+			}`,
+		},
+		{
+			name: "closed object with properties composed with allOf",
+			schema: `{
+				"additionalProperties": false,
+				"required": ["id", "name", "tag"],
+				"allOf": [
+					{"properties": {"id": {"type": "integer"}}},
+					{"allOf": [{"properties": {"name": {"type": "string"}}}]}
+				]
+			}`,
+			expected: []string{`/required/2: required property "tag" is not declared and additional properties are not allowed`},
+		},
+		{
+			name: "closed object with a required property matched by a pattern",
+			schema: `{
+				"additionalProperties": false,
+				"required": ["x-id"],
+				"patternProperties": {"^x-": {"type": "string"}}
+			}`,
+		},
+		{
+			name: "closed object composed with a $ref",
+			schema: `{
+				"additionalProperties": false,
+				"required": ["id"],
+				"allOf": [{"$ref": "#/$defs/base"}]
+			}`,
+		},
+		{
+			name: "nested closed objects",
+			schema: `{
+				"properties": {
+					"pet": {
+						"additionalProperties": false,
+						"required": ["name"]
+					},
+					"tags": {
+						"type": "array",
+						"items": {
+							"additionalProperties": false,
+							"required": ["label"],
+							"properties": {"value": {}}
+						}
+					}
+				}
+			}`,
+			expected: []string{
+				`/properties/pet/required/0: required property "name" is not declared and additional properties are not allowed`,
+				`/properties/tags/items/required/0: required property "label" is not declared and additional properties are not allowed`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var schema Schema
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+
+			errs := schema.ValidateObjectSchema()
+			require.Len(t, errs, len(tc.expected))
+			for i, err := range errs {
+				require.ErrorIs(t, err, ErrSpec)
+				assert.EqualError(t, err, tc.expected[i])
+			}
+		})
+	}
+}
+
+func TestSchema_ValidateEnum(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schema   string
+		expected []string
+	}{
+		{
+			name:   "consistent string enum with a default",
+			schema: `{"type": "string", "enum": ["cat", "dog"], "default": "dog"}`,
+		},
+		{
+			name:   "untyped enum with mixed values",
+			schema: `{"enum": ["cat", 1, true, null]}`,
+		},
+		{
+			name:   "integer enum with a string and a decimal",
+			schema: `{"type": "integer", "enum": [1, "2", 3.5, 4.0]}`,
+			expected: []string{
+				`/enum/1: enum value "2" does not match type [integer]`,
+				`/enum/2: enum value 3.5 does not match type [integer]`,
+			},
+		},
+		{
+			name:   "number or string enum",
+			schema: `{"type": ["number", "string"], "enum": [1.5, "a", false]}`,
+			expected: []string{
+				`/enum/2: enum value false does not match type [number string]`,
+			},
+		},
+		{
+			name:   "nullable enum",
+			schema: `{"type": "string", "nullable": true, "enum": ["a", null]}`,
+		},
+		{
+			name:   "null member without null type",
+			schema: `{"type": "string", "enum": ["a", null]}`,
+			expected: []string{
+				`/enum/1: enum value null does not match type [string]`,
+			},
+		},
+		{
+			name:   "array and object members",
+			schema: `{"type": "object", "enum": [{"a": 1}, [1], {"a": 1}]}`,
+			expected: []string{
+				`/enum/1: enum value [1] does not match type [object]`,
+				`/enum/2: enum value {"a":1} duplicates the value at index 0`,
+			},
+		},
+		{
+			name:   "duplicate members",
+			schema: `{"type": "integer", "enum": [1, 2, 1.0]}`,
+			expected: []string{
+				`/enum/2: enum value 1 duplicates the value at index 0`,
+			},
+		},
+		{
+			name:   "default out of enum",
+			schema: `{"type": "string", "enum": ["cat", "dog"], "default": "bird"}`,
+			expected: []string{
+				`/default: default value "bird" is not a member of enum`,
+			},
+		},
+		{
+			name:   "default without enum",
+			schema: `{"type": "string", "default": "bird"}`,
+		},
+		{
+			name: "nested enum",
+			schema: `{
+				"properties": {
+					"kind": {"type": "boolean", "enum": [true, "false"], "default": false}
+				}
+			}`,
+			expected: []string{
+				`/properties/kind/enum/1: enum value "false" does not match type [boolean]`,
+				`/properties/kind/default: default value false is not a member of enum`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var schema Schema
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+
+			errs := schema.ValidateEnum()
+			require.Len(t, errs, len(tc.expected))
+			for i, err := range errs {
+				require.ErrorIs(t, err, ErrSpec)
+				assert.EqualError(t, err, tc.expected[i])
+			}
+		})
+	}
+
+	t.Run("should compare values regardless of their go type", func(t *testing.T) {
+		schema := Int64Property().WithEnum(1, 2).WithDefault(float64(2))
+		assert.Empty(t, schema.ValidateEnum())
+	})
+}
+
+func TestSchema_ValidateFormat(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schema   string
+		expected []string
+	}{
+		{
+			name:   "int32 values in range",
+			schema: `{"type": "integer", "format": "int32", "minimum": -2147483648, "maximum": 2147483647, "default": 12}`,
+		},
+		{
+			name:   "int32 field receiving 2^40",
+			schema: `{"type": "integer", "format": "int32", "default": 1099511627776, "enum": [1, 1099511627776]}`,
+			expected: []string{
+				`/default: value 1099511627776 overflows format int32`,
+				`/enum/1: value 1099511627776 overflows format int32`,
+			},
+		},
+		{
+			name:   "int64 values",
+			schema: `{"type": "integer", "format": "int64", "example": 1099511627776, "maximum": 1e19}`,
+			expected: []string{
+				`/maximum: value 10000000000000000000 overflows format int64`,
+			},
+		},
+		{
+			name:   "integer receiving a decimal",
+			schema: `{"type": "integer", "default": 1.5, "const": 2.0}`,
+			expected: []string{
+				`/default: value 1.5 is not an integer`,
+			},
+		},
+		{
+			name:   "float and double values",
+			schema: `{"properties": {"f": {"type": "number", "format": "float", "maximum": 1e39}, "d": {"type": "number", "format": "double", "maximum": 1e39}}}`,
+			expected: []string{
+				`/properties/f/maximum: value 1e+39 overflows format float`,
+			},
+		},
+		{
+			name:   "byte values",
+			schema: `{"type": "string", "format": "byte", "default": "aGVsbG8=", "example": "aGVsbG8", "enum": ["aGVsbG8=", "hello!"]}`,
+			expected: []string{
+				`/enum/1: string "hello!" is not base64 encoded, as required by format byte`,
+			},
+		},
+		{
+			name:   "non numeric values",
+			schema: `{"type": "integer", "format": "int32", "default": "1099511627776"}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var schema Schema
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+
+			errs := schema.ValidateFormat()
+			require.Len(t, errs, len(tc.expected))
+			for i, err := range errs {
+				require.ErrorIs(t, err, ErrSpec)
+				assert.EqualError(t, err, tc.expected[i])
+			}
+		})
+	}
+
+	t.Run("should accept go integers at the bounds of int64", func(t *testing.T) {
+		schema := Int64Property().WithDefault(int64(math.MaxInt64))
+		assert.Empty(t, schema.ValidateFormat())
+	})
+}
+
+func TestSchema_ValidateBounds(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schema   string
+		expected []string
+	}{
+		{
+			name:   "consistent bounds",
+			schema: `{"minimum": 1, "maximum": 1, "minLength": 0, "maxLength": 8, "minItems": 1, "minProperties": 2, "maxProperties": 3}`,
+		},
+		{
+			name:   "inverted bounds",
+			schema: `{"minimum": 1.5, "maximum": 1, "minLength": 9, "maxLength": 8, "properties": {"tags": {"minItems": 3, "maxItems": 1}}}`,
+			expected: []string{
+				`/minimum: minimum 1.5 is greater than maximum 1`,
+				`/minLength: minLength 9 is greater than maxLength 8`,
+				`/properties/tags/minItems: minItems 3 is greater than maxItems 1`,
+			},
+		},
+		{
+			name:     "inverted contains counts",
+			schema:   `{"type": "array", "contains": {"type": "string"}, "minContains": 3, "maxContains": 2}`,
+			expected: []string{`/minContains: minContains 3 is greater than maxContains 2`},
+		},
+		{
+			name:   "empty exclusive ranges",
+			schema: `{"minimum": 1, "maximum": 1, "exclusiveMaximum": true, "properties": {"score": {"exclusiveMinimum": 3, "maximum": 2}, "ratio": {"minimum": 0, "exclusiveMinimum": 1, "exclusiveMaximum": 1}}}`,
+			expected: []string{
+				`/minimum: minimum 1 is not less than maximum 1`,
+				`/properties/ratio/exclusiveMinimum: exclusiveMinimum 1 is not less than exclusiveMaximum 1`,
+				`/properties/score/exclusiveMinimum: exclusiveMinimum 3 is greater than maximum 2`,
+			},
+		},
+		{
+			name:     "inverted property counts",
+			schema:   `{"type": "object", "minProperties": 3, "maxProperties": 2}`,
+			expected: []string{`/minProperties: minProperties 3 is greater than maxProperties 2`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var schema Schema
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+
+			errs := schema.ValidateBounds()
+			require.Len(t, errs, len(tc.expected))
+			for i, err := range errs {
+				require.ErrorIs(t, err, ErrSpec)
+				assert.EqualError(t, err, tc.expected[i])
+			}
+		})
+	}
+
+	t.Run("should build property counts", func(t *testing.T) {
+		schema := new(Schema).Typed("object", "").WithMinProperties(3).WithMaxProperties(2)
+		assert.Len(t, schema.ValidateBounds(), 1)
+	})
+}
+
+func TestSchema_ValidateCompositionRequired(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"components": {
+			"schemas": {
+				"named": {"properties": {"name": {"type": "string"}}}
+			}
+		}
+	}`), &root))
+
+	for _, tc := range []struct {
+		name     string
+		schema   string
+		expected []Warning
+	}{
+		{
+			name: "required property declared by another member",
+			schema: `{
+				"allOf": [
+					{"$ref": "#/components/schemas/named"},
+					{"required": ["name"], "properties": {"age": {"type": "integer"}}}
+				],
+				"required": ["age"]
+			}`,
+		},
+		{
+			name: "required property only declared by a oneOf alternative",
+			schema: `{
+				"allOf": [
+					{"$ref": "#/components/schemas/named"},
+					{"required": ["name", "email"]}
+				],
+				"oneOf": [
+					{"properties": {"email": {"type": "string"}}},
+					{"properties": {"phone": {"type": "string"}}}
+				]
+			}`,
+			expected: []Warning{
+				{Path: "/allOf/1", Message: `required property "email" is not declared by any member of allOf`},
+			},
+		},
+		{
+			name: "nested composition",
+			schema: `{
+				"properties": {
+					"owner": {"allOf": [{"required": ["id"]}]}
+				}
+			}`,
+			expected: []Warning{
+				{Path: "/properties/owner/allOf/0", Message: `required property "id" is not declared by any member of allOf`},
+			},
+		},
+		{
+			name:   "unresolved $ref",
+			schema: `{"allOf": [{"$ref": "#/components/schemas/missing"}, {"required": ["name"]}]}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var schema Schema
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+
+			assert.Equal(t, tc.expected, schema.ValidateCompositionRequired(root))
+		})
+	}
+}
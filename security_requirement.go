@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// SecurityRequirement lists the security schemes required to execute an operation,
+// with the scopes needed for each scheme.
+//
+// Scopes are relevant to oauth2 and openIdConnect schemes: for other schemes,
+// the list is empty or contains role names.
+//
+// An empty requirement means that no authentication is needed.
+//
+// For more information: https://spec.openapis.org/oas/v3.1.0#security-requirement-object
+type SecurityRequirement map[string][]string
+
+// NoSecurity is the empty requirement, which makes security optional when listed with other requirements.
+func NoSecurity() SecurityRequirement {
+	return SecurityRequirement{}
+}
+
+// WithSecurity adds security requirements that apply to all operations of the API.
+func (s *Swagger) WithSecurity(reqs ...SecurityRequirement) *Swagger {
+	for _, req := range reqs {
+		s.Security = append(s.Security, req)
+	}
+	return s
+}
+
+// ValidateSecurity checks that every security scheme named in a security requirement,
+// at the document level or on any operation, is defined.
+//
+// Schemes are defined in components.securitySchemes, or securityDefinitions for Swagger 2.0 specs.
+// Each undefined scheme is reported as a *ValidationError located by a JSON pointer.
+func (s *Swagger) ValidateSecurity() []error {
+	var errs []error
+
+	validate := func(requirements []map[string][]string, location string) {
+		for i, req := range requirements {
+			for _, name := range slices.Sorted(maps.Keys(req)) {
+				if s.hasSecurityScheme(name) {
+					continue
+				}
+
+				errs = append(errs, &ValidationError{
+					Path:    location + pointerTo("security", strconv.Itoa(i), name),
+					Message: fmt.Sprintf("security scheme %q is not defined", name),
+				})
+			}
+		}
+	}
+
+	validate(s.Security, "")
+
+	for key, op := range s.Operations() {
+		validate(op.Security, key.Pointer())
+	}
+
+	for key, op := range s.WebhookOperations() {
+		validate(op.Security, key.Pointer())
+	}
+
+	return errs
+}
+
+func (s *Swagger) hasSecurityScheme(name string) bool {
+	if s.Components != nil {
+		if _, ok := s.Components.SecuritySchemes[name]; ok {
+			return true
+		}
+	}
+
+	_, ok := s.SecurityDefinitions[name]
+
+	return ok
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSecurityRequirement(t *testing.T) {
+	t.Run("should require oauth2 with scopes on an operation", func(t *testing.T) {
+		op := new(Operation).
+			WithID("listPets").
+			WithSecurity(SecurityRequirement{"oauth2": {"read:pets", "write:pets"}})
+
+		require.Len(t, op.Security, 1)
+		assert.Equal(t, []string{"read:pets", "write:pets"}, op.Security[0]["oauth2"])
+		assertSerializeJSON(t, op.OperationProps,
+			`{"operationId":"listPets","security":[{"oauth2":["read:pets","write:pets"]}]}`)
+	})
+
+	t.Run("should make security optional with an empty requirement", func(t *testing.T) {
+		op := new(Operation).WithSecurity(SecurityRequirement{"apiKey": {}}, NoSecurity())
+
+		assertSerializeJSON(t, op.OperationProps, `{"security":[{"apiKey":[]},{}]}`)
+	})
+
+	t.Run("should set document level requirements", func(t *testing.T) {
+		doc := new(Swagger).WithSecurity(SecurityRequirement{"apiKey": {}})
+
+		assert.Equal(t, []map[string][]string{{"apiKey": {}}}, doc.Security)
+	})
+}
+
+func TestSwagger_ValidateSecurity(t *testing.T) {
+	doc := &Swagger{
+		SwaggerProps: SwaggerProps{
+			OpenAPI: "3.1.0",
+			Components: &Components{
+				ComponentsProps: ComponentsProps{
+					SecuritySchemes: map[string]SecurityScheme{
+						"oauth2": *OAuth2AccessToken("https://example.com/authorize", "https://example.com/token"),
+					},
+				},
+			},
+			Paths: &Paths{
+				Paths: map[string]PathItem{
+					"/pets": {
+						PathItemProps: PathItemProps{
+							Get: new(Operation).WithSecurity(
+								SecurityRequirement{"oauth2": {"read:pets", "write:pets"}},
+								NoSecurity(),
+							),
+							Post: new(Operation).WithSecurity(SecurityRequirement{"oauth2": {"write:pets"}, "apiKey": {}}),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("should accept defined schemes", func(t *testing.T) {
+		valid := *doc
+		valid.Paths = &Paths{Paths: map[string]PathItem{"/pets": {PathItemProps: PathItemProps{Get: doc.Paths.Paths["/pets"].Get}}}}
+
+		assert.Empty(t, valid.ValidateSecurity())
+	})
+
+	t.Run("should report undefined schemes", func(t *testing.T) {
+		invalid := *doc
+		invalid.WithSecurity(SecurityRequirement{"basic": {}})
+
+		errs := invalid.ValidateSecurity()
+		require.Len(t, errs, 2)
+		for _, err := range errs {
+			require.ErrorIs(t, err, ErrSpec)
+		}
+		assert.EqualError(t, errs[0], `/security/0/basic: security scheme "basic" is not defined`)
+		assert.EqualError(t, errs[1], `/paths/~1pets/post/security/0/apiKey: security scheme "apiKey" is not defined`)
+	})
+
+	t.Run("should resolve Swagger 2.0 security definitions", func(t *testing.T) {
+		legacy := new(Swagger).WithSecurity(SecurityRequirement{"basic": {}})
+		legacy.SecurityDefinitions = SecurityDefinitions{"basic": BasicAuth()}
+
+		assert.Empty(t, legacy.ValidateSecurity())
+	})
+}
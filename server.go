@@ -5,6 +5,9 @@ package spec
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/go-openapi/jsonpointer"
 	"github.com/go-openapi/swag/jsonutils"
@@ -99,3 +102,95 @@ func (s *Server) UnmarshalJSON(data []byte) error {
 	}
 	return json.Unmarshal(data, &s.VendorExtensible)
 }
+
+// ResolveURL substitutes variables into the templated URL of this server.
+//
+// Variables which are not supplied take their default value. Values of variables
+// constrained by an enum must be members of this enum.
+func (s Server) ResolveURL(vars map[string]string) (string, error) {
+	var resolved strings.Builder
+
+	template := s.URL
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			resolved.WriteString(template)
+
+			break
+		}
+
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable in %q: %w", s.URL, ErrServerURL)
+		}
+		end += start
+
+		value, err := s.variableValue(template[start+1:end], vars)
+		if err != nil {
+			return "", err
+		}
+
+		resolved.WriteString(template[:start])
+		resolved.WriteString(value)
+		template = template[end+1:]
+	}
+
+	return resolved.String(), nil
+}
+
+func (s Server) variableValue(name string, vars map[string]string) (string, error) {
+	variable, isDeclared := s.Variables[name]
+	value, isSupplied := vars[name]
+
+	switch {
+	case !isDeclared && !isSupplied:
+		return "", fmt.Errorf("variable %q is not defined: %w", name, ErrServerURL)
+	case !isDeclared:
+		return value, nil
+	case !isSupplied:
+		return variable.Default, nil
+	}
+
+	if len(variable.Enum) > 0 && !slices.Contains(variable.Enum, value) {
+		return "", fmt.Errorf("value %q for variable %q is not one of %v: %w", value, name, variable.Enum, ErrServerURL)
+	}
+
+	return value, nil
+}
+
+// ResolveServerURL returns the concrete URL of the server at index in the servers of this document,
+// with variables substituted as per Server.ResolveURL.
+func (s *Swagger) ResolveServerURL(index int, vars map[string]string) (string, error) {
+	if index < 0 || index >= len(s.Servers) {
+		return "", fmt.Errorf("no server at index %d: %w", index, ErrServerURL)
+	}
+
+	return s.Servers[index].ResolveURL(vars)
+}
+
+// EffectiveServers returns the servers which apply to an operation of this document.
+//
+// Servers set on the operation override the servers of its path item, which override the servers
+// of the document. Levels which do not exist, such as an undefined method, are skipped.
+// When no servers are set at all, the default is a single server with URL "/", as specified by OpenAPI 3.x.
+func (s *Swagger) EffectiveServers(path, method string) []Server {
+	if s.Paths != nil {
+		if item, ok := s.Paths.Paths[path]; ok {
+			for m, op := range item.operations() {
+				if m == strings.ToLower(method) && len(op.Servers) > 0 {
+					return op.Servers
+				}
+			}
+
+			if len(item.Servers) > 0 {
+				return item.Servers
+			}
+		}
+	}
+
+	if len(s.Servers) > 0 {
+		return s.Servers
+	}
+
+	return []Server{{ServerProps: ServerProps{URL: "/"}}}
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSwagger_ResolveServerURL(t *testing.T) {
+	var doc Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"servers": [
+			{"url": "https://api.example.com/v1"},
+			{
+				"url": "https://{region}.example.com:{port}/{basePath}",
+				"variables": {
+					"region": {"enum": ["eu", "us"], "default": "eu"},
+					"port": {"default": "443"},
+					"basePath": {"default": "v2"}
+				}
+			},
+			{"url": "https://{host}/{unterminated"}
+		]
+	}`), &doc))
+
+	t.Run("should return a URL without variables as is", func(t *testing.T) {
+		resolved, err := doc.ResolveServerURL(0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "https://api.example.com/v1", resolved)
+	})
+
+	t.Run("should apply defaults to variables which are not supplied", func(t *testing.T) {
+		resolved, err := doc.ResolveServerURL(1, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "https://eu.example.com:443/v2", resolved)
+	})
+
+	t.Run("should substitute supplied variables", func(t *testing.T) {
+		resolved, err := doc.ResolveServerURL(1, map[string]string{"region": "us", "port": "8443"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://us.example.com:8443/v2", resolved)
+	})
+
+	t.Run("should fail when a server URL cannot be resolved", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			index    int
+			vars     map[string]string
+			expected string
+		}{
+			{
+				name:     "value out of enum",
+				index:    1,
+				vars:     map[string]string{"region": "ap"},
+				expected: `value "ap" for variable "region" is not one of [eu us]`,
+			},
+			{
+				name:     "undefined variable",
+				index:    2,
+				expected: `variable "host" is not defined`,
+			},
+			{
+				name:     "unterminated variable",
+				index:    2,
+				vars:     map[string]string{"host": "localhost"},
+				expected: `unterminated variable in "https://{host}/{unterminated"`,
+			},
+			{
+				name:     "index out of range",
+				index:    3,
+				expected: `no server at index 3`,
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := doc.ResolveServerURL(tc.index, tc.vars)
+				require.ErrorIs(t, err, ErrServerURL)
+				assert.ErrorContains(t, err, tc.expected)
+			})
+		}
+	})
+}
+
+func TestSwagger_EffectiveServers(t *testing.T) {
+	server := func(url string) Server { return Server{ServerProps: ServerProps{URL: url}} }
+
+	get := new(Operation)
+	get.Servers = []Server{server("https://read.example.com")}
+
+	item := new(PathItem).
+		WithSummary("pets").
+		WithDescription("all about pets").
+		WithServers(server("https://pets.example.com"))
+	item.Get = get
+	item.Post = new(Operation)
+
+	doc := &Swagger{SwaggerProps: SwaggerProps{
+		Servers: []Server{server("https://api.example.com")},
+		Paths: &Paths{Paths: map[string]PathItem{
+			"/pets":   *item,
+			"/stores": {PathItemProps: PathItemProps{Get: new(Operation)}},
+		}},
+	}}
+
+	for _, tc := range []struct {
+		name, path, method, expected string
+	}{
+		{name: "operation servers", path: "/pets", method: "GET", expected: "https://read.example.com"},
+		{name: "path item servers", path: "/pets", method: "post", expected: "https://pets.example.com"},
+		{name: "document servers", path: "/stores", method: "get", expected: "https://api.example.com"},
+		{name: "undefined path", path: "/users", method: "get", expected: "https://api.example.com"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			servers := doc.EffectiveServers(tc.path, tc.method)
+			require.Len(t, servers, 1)
+			assert.Equal(t, tc.expected, servers[0].URL)
+		})
+	}
+
+	t.Run("should default to the root URL", func(t *testing.T) {
+		servers := new(Swagger).EffectiveServers("/pets", "get")
+		require.Len(t, servers, 1)
+		assert.Equal(t, "/", servers[0].URL)
+	})
+
+	t.Run("should serialize path item summary, description and servers", func(t *testing.T) {
+		assertSerializeJSON(t, PathItem{PathItemProps: PathItemProps{Summary: "pets", Description: "all about pets", Servers: item.Servers}},
+			`{"summary":"pets","description":"all about pets","servers":[{"url":"https://pets.example.com"}]}`)
+	})
+}
@@ -20,6 +20,8 @@ const (
 	SwaggerSchemaURL = "http://swagger.io/v2/schema.json#"
 	// JSONSchemaURL the url for the json schema
 	JSONSchemaURL = "http://json-schema.org/draft-04/schema#"
+	// JSONSchema2020URL the url for the json schema draft 2020-12
+	JSONSchema2020URL = "https://json-schema.org/draft/2020-12/schema"
 	// OpenAPIVersion the default OpenAPI version to use
 	OpenAPIVersion = "3.2.0"
 )
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxMinorVersion is the latest minor version of OpenAPI 3 supported, see OpenAPIVersion.
+const maxMinorVersion = 2
+
+// SpecVersion parses the OpenAPI version of the document, e.g. 3 and 1 for "3.1.0".
+//
+// OpenAPI 3.0 to 3.2 documents are supported, 3.2 being the OpenAPIVersion of this package. Other versions,
+// including Swagger 2.0 documents, produce an error which matches errors.ErrUnsupported. A missing or malformed
+// version produces an error which matches ErrSpec.
+func (s *Swagger) SpecVersion() (major, minor int, err error) {
+	if (s.OpenAPI == "" && s.Swagger != "") || strings.HasPrefix(s.OpenAPI, "2.") {
+		return 0, 0, fmt.Errorf("version %q is not supported: use the Swagger 2.0 package github.com/go-openapi/spec: %w",
+			cmp.Or(s.OpenAPI, s.Swagger), errors.ErrUnsupported)
+	}
+	if s.OpenAPI == "" {
+		return 0, 0, fmt.Errorf("the OpenAPI version is required: %w", ErrSpec)
+	}
+
+	parts := strings.SplitN(s.OpenAPI, ".", 3)
+	if len(parts) >= 2 {
+		major, err = strconv.Atoi(parts[0])
+		if err == nil {
+			minor, err = strconv.Atoi(parts[1])
+		}
+	}
+	if len(parts) < 2 || err != nil {
+		return 0, 0, fmt.Errorf("invalid OpenAPI version %q: %w", s.OpenAPI, ErrSpec)
+	}
+
+	if major != 3 || minor > maxMinorVersion {
+		return 0, 0, fmt.Errorf("OpenAPI version %q is not supported: %w", s.OpenAPI, errors.ErrUnsupported)
+	}
+
+	return major, minor, nil
+}
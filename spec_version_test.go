@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSwagger_SpecVersion(t *testing.T) {
+	for _, tc := range []struct {
+		version      string
+		major, minor int
+	}{
+		{version: "3.1.0", major: 3, minor: 1},
+		{version: "3.0.3", major: 3, minor: 0},
+		{version: "3.1", major: 3, minor: 1},
+		{version: OpenAPIVersion, major: 3, minor: 2},
+	} {
+		t.Run("should parse "+tc.version, func(t *testing.T) {
+			doc := Swagger{SwaggerProps: SwaggerProps{OpenAPI: tc.version}}
+
+			major, minor, err := doc.SpecVersion()
+			require.NoError(t, err)
+			assert.Equal(t, tc.major, major)
+			assert.Equal(t, tc.minor, minor)
+		})
+	}
+
+	t.Run("should reject Swagger 2.0", func(t *testing.T) {
+		for _, doc := range []Swagger{
+			{SwaggerProps: SwaggerProps{Swagger: "2.0"}},
+			{SwaggerProps: SwaggerProps{OpenAPI: "2.0"}},
+		} {
+			_, _, err := doc.SpecVersion()
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, errors.ErrUnsupported))
+			assert.ErrorContains(t, err, "github.com/go-openapi/spec")
+		}
+	})
+
+	t.Run("should reject unsupported versions", func(t *testing.T) {
+		_, _, err := (&Swagger{SwaggerProps: SwaggerProps{OpenAPI: "4.0.0"}}).SpecVersion()
+		assert.True(t, errors.Is(err, errors.ErrUnsupported))
+	})
+
+	t.Run("should reject missing or malformed versions", func(t *testing.T) {
+		for _, version := range []string{"", "3", "three.one", "3.x.0"} {
+			_, _, err := (&Swagger{SwaggerProps: SwaggerProps{OpenAPI: version}}).SpecVersion()
+			require.ErrorIsf(t, err, ErrSpec, "version %q", version)
+		}
+	})
+}
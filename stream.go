@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var _ io.WriterTo = &Swagger{}
+
+// WriteTo writes the document to w as JSON, like json.Marshal but without building the whole document in memory.
+//
+// The document is written member by member: paths, webhooks and the sections of components or definitions
+// are written one entry at a time, so that the memory used is bounded by their largest entry.
+// This is useful to serve large documents, e.g. fully expanded ones.
+//
+// It returns the number of bytes written.
+func (s *Swagger) WriteTo(w io.Writer) (int64, error) {
+	j := &jsonStream{w: w}
+	j.object(func(o *jsonObject) {
+		o.fields(reflect.ValueOf(s.SwaggerProps), j.value)
+		o.members(s.VendorExtensible)
+	})
+
+	return j.n, j.err
+}
+
+// jsonStream writes JSON values to a writer, keeping track of the number of bytes written and of the first error.
+type jsonStream struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (j *jsonStream) write(b []byte) {
+	if j.err != nil {
+		return
+	}
+
+	n, err := j.w.Write(b)
+	j.n += int64(n)
+	j.err = err
+}
+
+func (j *jsonStream) marshal(value any) {
+	if j.err != nil {
+		return
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		j.err = err
+
+		return
+	}
+	j.write(b)
+}
+
+// value writes a member of the document, streaming its entries when it is a large map.
+func (j *jsonStream) value(value reflect.Value) {
+	switch v := value.Interface().(type) {
+	case *Paths:
+		if v == nil {
+			j.marshal(nil)
+
+			return
+		}
+
+		j.object(func(o *jsonObject) {
+			o.members(v.VendorExtensible)
+			for _, key := range slices.Sorted(maps.Keys(v.Paths)) {
+				if strings.HasPrefix(key, "/") {
+					o.member(key, v.Paths[key])
+				}
+			}
+		})
+	case *Components:
+		if v == nil {
+			j.marshal(nil)
+
+			return
+		}
+
+		j.object(func(o *jsonObject) {
+			o.fields(reflect.ValueOf(v.ComponentsProps), j.value)
+			o.members(v.VendorExtensible)
+		})
+	default:
+		if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String || value.IsNil() {
+			j.marshal(v)
+
+			return
+		}
+
+		keys := value.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+
+		j.object(func(o *jsonObject) {
+			for _, key := range keys {
+				o.member(key.String(), value.MapIndex(key).Interface())
+			}
+		})
+	}
+}
+
+// object writes a JSON object, with the members written by fn.
+func (j *jsonStream) object(fn func(*jsonObject)) {
+	j.write([]byte("{"))
+	fn(&jsonObject{stream: j})
+	j.write([]byte("}"))
+}
+
+// jsonObject writes the members of a JSON object.
+type jsonObject struct {
+	stream  *jsonStream
+	started bool
+}
+
+// separate writes the comma before a member, unless it's the first one.
+func (o *jsonObject) separate() {
+	if o.started {
+		o.stream.write([]byte(","))
+	}
+	o.started = true
+}
+
+func (o *jsonObject) key(key string) {
+	o.separate()
+	o.stream.marshal(key)
+	o.stream.write([]byte(":"))
+}
+
+func (o *jsonObject) member(key string, value any) {
+	o.key(key)
+	o.stream.marshal(value)
+}
+
+// members writes the members of a value marshaled as a JSON object, e.g. vendor extensions.
+func (o *jsonObject) members(value any) {
+	if o.stream.err != nil {
+		return
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		o.stream.err = err
+
+		return
+	}
+
+	const minLengthIfNotEmpty = 3
+	if len(b) < minLengthIfNotEmpty || b[0] != '{' {
+		return
+	}
+
+	o.separate()
+	o.stream.write(b[1 : len(b)-1])
+}
+
+// fields writes the fields of a struct in the same way as json.Marshal, writing their values with write.
+func (o *jsonObject) fields(value reflect.Value, write func(reflect.Value)) {
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldValue := value.Field(i)
+		if slices.Contains(strings.Split(options, ","), "omitempty") && isEmptyJSON(fieldValue) {
+			continue
+		}
+
+		o.key(name)
+		write(fieldValue)
+	}
+}
+
+// isEmptyJSON tells whether a value is omitted by the omitempty option of json.Marshal.
+func isEmptyJSON(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Struct:
+		return false
+	default:
+		return value.IsZero()
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestSwagger_WriteTo(t *testing.T) {
+	t.Run("should stream the same JSON as json.Marshal", func(t *testing.T) {
+		for _, fixture := range []string{
+			filepath.Join("fixtures", "expansion", "all-the-things.json"),
+			filepath.Join("fixtures", "expansion", "callbacks.json"),
+			filepath.Join("fixtures", "validation", "invalid.json"),
+		} {
+			t.Run(fixture, func(t *testing.T) {
+				data, err := os.ReadFile(fixture)
+				require.NoError(t, err)
+
+				var doc Swagger
+				require.NoError(t, json.Unmarshal(data, &doc))
+				require.NoError(t, ExpandSpec(&doc, nil))
+
+				expected, err := json.Marshal(&doc)
+				require.NoError(t, err)
+
+				var b bytes.Buffer
+				n, err := doc.WriteTo(&b)
+				require.NoError(t, err)
+				assert.Equal(t, string(expected), b.String())
+				assert.Equal(t, int64(b.Len()), n)
+			})
+		}
+	})
+
+	t.Run("should stream empty documents and extensions", func(t *testing.T) {
+		doc := Swagger{
+			VendorExtensible: VendorExtensible{Extensions: Extensions{"x-generator": "<none>"}},
+			SwaggerProps:     SwaggerProps{Components: &Components{}, Webhooks: map[string]PathItem{}},
+		}
+
+		expected, err := json.Marshal(&doc)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		n, err := doc.WriteTo(&b)
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), b.String())
+		assert.Equal(t, int64(len(expected)), n)
+	})
+
+	t.Run("should return the error of the writer", func(t *testing.T) {
+		_, err := new(Swagger).WriteTo(failingWriter{})
+		require.Error(t, err)
+	})
+}
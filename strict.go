@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// JSON Schema 2020-12 keywords which are not modeled by Schema, but kept in its ExtraProps
+var schemaExtraKeywords = []string{
+	"$ref", "$schema", "$id", "$anchor", "$dynamicRef", "$dynamicAnchor", "$comment", "$vocabulary",
+	"examples", "prefixItems",
+	"if", "then", "else", "dependentSchemas", "dependentRequired",
+	"unevaluatedItems", "unevaluatedProperties", "contentEncoding", "contentMediaType", "contentSchema",
+}
+
+var (
+	documentFields  = knownFields([]reflect.Type{reflect.TypeFor[SwaggerProps]()})
+	operationFields = knownFields([]reflect.Type{reflect.TypeFor[OperationProps]()})
+	schemaFields    = knownFields(
+		[]reflect.Type{reflect.TypeFor[SchemaProps](), reflect.TypeFor[SwaggerSchemaProps]()},
+		schemaExtraKeywords...,
+	)
+)
+
+// LoadStrict reads a JSON spec document from r, like LoadFromReader, and rejects unknown fields.
+//
+// Fields are checked on the document itself, on its operations, and on its schemas, i.e. the schemas of
+// components, definitions, parameters, request bodies and responses, and the schemas nested in them.
+// Vendor extensions ("x-" fields) are always accepted, as well as the JSON Schema 2020-12 keywords
+// which schemas keep as extra properties.
+//
+// Unknown fields are reported as a *MultiError, with a *ValidationError located by a JSON pointer to each field.
+func LoadStrict(r io.Reader) (*Swagger, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &ParseError{Err: err}
+	}
+
+	doc, err := LoadFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var generic map[string]any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, &ParseError{Err: err}
+	}
+
+	c := &strictChecker{}
+	c.document(generic)
+	if len(c.errs) > 0 {
+		return nil, NewMultiError(c.errs...)
+	}
+
+	return doc, nil
+}
+
+// knownFields collects the JSON names of the fields of structs, including embedded ones.
+func knownFields(types []reflect.Type, extra ...string) map[string]struct{} {
+	fields := make(map[string]struct{})
+	for _, name := range extra {
+		fields[name] = struct{}{}
+	}
+
+	for _, typ := range types {
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				maps.Copy(fields, knownFields([]reflect.Type{field.Type}))
+
+				continue
+			}
+
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name != "" && name != "-" {
+				fields[name] = struct{}{}
+			}
+		}
+	}
+
+	return fields
+}
+
+type strictChecker struct {
+	errs []error
+}
+
+func (c *strictChecker) document(doc map[string]any) {
+	c.fields(doc, documentFields, "")
+
+	genericWalker{
+		operation: func(op map[string]any, location string) { c.fields(op, operationFields, location) },
+		schema:    func(schema map[string]any, location string) { c.fields(schema, schemaFields, location) },
+	}.document(doc)
+}
+
+func (c *strictChecker) fields(object map[string]any, known map[string]struct{}, location string) {
+	for _, key := range slices.Sorted(maps.Keys(object)) {
+		if _, ok := known[key]; ok || strings.HasPrefix(strings.ToLower(key), "x-") {
+			continue
+		}
+
+		c.errs = append(c.errs, &ValidationError{
+			Path:    location + pointerTo(key),
+			Message: fmt.Sprintf("unknown field %q", key),
+		})
+	}
+}
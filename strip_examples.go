@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+// StripExamples removes all the examples of the document, e.g. to reduce the size of a published spec.
+//
+// This removes the "example" and "examples" of schemas, parameters, headers and media types, the Swagger 2.0
+// "examples" of responses, as well as the examples of components. Since examples are only referred to by
+// the "examples" of parameters and media types, no $ref to an example is left in the document.
+func (s *Swagger) StripExamples() {
+	v := schemaVisitor{
+		fn: func(_ string, schema *Schema) error {
+			schema.Example = nil
+			delete(schema.ExtraProps, "examples")
+
+			return nil
+		},
+		object: stripExamples,
+	}
+	_ = v.document(s) // never fails, since fn never does
+
+	if s.Components != nil {
+		s.Components.Examples = nil
+	}
+}
+
+func stripExamples(object any) {
+	switch o := object.(type) {
+	case *Parameter:
+		o.Example = nil
+		o.Examples = nil
+		stripItemsExamples(o.Items)
+	case *Header:
+		o.Example = nil
+		stripItemsExamples(o.Items)
+	case *MediaType:
+		o.Example = nil
+		o.Examples = nil
+	case *Response:
+		o.Examples = nil
+	}
+}
+
+func stripItemsExamples(items *Items) {
+	for ; items != nil; items = items.Items {
+		items.Example = nil
+	}
+}
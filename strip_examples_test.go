@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSwagger_StripExamples(t *testing.T) {
+	const doc = `{
+		"openapi": "3.1.0",
+		"info": {"title": "pets", "version": "1.0"},
+		"components": {
+			"schemas": {
+				"pet": {
+					"type": "object",
+					"example": {"name": "rex"},
+					"properties": {
+						"name": {"type": "string", "examples": ["rex", "tom"]}
+					}
+				}
+			},
+			"parameters": {
+				"limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}, "example": 10}
+			},
+			"headers": {
+				"rate": {"schema": {"type": "integer"}, "example": 100}
+			},
+			"examples": {
+				"rex": {"summary": "a dog", "value": [{"name": "rex"}]}
+			}
+		},
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [
+						{"$ref": "#/components/parameters/limit"},
+						{"name": "tag", "in": "query", "schema": {"type": "string"}, "examples": {"dog": {"value": "dog"}}}
+					],
+					"responses": {
+						"200": {
+							"description": "ok",
+							"headers": {"X-Rate-Limit": {"$ref": "#/components/headers/rate"}},
+							"content": {
+								"application/json": {
+									"schema": {"type": "array", "items": {"$ref": "#/components/schemas/pet"}},
+									"examples": {"rex": {"$ref": "#/components/examples/rex"}}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	var spec Swagger
+	require.NoError(t, json.Unmarshal([]byte(doc), &spec))
+	require.Empty(t, spec.ValidateExamples())
+
+	spec.StripExamples()
+
+	t.Run("should remove all examples", func(t *testing.T) {
+		stripped, err := json.Marshal(&spec)
+		require.NoError(t, err)
+
+		assert.False(t, bytes.Contains(stripped, []byte(`"example"`)), string(stripped))
+		assert.False(t, bytes.Contains(stripped, []byte(`"examples"`)), string(stripped))
+		assert.False(t, bytes.Contains(stripped, []byte(`#/components/examples`)), string(stripped))
+	})
+
+	t.Run("should keep the rest of the document", func(t *testing.T) {
+		pet := spec.Components.Schemas["pet"]
+		assert.Equal(t, StringOrArray{"string"}, pet.Properties["name"].Type)
+
+		op := spec.Paths.Paths["/pets"].Get
+		require.Len(t, op.Parameters, 2)
+		assert.Equal(t, "tag", op.Parameters[1].Name)
+		media := op.Responses.StatusCodeResponses[200].Content["application/json"]
+		require.NotNil(t, media.Schema)
+		assert.Equal(t, "#/components/schemas/pet", media.Schema.Items.Schema.Ref.String())
+	})
+
+	t.Run("should still be a valid document", func(t *testing.T) {
+		stripped, err := json.Marshal(&spec)
+		require.NoError(t, err)
+
+		reloaded, err := LoadStrict(bytes.NewReader(stripped))
+		require.NoError(t, err)
+		assert.Empty(t, reloaded.ValidateExamples())
+		assert.Empty(t, reloaded.ValidatePathParameters())
+		require.NoError(t, ExpandSpec(reloaded, nil))
+	})
+}
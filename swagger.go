@@ -88,6 +88,26 @@ func (s Swagger) MarshalJSON() ([]byte, error) {
 	return jsonutils.ConcatJSON(b1, b2), nil
 }
 
+// MarshalIndent is like MarshalJSON, but produces an indented JSON document.
+//
+// Each JSON element begins on a new line starting with prefix followed by one or more
+// copies of indent according to the nesting level, as with json.MarshalIndent.
+func (s *Swagger) MarshalIndent(prefix, indent string) ([]byte, error) {
+	compact, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	// the JSON produced by our custom marshalers is concatenated from several parts:
+	// reformat it from scratch rather than relying on the layout of any part
+	var b bytes.Buffer
+	if err := json.Indent(&b, compact, prefix, indent); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
 // UnmarshalJSON unmarshals a swagger spec from json
 func (s *Swagger) UnmarshalJSON(data []byte) error {
 	var sw Swagger
@@ -483,7 +503,8 @@ func (s *SchemaOrArray) UnmarshalJSON(data []byte) error {
 	if len(data) > 1 {
 		first = data[0]
 	}
-	if first == '{' {
+	if first == '{' || first == 't' || first == 'f' {
+		// boolean schemas are unmarshaled as TrueSchema or FalseSchema
 		var sch Schema
 		if err := json.Unmarshal(data, &sch); err != nil {
 			return err
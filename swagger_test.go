@@ -336,6 +336,60 @@ func TestVendorExtensionStringSlice(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestVendorExtensible_DecodeExtension(t *testing.T) {
+	type goType struct {
+		Import struct {
+			Package string `json:"package"`
+			Alias   string `json:"alias,omitempty"`
+		} `json:"import"`
+		Type  string   `json:"type"`
+		Hints []string `json:"hints,omitempty"`
+	}
+
+	var schema Schema
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "string",
+		"x-go-type": {"import": {"package": "github.com/example/ids"}, "type": "ID", "hints": ["nullable"]},
+		"X-Go-Name": "Identifier",
+		"x-order": "first"
+	}`), &schema))
+
+	t.Run("should decode an object extension into a struct", func(t *testing.T) {
+		var decoded goType
+		require.NoError(t, schema.DecodeExtension("x-go-type", &decoded))
+		assert.Equal(t, "github.com/example/ids", decoded.Import.Package)
+		assert.Empty(t, decoded.Import.Alias)
+		assert.Equal(t, "ID", decoded.Type)
+		assert.Equal(t, []string{"nullable"}, decoded.Hints)
+	})
+
+	t.Run("should match keys case-insensitively", func(t *testing.T) {
+		var name string
+		require.NoError(t, schema.DecodeExtension("x-go-name", &name))
+		assert.Equal(t, "Identifier", name)
+	})
+
+	t.Run("should decode extensions set programmatically", func(t *testing.T) {
+		var ext VendorExtensible
+		ext.AddExtension("x-limits", map[string]int{"max": 3})
+
+		var limits struct{ Max int }
+		require.NoError(t, ext.DecodeExtension("x-limits", &limits))
+		assert.Equal(t, 3, limits.Max)
+	})
+
+	t.Run("should fail on a missing extension", func(t *testing.T) {
+		var decoded goType
+		err := schema.DecodeExtension("x-missing", &decoded)
+		require.ErrorIs(t, err, ErrExtensionNotFound)
+	})
+
+	t.Run("should fail on a mismatched type", func(t *testing.T) {
+		var order int
+		require.Error(t, schema.DecodeExtension("x-order", &order))
+	})
+}
+
 func TestOptionalSwaggerProps_Serialize(t *testing.T) {
 	minimalJSONSpec := []byte(`{
 	"swagger": "2.0",
@@ -449,3 +503,30 @@ func doTestSwaggerGobEncoding(t *testing.T, fixture string) {
 
 	doTestAnyGobEncoding(t, &src, &dst)
 }
+
+func TestSwaggerSpec_MarshalIndent(t *testing.T) {
+	var expected Swagger
+	require.NoError(t, json.Unmarshal([]byte(specJSON), &expected))
+
+	compact, err := json.Marshal(expected)
+	require.NoError(t, err)
+
+	indented, err := expected.MarshalIndent("", "  ")
+	require.NoError(t, err)
+
+	require.True(t, json.Valid(indented))
+	assert.Contains(t, string(indented), "\n  \"openapi\": \"3.0.0\"")
+	assert.Contains(t, string(indented), "\n    \"title\": ")
+	assert.JSONEq(t, string(compact), string(indented))
+
+	var actual Swagger
+	require.NoError(t, json.Unmarshal(indented, &actual))
+	assertSpecs(t, actual, expected)
+
+	t.Run("with prefix", func(t *testing.T) {
+		prefixed, err := expected.MarshalIndent("//", "\t")
+		require.NoError(t, err)
+
+		assert.Contains(t, string(prefixed), "\n//\t\"openapi\": \"3.0.0\"")
+	})
+}
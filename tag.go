@@ -5,6 +5,8 @@ package spec
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
 
 	"github.com/go-openapi/jsonpointer"
 	"github.com/go-openapi/swag/jsonutils"
@@ -62,3 +64,36 @@ func (t *Tag) UnmarshalJSON(data []byte) error {
 	}
 	return json.Unmarshal(data, &t.VendorExtensible)
 }
+
+// ValidateTags reports the tags listed more than once by an operation.
+//
+// Duplicate tags are not invalid, but are likely unintended: they are reported as warnings and
+// are never removed. Each duplicate is located by a JSON pointer to its position in the tags.
+func (s *Swagger) ValidateTags() []Warning {
+	var warnings []Warning
+
+	validate := func(key OperationKey, op *Operation) {
+		seen := make(map[string]int, len(op.Tags))
+		for i, tag := range op.Tags {
+			if first, duplicate := seen[tag]; duplicate {
+				warnings = append(warnings, Warning{
+					Path:    key.Pointer() + pointerTo("tags", strconv.Itoa(i)),
+					Message: fmt.Sprintf("tag %q duplicates the tag at index %d", tag, first),
+				})
+
+				continue
+			}
+			seen[tag] = i
+		}
+	}
+
+	for key, op := range s.Operations() {
+		validate(key, op)
+	}
+
+	for key, op := range s.WebhookOperations() {
+		validate(key, op)
+	}
+
+	return warnings
+}
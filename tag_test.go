@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestOperation_Tags(t *testing.T) {
+	t.Run("should append tags in order, with duplicates", func(t *testing.T) {
+		op := new(Operation).AddTag("pets").AddTag("admin").AddTag("pets")
+		assert.Equal(t, []string{"pets", "admin", "pets"}, op.Tags)
+
+		op.SetTags("store", "pets")
+		assert.Equal(t, []string{"store", "pets"}, op.Tags)
+	})
+
+	t.Run("should warn about duplicate tags", func(t *testing.T) {
+		doc := &Swagger{SwaggerProps: SwaggerProps{
+			Paths: &Paths{Paths: map[string]PathItem{
+				"/pets": {PathItemProps: PathItemProps{
+					Get:  new(Operation).AddTag("pets").AddTag("admin").AddTag("pets"),
+					Post: new(Operation).SetTags("pets", "admin"),
+				}},
+			}},
+		}}
+
+		warnings := doc.ValidateTags()
+		require.Len(t, warnings, 1)
+		assert.Equal(t, `/paths/~1pets/get/tags/2: tag "pets" duplicates the tag at index 0`, warnings[0].String())
+	})
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+const (
+	// upgradedVersion is the version of specs produced by Upgrade30To31
+	upgradedVersion = "3.1.0"
+
+	// schemaDialect31 is the default JSON Schema dialect of OpenAPI 3.1 schemas
+	schemaDialect31 = "https://spec.openapis.org/oas/3.1/dialect/base"
+)
+
+// Upgrade30To31 converts an OpenAPI 3.0 spec into an OpenAPI 3.1 spec, leaving the original spec untouched.
+//
+// The constructs of 3.0 schemas are converted to their 3.1 equivalent:
+//   - "nullable: true" adds "null" to the type of the schema, and to its enum if any
+//   - "example" becomes a single value "examples" array
+//
+// The version of the spec becomes 3.1.0, and its jsonSchemaDialect defaults to the OpenAPI 3.1 dialect.
+//
+// Upgrading an OpenAPI 3.1 or later spec returns an unchanged copy, so that upgrading twice yields the same result.
+// It fails on specs which do not declare a supported OpenAPI 3.x version.
+func Upgrade30To31(spec *Swagger) (*Swagger, error) {
+	_, minor, err := spec.SpecVersion()
+	if err != nil {
+		return nil, fmt.Errorf("cannot upgrade to %s: %w", upgradedVersion, err)
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if minor > 0 {
+		unchanged := new(Swagger)
+		if err := json.Unmarshal(data, unchanged); err != nil {
+			return nil, err
+		}
+
+		return unchanged, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	doc["openapi"] = upgradedVersion
+	if _, ok := doc["jsonSchemaDialect"]; !ok {
+		doc["jsonSchemaDialect"] = schemaDialect31
+	}
+	if _, ok := doc["components"]; ok {
+		// definitions mirror the schemas of the components, and are restored from them on unmarshaling
+		delete(doc, "definitions")
+	}
+
+	genericWalker{schema: upgradeSchema}.document(doc)
+
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	upgraded := new(Swagger)
+	if err := json.Unmarshal(data, upgraded); err != nil {
+		return nil, err
+	}
+
+	return upgraded, nil
+}
+
+func upgradeSchema(schema map[string]any, _ string) {
+	if nullable, ok := schema["nullable"].(bool); ok {
+		delete(schema, "nullable")
+		if nullable {
+			nullableSchema(schema)
+		}
+	}
+
+	if example, ok := schema["example"]; ok {
+		delete(schema, "example")
+		if _, hasExamples := schema["examples"]; !hasExamples {
+			schema["examples"] = []any{example}
+		}
+	}
+
+	translateBound(schema, "maximum", "exclusiveMaximum")
+	translateBound(schema, "minimum", "exclusiveMinimum")
+
+	// JSON Schema 2020-12 describes encoded strings with content keywords rather than formats
+	switch schema["format"] {
+	case "byte":
+		delete(schema, "format")
+		schema["contentEncoding"] = "base64"
+	case "binary":
+		delete(schema, "format")
+		schema["contentMediaType"] = "application/octet-stream"
+	}
+}
+
+// nullableSchema adds "null" to the types and to the enum of a schema.
+//
+// A schema without type already accepts null values.
+func nullableSchema(schema map[string]any) {
+	switch types := schema["type"].(type) {
+	case string:
+		schema["type"] = []any{types, "null"}
+	case []any:
+		if !slices.Contains(types, any("null")) {
+			schema["type"] = append(types, "null")
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, nil) {
+		schema["enum"] = append(enum, nil)
+	}
+}
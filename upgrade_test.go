@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestUpgrade30To31(t *testing.T) {
+	const spec30 = `{
+		"openapi": "3.0.3",
+		"info": {"title": "pets", "version": "1.0"},
+		"components": {
+			"schemas": {
+				"Name": {"type": "string", "nullable": true, "example": "rex"},
+				"Kind": {"type": "string", "nullable": true, "enum": ["cat", "dog"]},
+				"Age": {"type": "integer", "nullable": false},
+				"Price": {"type": "number", "minimum": 0, "exclusiveMinimum": true, "maximum": 100},
+				"Avatar": {"type": "string", "format": "byte"},
+				"Upload": {"type": "string", "format": "binary"}
+			}
+		},
+		"paths": {
+			"/pets": {
+				"get": {
+					"responses": {
+						"200": {"content": {"application/json": {"schema": {"type": "array", "nullable": true, "items": {"$ref": "#/components/schemas/Name"}}}}}
+					}
+				}
+			}
+		}
+	}`
+
+	var doc Swagger
+	require.NoError(t, json.Unmarshal([]byte(spec30), &doc))
+
+	upgraded, err := Upgrade30To31(&doc)
+	require.NoError(t, err)
+	require.NotNil(t, upgraded)
+
+	t.Run("should update the version and the schema dialect", func(t *testing.T) {
+		assert.Equal(t, "3.1.0", upgraded.OpenAPI)
+		assert.Equal(t, "https://spec.openapis.org/oas/3.1/dialect/base", upgraded.JSONSchemaDialect)
+		assert.Equal(t, "3.0.3", doc.OpenAPI)
+	})
+
+	t.Run("should convert nullable to type arrays", func(t *testing.T) {
+		name := upgraded.Components.Schemas["Name"]
+		assert.Equal(t, StringOrArray{"string", "null"}, name.Type)
+		assert.Nil(t, name.Nullable)
+
+		kind := upgraded.Components.Schemas["Kind"]
+		assert.Equal(t, StringOrArray{"string", "null"}, kind.Type)
+		assert.Equal(t, []any{"cat", "dog", nil}, kind.Enum)
+
+		age := upgraded.Components.Schemas["Age"]
+		assert.Equal(t, StringOrArray{"integer"}, age.Type)
+		assert.Nil(t, age.Nullable)
+
+		schema := upgraded.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Content["application/json"].Schema
+		require.NotNil(t, schema)
+		assert.Equal(t, StringOrArray{"array", "null"}, schema.Type)
+	})
+
+	t.Run("should convert example to examples", func(t *testing.T) {
+		name := upgraded.Components.Schemas["Name"]
+		assert.Nil(t, name.Example)
+		assert.Equal(t, []any{"rex"}, name.ExtraProps["examples"])
+	})
+
+	t.Run("should convert boolean exclusive bounds to numbers", func(t *testing.T) {
+		price := upgraded.Components.Schemas["Price"]
+		assert.Nil(t, price.Minimum)
+		assert.False(t, price.ExclusiveMinimum)
+		assert.Equal(t, float64Ptr(0), price.ExclusiveMinValue)
+		assert.Equal(t, float64Ptr(100), price.Maximum)
+	})
+
+	t.Run("should convert byte and binary formats to content keywords", func(t *testing.T) {
+		avatar := upgraded.Components.Schemas["Avatar"]
+		assert.Empty(t, avatar.Format)
+		assert.Equal(t, "base64", avatar.ExtraProps["contentEncoding"])
+
+		upload := upgraded.Components.Schemas["Upload"]
+		assert.Empty(t, upload.Format)
+		assert.Equal(t, "application/octet-stream", upload.ExtraProps["contentMediaType"])
+	})
+
+	t.Run("should be idempotent", func(t *testing.T) {
+		twice, err := Upgrade30To31(upgraded)
+		require.NoError(t, err)
+
+		once, err := json.Marshal(upgraded)
+		require.NoError(t, err)
+		again, err := json.Marshal(twice)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(once), string(again))
+	})
+
+	t.Run("should be reverted by Downgrade31To30", func(t *testing.T) {
+		downgraded, warnings, err := Downgrade31To30(upgraded)
+		require.NoError(t, err)
+		assert.Equal(t, []Warning{
+			{Path: "/jsonSchemaDialect", Message: "jsonSchemaDialect is not supported by OpenAPI 3.0: dropped"},
+		}, warnings)
+
+		// the only differences are the null member of the enum and the dropped non-nullable flag
+		var expected Swagger
+		require.NoError(t, json.Unmarshal([]byte(spec30), &expected))
+		kind := expected.Components.Schemas["Kind"]
+		expected.Components.Schemas["Kind"] = *kind.WithEnum("cat", "dog", nil)
+		age := expected.Components.Schemas["Age"]
+		age.Nullable = nil
+		expected.Components.Schemas["Age"] = age
+
+		want, err := json.Marshal(expected)
+		require.NoError(t, err)
+		got, err := json.Marshal(downgraded)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), string(got))
+	})
+
+	t.Run("should leave 3.1 and later specs unchanged", func(t *testing.T) {
+		for _, version := range []string{"3.1.0", "3.2.0"} {
+			var spec Swagger
+			require.NoError(t, json.Unmarshal([]byte(`{
+				"openapi": "`+version+`",
+				"components": {
+					"schemas": {
+						"Age": {"type": "integer", "nullable": true, "example": 3, "minimum": 0, "exclusiveMinimum": true}
+					}
+				}
+			}`), &spec))
+
+			unchanged, err := Upgrade30To31(&spec)
+			require.NoError(t, err)
+			assert.NotSame(t, &spec, unchanged)
+			assert.Equal(t, version, unchanged.OpenAPI)
+			assert.Empty(t, unchanged.JSONSchemaDialect)
+			assert.JSONEq(t, asJSON(t, &spec), asJSON(t, unchanged))
+		}
+	})
+
+	t.Run("should reject specs which are not 3.x", func(t *testing.T) {
+		_, err := Upgrade30To31(&Swagger{SwaggerProps: SwaggerProps{Swagger: "2.0"}})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrUnsupported))
+	})
+}